@@ -0,0 +1,473 @@
+package environments
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/utils"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/environments/utils/git"
+	"github.com/argonsecurity/go-environments/models"
+)
+
+const (
+	// CaptureBundleVersion is the version of the capture bundle format
+	CaptureBundleVersion = "1"
+
+	gitRemoteUrlCommand = "remote-url"
+	gitCommitCommand    = "commit"
+	gitBranchCommand    = "branch"
+)
+
+var (
+	secretEnvRegexp = regexp.MustCompile(`(?i)(TOKEN|SECRET|PASSWORD|PASSWD|PASSPHRASE|CREDENTIAL|PRIVATE|API_?KEY|ACCESS_?KEY)`)
+
+	// secretFileEntryRegexp matches the "key=value" and "key: value" entries of properties, yaml and json files
+	secretFileEntryRegexp = regexp.MustCompile(`(?m)^([ \t]*(?:- )?)("?)([\w.\-]+)("?[ \t]*[:=][ \t]*)(.*?)(,?)[ \t\r]*$`)
+	urlCredentialsRegexp  = regexp.MustCompile(`([a-zA-Z][a-zA-Z0-9+.\-]*\\?://)[^/\s@"']+@`)
+)
+
+const redactedValue = "[REDACTED]"
+
+// CaptureBundle holds everything that was read while building a configuration -
+// variables, the working directory, files, paths and git command outputs - so the same configuration can be built again on another machine with Replay.
+// Secret variables are left out and listed in RedactedEnvs, the values of secret keys in files are replaced,
+// and credentials are removed from URLs
+type CaptureBundle struct {
+	Version          string                `json:"version"`
+	Source           enums.Source          `json:"source"`
+	Envs             map[string]string     `json:"envs"`
+	RedactedEnvs     []string              `json:"redactedEnvs,omitempty"`
+	WorkingDirectory string                `json:"workingDirectory,omitempty"`
+	Files            map[string]string     `json:"files,omitempty"`
+	Paths            []string              `json:"paths,omitempty"`
+	Directories      []string              `json:"directories,omitempty"`
+	GitCommands      []CapturedGitCommand  `json:"gitCommands,omitempty"`
+	Configuration    *models.Configuration `json:"configuration,omitempty"`
+}
+
+// CapturedGitCommand is the output of a git command that was run while building a configuration
+type CapturedGitCommand struct {
+	Command        string `json:"command"`
+	RepositoryPath string `json:"repositoryPath"`
+	Commit         string `json:"commit,omitempty"`
+	Output         string `json:"output"`
+	Error          string `json:"error,omitempty"`
+}
+
+// Capture detects the environment and builds its configuration,
+// recording everything that was read into a bundle
+func Capture() (*CaptureBundle, error) {
+	return CaptureEnvironment("")
+}
+
+// CaptureEnvironment builds the configuration of the environment with the given name, or the detected environment when the name is empty,
+// recording everything that was read into a bundle
+func CaptureEnvironment(name string) (*CaptureBundle, error) {
+	recorder := newCaptureSource()
+
+	source := enums.Source(name)
+	var env Environment
+	if name == "" {
+		detection := DetectEnvironmentWithReport()
+		env, source = detection.Environment, detection.Source
+		for _, candidate := range detection.Candidates {
+			for _, variable := range candidate.MatchedVariables {
+				recorder.LookupEnv(variable)
+			}
+		}
+	} else {
+		var err error
+		if env, err = GetEnvironment(name); err != nil {
+			return nil, err
+		}
+	}
+
+	loader, ok := env.(SourceLoader)
+	if !ok {
+		return nil, fmt.Errorf("environment %s does not support capture", env.Name())
+	}
+
+	configuration, err := loader.GetConfigurationFrom(recorder)
+	if err != nil {
+		return nil, err
+	}
+
+	return recorder.bundle(source, configuration), nil
+}
+
+// Replay builds the configuration of a captured bundle, without reading the variables, files or git repository of the current machine
+func Replay(bundle *CaptureBundle) (*models.Configuration, error) {
+	if bundle == nil {
+		return nil, errors.New("capture bundle is empty")
+	}
+	if bundle.Version != CaptureBundleVersion {
+		return nil, fmt.Errorf("capture bundle version %s is not supported", bundle.Version)
+	}
+
+	env, err := GetEnvironment(string(bundle.Source))
+	if err != nil {
+		return nil, err
+	}
+	loader, ok := env.(SourceLoader)
+	if !ok {
+		return nil, fmt.Errorf("environment %s does not support replay", env.Name())
+	}
+
+	root, err := os.MkdirTemp("", "go-environments-replay-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(root)
+
+	if err := materializeBundle(root, bundle); err != nil {
+		return nil, err
+	}
+
+	envs := make(map[string]string, len(bundle.Envs))
+	for key, value := range bundle.Envs {
+		envs[key] = value
+	}
+	src := envsource.New(envs).
+		WithRoot(root).
+		WithWorkingDirectory(bundle.WorkingDirectory).
+		WithGitClient(&replayGitClient{root: root, commands: bundle.GitCommands})
+	return loader.GetConfigurationFrom(src)
+}
+
+// materializeBundle recreates the captured files and paths under root
+func materializeBundle(root string, bundle *CaptureBundle) error {
+	for _, dir := range bundle.Directories {
+		localPath, err := bundlePath(root, dir)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(localPath, 0755); err != nil {
+			return err
+		}
+	}
+
+	files := map[string]string{}
+	for _, path := range bundle.Paths {
+		files[path] = ""
+	}
+	for path, content := range bundle.Files {
+		files[path] = content
+	}
+
+	for path, content := range files {
+		localPath, err := bundlePath(root, path)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(localPath), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(localPath, []byte(content), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bundlePath resolves a path of a bundle under root, paths that escape root are rejected
+func bundlePath(root string, path string) (string, error) {
+	localPath := filepath.Join(root, path)
+	relativePath, err := filepath.Rel(root, localPath)
+	if err != nil || relativePath == ".." || strings.HasPrefix(relativePath, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("capture bundle path %s is outside of the replay directory", path)
+	}
+	return localPath, nil
+}
+
+// captureSource reads from the process environment, file system and git like envsource.OS, and records what was read
+type captureSource struct {
+	lock        sync.Mutex
+	envs        map[string]string
+	files       map[string]string
+	paths       map[string]bool
+	walked      map[string]bool
+	workingDir  string
+	gitCommands []CapturedGitCommand
+}
+
+func newCaptureSource() *captureSource {
+	return &captureSource{
+		envs:   map[string]string{},
+		files:  map[string]string{},
+		paths:  map[string]bool{},
+		walked: map[string]bool{},
+	}
+}
+
+func (s *captureSource) LookupEnv(key string) (string, bool) {
+	value, ok := os.LookupEnv(key)
+	if ok {
+		s.lock.Lock()
+		s.envs[key] = value
+		s.lock.Unlock()
+	}
+	return value, ok
+}
+
+func (s *captureSource) Getenv(key string) string {
+	value, _ := s.LookupEnv(key)
+	return value
+}
+
+// WorkingDirectory returns the working directory of the process and records it
+func (s *captureSource) WorkingDirectory() string {
+	workingDir, _ := os.Getwd()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.workingDir = workingDir
+	return workingDir
+}
+
+func (s *captureSource) RecordFile(name string, content []byte) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.files[name] = string(content)
+}
+
+func (s *captureSource) RecordPath(name string, isDir bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.paths[name] = s.paths[name] || isDir
+}
+
+func (s *captureSource) RecordWalkedPath(name string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.walked[name] = true
+}
+
+func (s *captureSource) GitClient() git.GitClient {
+	return &recordingGitClient{source: s}
+}
+
+func (s *captureSource) recordGitCommand(command CapturedGitCommand, output string, err error) {
+	command.Output = redactUrl(output)
+	if err != nil {
+		command.Error = err.Error()
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.gitCommands = append(s.gitCommands, command)
+}
+
+// bundle creates the bundle of what was read while building configuration.
+// Walked files are kept only when they are pipeline files of the configuration, the rest of the walked tree is not needed for replay
+func (s *captureSource) bundle(source enums.Source, configuration *models.Configuration) *CaptureBundle {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	bundle := &CaptureBundle{
+		Version:          CaptureBundleVersion,
+		Source:           source,
+		Envs:             map[string]string{},
+		WorkingDirectory: s.workingDir,
+		Files:            map[string]string{},
+		GitCommands:      append([]CapturedGitCommand{}, s.gitCommands...),
+		Configuration:    configuration,
+	}
+
+	used := map[string]bool{}
+	if configuration != nil {
+		for _, path := range configuration.PipelinePaths {
+			used[path] = true
+		}
+	}
+
+	for key, value := range s.envs {
+		if IsSecretEnv(key) {
+			bundle.RedactedEnvs = append(bundle.RedactedEnvs, key)
+			continue
+		}
+		bundle.Envs[key] = redactUrl(value)
+	}
+	for path, content := range s.files {
+		if s.walked[path] && !used[path] {
+			continue
+		}
+		bundle.Files[path] = redactFile(content)
+	}
+	for path, isDir := range s.paths {
+		if isDir {
+			bundle.Directories = append(bundle.Directories, path)
+		} else {
+			bundle.Paths = append(bundle.Paths, path)
+		}
+	}
+	for path := range s.walked {
+		if used[path] && !s.paths[path] {
+			bundle.Paths = append(bundle.Paths, path)
+		}
+	}
+
+	sort.Strings(bundle.RedactedEnvs)
+	sort.Strings(bundle.Paths)
+	sort.Strings(bundle.Directories)
+	return bundle
+}
+
+// IsSecretEnv checks whether the name of a variable looks like a secret, i.e. GITHUB_TOKEN or SYSTEM_ACCESSTOKEN
+func IsSecretEnv(name string) bool {
+	return secretEnvRegexp.MatchString(name)
+}
+
+// RedactEnv returns the value of a variable for printing, values of secret variables are replaced and credentials are removed from URLs
+func RedactEnv(name string, value string) string {
+	if IsSecretEnv(name) {
+		return redactedValue
+	}
+	return redactUrl(value)
+}
+
+// redactUrl removes the credentials from a value that is a URL, other values are returned as is
+func redactUrl(value string) string {
+	parsedUrl, err := url.Parse(value)
+	if err != nil || parsedUrl.User == nil {
+		return value
+	}
+	return utils.StripCredentialsFromUrl(value)
+}
+
+// redactFile replaces the values of secret keys in the entries of a properties, yaml or json file, and removes credentials from URLs.
+// Only string values are replaced in json, so the file can still be parsed during replay
+func redactFile(content string) string {
+	content = secretFileEntryRegexp.ReplaceAllStringFunc(content, func(entry string) string {
+		match := secretFileEntryRegexp.FindStringSubmatch(entry)
+		indent, keyQuote, key, separator, value, comma := match[1], match[2], match[3], match[4], match[5], match[6]
+		if !secretEnvRegexp.MatchString(key) || value == "" {
+			return entry
+		}
+		if keyQuote != "" {
+			if !strings.HasPrefix(value, `"`) {
+				return entry
+			}
+			value = `"` + redactedValue + `"`
+		} else {
+			value = redactedValue
+		}
+		return indent + keyQuote + key + separator + value + comma
+	})
+	return urlCredentialsRegexp.ReplaceAllString(content, "$1")
+}
+
+// recordingGitClient runs git commands with the global git client and records their output
+type recordingGitClient struct {
+	source *captureSource
+}
+
+func (c *recordingGitClient) GetGitRemoteURL(repositoryPath string) (string, error) {
+	output, err := git.GlobalGitClient.GetGitRemoteURL(repositoryPath)
+	c.source.recordGitCommand(CapturedGitCommand{Command: gitRemoteUrlCommand, RepositoryPath: repositoryPath}, output, err)
+	return output, err
+}
+
+func (c *recordingGitClient) GetGitCommit(repositoryPath string) (string, error) {
+	output, err := git.GlobalGitClient.GetGitCommit(repositoryPath)
+	c.source.recordGitCommand(CapturedGitCommand{Command: gitCommitCommand, RepositoryPath: repositoryPath}, output, err)
+	return output, err
+}
+
+func (c *recordingGitClient) GetGitBranch(repositoryPath string, commit string) (string, error) {
+	output, err := git.GlobalGitClient.GetGitBranch(repositoryPath, commit)
+	c.source.recordGitCommand(CapturedGitCommand{Command: gitBranchCommand, RepositoryPath: repositoryPath, Commit: commit}, output, err)
+	return output, err
+}
+
+func (c *recordingGitClient) GetGitBranchWithWarnings(repositoryPath string, commit string) (string, []error, error) {
+	warningsGetter, ok := git.GlobalGitClient.(git.BranchWarningsGetter)
+	if !ok {
+		output, err := c.GetGitBranch(repositoryPath, commit)
+		return output, nil, err
+	}
+
+	output, warnings, err := warningsGetter.GetGitBranchWithWarnings(repositoryPath, commit)
+	c.source.recordGitCommand(CapturedGitCommand{Command: gitBranchCommand, RepositoryPath: repositoryPath, Commit: commit}, output, err)
+	return output, warnings, err
+}
+
+func (c *recordingGitClient) AddRemoteUrl(repositoryPath string, remoteUrl string) error {
+	return git.GlobalGitClient.AddRemoteUrl(repositoryPath, remoteUrl)
+}
+
+func (c *recordingGitClient) CreateGitRepository(path string) error {
+	return git.GlobalGitClient.CreateGitRepository(path)
+}
+
+// replayGitClient answers git commands from the outputs of a capture bundle
+type replayGitClient struct {
+	root     string
+	commands []CapturedGitCommand
+}
+
+func (c *replayGitClient) GetGitRemoteURL(repositoryPath string) (string, error) {
+	return c.find(gitRemoteUrlCommand, repositoryPath, "")
+}
+
+func (c *replayGitClient) GetGitCommit(repositoryPath string) (string, error) {
+	return c.find(gitCommitCommand, repositoryPath, "")
+}
+
+func (c *replayGitClient) GetGitBranch(repositoryPath string, commit string) (string, error) {
+	return c.find(gitBranchCommand, repositoryPath, commit)
+}
+
+func (c *replayGitClient) AddRemoteUrl(repositoryPath string, remoteUrl string) error {
+	return errors.New("git repositories cannot be changed during replay")
+}
+
+func (c *replayGitClient) CreateGitRepository(path string) error {
+	return errors.New("git repositories cannot be changed during replay")
+}
+
+// find returns the captured output of a git command.
+// Commands are matched by their repository path first, and by the command alone when the path differs,
+// i.e. for environments that run git in the current working directory
+func (c *replayGitClient) find(command string, repositoryPath string, commit string) (string, error) {
+	var fallback *CapturedGitCommand
+	for i := range c.commands {
+		captured := &c.commands[i]
+		if captured.Command != command || captured.Commit != commit {
+			continue
+		}
+		if c.localPath(captured.RepositoryPath) == repositoryPath {
+			return captured.result()
+		}
+		if fallback == nil {
+			fallback = captured
+		}
+	}
+
+	if fallback != nil {
+		return fallback.result()
+	}
+	return "", fmt.Errorf("git %s of %s was not captured", command, repositoryPath)
+}
+
+// localPath resolves a repository path of the captured run to the path the replayed environment passes to git
+func (c *replayGitClient) localPath(repositoryPath string) string {
+	if repositoryPath == "" {
+		return ""
+	}
+	return filepath.Join(c.root, repositoryPath)
+}
+
+func (c *CapturedGitCommand) result() (string, error) {
+	if c.Error != "" {
+		return c.Output, errors.New(c.Error)
+	}
+	return c.Output, nil
+}
@@ -0,0 +1,310 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/argonsecurity/go-environments"
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/exporters"
+	"github.com/argonsecurity/go-environments/models"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	jsonFormat = "json"
+	yamlFormat = "yaml"
+	envFormat  = "env"
+)
+
+var exportFormats = map[string]exporters.Format{
+	envFormat:                            exporters.DotenvFormat,
+	string(exporters.DotenvFormat):       exporters.DotenvFormat,
+	string(exporters.GithubFormat):       exporters.GithubFormat,
+	string(exporters.AzureFormat):        exporters.AzureFormat,
+	string(exporters.GitlabDotenvFormat): exporters.GitlabDotenvFormat,
+}
+
+func runDetect(args []string, stdout io.Writer, stderr io.Writer) int {
+	fs := newFlagSet("detect", stderr)
+	report := fs.Bool("report", false, "print every evaluated environment and the variables that matched, as json")
+	if _, err := parseFlags(fs, args); err != nil {
+		return exitUsage
+	}
+
+	detection := environments.DetectEnvironmentWithReport()
+	if *report {
+		if err := writeJson(stdout, detection); err != nil {
+			fmt.Fprintln(stderr, err)
+			return exitError
+		}
+	} else {
+		fmt.Fprintln(stdout, detection.Source)
+	}
+
+	if detection.Source == enums.Localhost {
+		fmt.Fprintln(stderr, "no CI environment detected")
+		return exitDetectionFailed
+	}
+	return exitOK
+}
+
+func runConfig(args []string, stdout io.Writer, stderr io.Writer) int {
+	fs := newFlagSet("config", stderr)
+	envName := fs.String("env", "", "environment name, detected when empty")
+	format := fs.String("format", jsonFormat, "output format: json, yaml, env, dotenv, github, azure or gitlab-dotenv")
+	prefix := fs.String("prefix", exporters.DefaultPrefix, "prefix of the variable names of the export formats")
+	capture := fs.String("capture", "", "write everything that was read to a capture bundle file")
+	replay := fs.String("replay", "", "build the configuration from a capture bundle file instead of the current machine")
+	if _, err := parseFlags(fs, args); err != nil {
+		return exitUsage
+	}
+
+	if *capture != "" && *replay != "" {
+		fmt.Fprintln(stderr, "--capture and --replay cannot be used together")
+		return exitUsage
+	}
+
+	exportFormat, isExport := exportFormats[*format]
+	if *format != jsonFormat && *format != yamlFormat && !isExport {
+		fmt.Fprintf(stderr, "unsupported format %q (json, yaml, env, dotenv, github, azure, gitlab-dotenv)\n", *format)
+		return exitUsage
+	}
+
+	var configuration *models.Configuration
+	var code int
+	switch {
+	case *capture != "":
+		configuration, code = captureConfiguration(*envName, *capture, stderr)
+	case *replay != "":
+		configuration, code = replayConfiguration(*replay, stderr)
+	default:
+		configuration, code = getConfiguration(*envName, stderr)
+	}
+	if code != exitOK {
+		return code
+	}
+	for _, warning := range configuration.Warnings {
+		fmt.Fprintf(stderr, "warning [%s]: %s\n", warning.Code, warning.Error())
+	}
+
+	var err error
+	switch *format {
+	case jsonFormat:
+		err = writeJson(stdout, configuration)
+	case yamlFormat:
+		err = writeYaml(stdout, configuration)
+	default:
+		err = exporters.New(*prefix).Write(stdout, exportFormat, configuration)
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitError
+	}
+	return exitOK
+}
+
+func runLinks(args []string, stdout io.Writer, stderr io.Writer) int {
+	fs := newFlagSet("links", stderr)
+	envName := fs.String("env", "", "environment name, detected when empty")
+	line := fs.Int("line", 0, "line number")
+	endLine := fs.Int("end-line", 0, "last line number, defaults to --line")
+	branch := fs.String("branch", "", "branch name, defaults to the branch of the configuration")
+	commit := fs.String("commit", "", "commit sha, defaults to the commit of the configuration")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return exitUsage
+	}
+
+	if len(positional) != 2 || positional[0] != "file" {
+		fmt.Fprintln(stderr, "usage: go-environments links file <path> [--line N] [--end-line N] [--branch name] [--commit sha]")
+		return exitUsage
+	}
+
+	env, err := environments.GetOrDetectEnvironment(*envName)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitDetectionFailed
+	}
+
+	if *branch == "" || *commit == "" {
+		configuration, err := env.GetConfiguration()
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return exitConfigurationFailed
+		}
+		if *branch == "" {
+			*branch = configuration.Branch
+		}
+		if *commit == "" {
+			*commit = configuration.CommitSha
+		}
+	}
+
+	link := env.GetFileLineLink(positional[1], *branch, *commit, *line, *endLine)
+	if link == "" {
+		fmt.Fprintf(stderr, "environment %s does not support file links\n", env.Name())
+		return exitConfigurationFailed
+	}
+	fmt.Fprintln(stdout, link)
+	return exitOK
+}
+
+func runEnvVars(args []string, stdout io.Writer, stderr io.Writer) int {
+	fs := newFlagSet("env-vars", stderr)
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return exitUsage
+	}
+
+	if len(positional) != 1 {
+		fmt.Fprintln(stderr, "usage: go-environments env-vars <source>")
+		return exitUsage
+	}
+
+	env, err := environments.GetEnvironment(positional[0])
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitDetectionFailed
+	}
+
+	src := &lookupRecorder{src: envsource.OS, lookups: map[string]bool{}}
+	_, configurationErr := environments.GetConfigurationFrom(env, src)
+	if getter, ok := env.(environments.DetectionVariablesGetter); ok {
+		// the detection variables are read by IsCurrentEnvironment, not while building the configuration
+		for _, name := range getter.DetectionVariables() {
+			src.lookups[name] = true
+		}
+	}
+
+	names := make([]string, 0, len(src.lookups))
+	for name := range src.lookups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if value, ok := envsource.OS.LookupEnv(name); ok {
+			fmt.Fprintf(stdout, "%s=%s\n", name, environments.RedactEnv(name, value))
+		} else {
+			fmt.Fprintf(stdout, "%s (not set)\n", name)
+		}
+	}
+
+	if configurationErr != nil {
+		fmt.Fprintln(stderr, configurationErr)
+		return exitConfigurationFailed
+	}
+	return exitOK
+}
+
+func getConfiguration(envName string, stderr io.Writer) (*models.Configuration, int) {
+	env, err := environments.GetOrDetectEnvironment(envName)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return nil, exitDetectionFailed
+	}
+
+	configuration, err := env.GetConfiguration()
+	if err != nil {
+		fmt.Fprintf(stderr, "failed to get %s configuration: %s\n", env.Name(), err)
+		return nil, exitConfigurationFailed
+	}
+	return configuration, exitOK
+}
+
+func captureConfiguration(envName string, bundlePath string, stderr io.Writer) (*models.Configuration, int) {
+	bundle, err := environments.CaptureEnvironment(envName)
+	if err != nil {
+		fmt.Fprintf(stderr, "failed to capture configuration: %s\n", err)
+		return nil, exitConfigurationFailed
+	}
+
+	file, err := os.Create(bundlePath)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return nil, exitError
+	}
+	defer file.Close()
+
+	if err := writeJson(file, bundle); err != nil {
+		fmt.Fprintln(stderr, err)
+		return nil, exitError
+	}
+	return bundle.Configuration, exitOK
+}
+
+func replayConfiguration(bundlePath string, stderr io.Writer) (*models.Configuration, int) {
+	content, err := os.ReadFile(bundlePath)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return nil, exitError
+	}
+
+	var bundle *environments.CaptureBundle
+	if err := json.Unmarshal(content, &bundle); err != nil {
+		fmt.Fprintf(stderr, "invalid capture bundle: %s\n", err)
+		return nil, exitError
+	}
+
+	configuration, err := environments.Replay(bundle)
+	if err != nil {
+		fmt.Fprintf(stderr, "failed to replay configuration: %s\n", err)
+		return nil, exitConfigurationFailed
+	}
+	return configuration, exitOK
+}
+
+// lookupRecorder records the variables an environment reads
+type lookupRecorder struct {
+	src     envsource.EnvSource
+	lookups map[string]bool
+}
+
+func (r *lookupRecorder) LookupEnv(key string) (string, bool) {
+	r.lookups[key] = true
+	return r.src.LookupEnv(key)
+}
+
+func (r *lookupRecorder) Getenv(key string) string {
+	r.lookups[key] = true
+	return r.src.Getenv(key)
+}
+
+func newFlagSet(name string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	return fs
+}
+
+// parseFlags parses flags that may appear before, between or after the positional arguments
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	positional := []string{}
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+func writeJson(w io.Writer, value any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+func writeYaml(w io.Writer, value any) error {
+	encoder := yaml.NewEncoder(w)
+	defer encoder.Close()
+	return encoder.Encode(value)
+}
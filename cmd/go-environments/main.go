@@ -0,0 +1,76 @@
+// go-environments detects the CI environment it runs in and prints its configuration
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+const (
+	exitOK                  = 0
+	exitError               = 1
+	exitUsage               = 2
+	exitDetectionFailed     = 3
+	exitConfigurationFailed = 4
+)
+
+const usage = `usage: go-environments <command> [flags]
+
+commands:
+  detect                           print the detected environment
+  config [--format <format>]       print the configuration of the environment
+  links file <path> [--line N]     print a link to a file of the repository
+  env-vars <source>                print the variables an environment reads, secrets are redacted
+
+config formats:
+  json, yaml                       the serialized configuration
+  env, dotenv                      a .env file
+  github                           GITHUB_OUTPUT/GITHUB_ENV file syntax
+  azure                            ##vso[task.setvariable] logging commands
+  gitlab-dotenv                    a GitLab dotenv report artifact
+  the variable names of the export formats start with --prefix, ARGON_ by default
+
+config capture:
+  --capture <file>                 write everything the configuration was built from to a bundle
+  --replay <file>                  build the configuration from a bundle captured on another machine
+
+exit codes:
+  0  success
+  1  unexpected error
+  2  usage error
+  3  environment detection failed
+  4  configuration failed
+`
+
+type command func(args []string, stdout io.Writer, stderr io.Writer) int
+
+var commands = map[string]command{
+	"detect":   runDetect,
+	"config":   runConfig,
+	"links":    runLinks,
+	"env-vars": runEnvVars,
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout io.Writer, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return exitUsage
+	}
+
+	if args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		fmt.Fprint(stdout, usage)
+		return exitOK
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command %q\n\n%s", args[0], usage)
+		return exitUsage
+	}
+	return cmd(args[1:], stdout, stderr)
+}
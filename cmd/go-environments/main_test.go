@@ -0,0 +1,217 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/argonsecurity/go-environments"
+	"github.com/argonsecurity/go-environments/environments/gitlab"
+	"github.com/argonsecurity/go-environments/environments/testutils"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/models"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	gitlabMainEnvsFilePath = "../../environments/gitlab/testdata/gitlab-ci-main-env.json"
+	gitlabCommit           = "3ufl0xuicz460no9xck5j3xyyvk9w8m4j7bwr3ta"
+)
+
+func Test_run(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           []string
+		envsFilePath   string
+		wantCode       int
+		wantStdout     string
+		wantStdoutPart string
+	}{
+		{
+			name:     "No command",
+			args:     []string{},
+			wantCode: exitUsage,
+		},
+		{
+			name:     "Unknown command",
+			args:     []string{"test"},
+			wantCode: exitUsage,
+		},
+		{
+			name:           "Help",
+			args:           []string{"help"},
+			wantCode:       exitOK,
+			wantStdoutPart: "usage: go-environments",
+		},
+		{
+			name:         "Detect GitLab",
+			args:         []string{"detect"},
+			envsFilePath: gitlabMainEnvsFilePath,
+			wantCode:     exitOK,
+			wantStdout:   "gitlab\n",
+		},
+		{
+			name:           "Detect GitLab with report",
+			args:           []string{"detect", "--report"},
+			envsFilePath:   gitlabMainEnvsFilePath,
+			wantCode:       exitOK,
+			wantStdoutPart: `"matchedVariables": [`,
+		},
+		{
+			name:       "Detect nothing",
+			args:       []string{"detect"},
+			wantCode:   exitDetectionFailed,
+			wantStdout: "localhost\n",
+		},
+		{
+			name:         "Config unsupported format",
+			args:         []string{"config", "--format", "xml"},
+			envsFilePath: gitlabMainEnvsFilePath,
+			wantCode:     exitUsage,
+		},
+		{
+			name:     "Config unknown environment",
+			args:     []string{"config", "--env", "test"},
+			wantCode: exitDetectionFailed,
+		},
+		{
+			name:           "Config env format",
+			args:           []string{"config", "--format", "env"},
+			envsFilePath:   gitlabMainEnvsFilePath,
+			wantCode:       exitOK,
+			wantStdoutPart: "COMMIT_SHA=" + gitlabCommit + "\n",
+		},
+		{
+			name:           "Config github format with prefix",
+			args:           []string{"config", "--format", "github", "--prefix", "CI_"},
+			envsFilePath:   gitlabMainEnvsFilePath,
+			wantCode:       exitOK,
+			wantStdoutPart: "\nCI_COMMIT_SHA=" + gitlabCommit + "\n",
+		},
+		{
+			name:         "File link",
+			args:         []string{"links", "file", "path/to/file", "--line", "3"},
+			envsFilePath: gitlabMainEnvsFilePath,
+			wantCode:     exitOK,
+			wantStdout:   "https://gitlab.com/test-group/test-sub-group/test-project/-/blob/" + gitlabCommit + "/path/to/file#L3-3\n",
+		},
+		{
+			name:         "File link with flags before the path",
+			args:         []string{"links", "file", "--line", "3", "--end-line", "5", "--commit", "commit", "path/to/file"},
+			envsFilePath: gitlabMainEnvsFilePath,
+			wantCode:     exitOK,
+			wantStdout:   "https://gitlab.com/test-group/test-sub-group/test-project/-/blob/commit/path/to/file#L3-5\n",
+		},
+		{
+			name:     "File link without path",
+			args:     []string{"links", "file"},
+			wantCode: exitUsage,
+		},
+		{
+			name:           "Environment variables",
+			args:           []string{"env-vars", "gitlab"},
+			envsFilePath:   gitlabMainEnvsFilePath,
+			wantCode:       exitOK,
+			wantStdoutPart: "CI_COMMIT_SHA=" + gitlabCommit + "\n",
+		},
+		{
+			name:           "Environment variables with credentials in a url",
+			args:           []string{"env-vars", "gitlab"},
+			envsFilePath:   gitlabMainEnvsFilePath,
+			wantCode:       exitOK,
+			wantStdoutPart: "CI_REPOSITORY_URL=https://gitlab.com/test-group/test-sub-group/test-project.git\n",
+		},
+		{
+			name:           "Environment variables used for detection",
+			args:           []string{"env-vars", "gitlab"},
+			envsFilePath:   gitlabMainEnvsFilePath,
+			wantCode:       exitOK,
+			wantStdoutPart: "GITLAB_CI=true\n",
+		},
+		{
+			name:     "Environment variables of unknown environment",
+			args:     []string{"env-vars", "test"},
+			wantCode: exitDetectionFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prepareTest(t, tt.envsFilePath)
+			stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+
+			code := run(tt.args, stdout, stderr)
+			assert.Equal(t, tt.wantCode, code, stderr.String())
+			if tt.wantStdout != "" {
+				assert.Equal(t, tt.wantStdout, stdout.String())
+			}
+			if tt.wantStdoutPart != "" {
+				assert.Contains(t, stdout.String(), tt.wantStdoutPart)
+			}
+		})
+	}
+}
+
+// tokenEnvironment reads a token like the GitHub client of CircleCI
+type tokenEnvironment struct {
+	environments.Environment
+}
+
+func (e tokenEnvironment) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	src.Getenv("TEST_GITHUB_TOKEN")
+	src.Getenv("TEST_BRANCH")
+	return &models.Configuration{}, nil
+}
+
+func Test_runEnvVarsRedaction(t *testing.T) {
+	environments.RegisterEnvironment("token-test", tokenEnvironment{Environment: gitlab.Gitlab})
+	t.Cleanup(func() { environments.UnregisterEnvironment("token-test") })
+	t.Setenv("TEST_GITHUB_TOKEN", "ghs_secret")
+	t.Setenv("TEST_BRANCH", "main")
+
+	stdout := &bytes.Buffer{}
+	assert.Equal(t, exitOK, run([]string{"env-vars", "token-test"}, stdout, &bytes.Buffer{}))
+	assert.Equal(t, "TEST_BRANCH=main\nTEST_GITHUB_TOKEN=[REDACTED]\n", stdout.String())
+}
+
+func Test_runConfig(t *testing.T) {
+	prepareTest(t, gitlabMainEnvsFilePath)
+
+	stdout := &bytes.Buffer{}
+	assert.Equal(t, exitOK, run([]string{"config"}, stdout, &bytes.Buffer{}))
+	var fromJson map[string]any
+	assert.NoError(t, json.Unmarshal(stdout.Bytes(), &fromJson))
+	assert.Equal(t, "gitlab", fromJson["environment"])
+	assert.Equal(t, gitlabCommit, fromJson["commitSha"])
+
+	stdout.Reset()
+	assert.Equal(t, exitOK, run([]string{"config", "--format", "yaml"}, stdout, &bytes.Buffer{}))
+	var fromYaml map[string]any
+	assert.NoError(t, yaml.Unmarshal(stdout.Bytes(), &fromYaml))
+	assert.Equal(t, fromJson["commitSha"], fromYaml["commitSha"])
+	assert.Equal(t, fromJson["schemaVersion"], fromYaml["schemaVersion"])
+}
+
+func Test_runConfigCaptureAndReplay(t *testing.T) {
+	bundlePath := filepath.Join(t.TempDir(), "bundle.json")
+	captured := &bytes.Buffer{}
+	t.Run("Capture", func(t *testing.T) {
+		prepareTest(t, gitlabMainEnvsFilePath)
+		assert.Equal(t, exitOK, run([]string{"config", "--env", "gitlab", "--capture", bundlePath}, captured, &bytes.Buffer{}))
+	})
+
+	replayed := &bytes.Buffer{}
+	assert.Equal(t, exitOK, run([]string{"config", "--replay", bundlePath}, replayed, &bytes.Buffer{}))
+	assert.JSONEq(t, captured.String(), replayed.String())
+
+	assert.Equal(t, exitUsage, run([]string{"config", "--capture", bundlePath, "--replay", bundlePath}, &bytes.Buffer{}, &bytes.Buffer{}))
+	assert.Equal(t, exitError, run([]string{"config", "--replay", filepath.Join(t.TempDir(), "missing.json")}, &bytes.Buffer{}, &bytes.Buffer{}))
+}
+
+func prepareTest(t *testing.T, envsFilePath string) {
+	envCleanup := testutils.SetEnvsFromFile(envsFilePath)
+	t.Cleanup(envCleanup)
+	gitlab.Gitlab.Reset()
+	t.Cleanup(gitlab.Gitlab.Reset)
+}
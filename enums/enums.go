@@ -15,4 +15,22 @@ const (
 	Localhost       Source = "localhost"
 	Unknown         Source = "unknown"
 	CircleCi        Source = "circleci"
+	Travis          Source = "travis"
+	Buildkite       Source = "buildkite"
+	CodeBuild       Source = "codebuild"
+	CloudBuild      Source = "cloudbuild"
+	TeamCity        Source = "teamcity"
+	Drone           Source = "drone"
+	Woodpecker      Source = "woodpecker"
+	Gitea           Source = "gitea"
+	Bamboo          Source = "bamboo"
+	Tekton          Source = "tekton"
+	Argo            Source = "argo"
+	Semaphore       Source = "semaphore"
+	Codefresh       Source = "codefresh"
+	Concourse       Source = "concourse"
+	Harness         Source = "harness"
+	Gerrit          Source = "gerrit"
+	CodeCommit      Source = "codecommit"
+	SourceHut       Source = "sourcehut"
 )
@@ -2,33 +2,162 @@ package environments
 
 import (
 	"fmt"
+	"os"
+	"strings"
+	"sync"
+
 	"github.com/argonsecurity/go-environments/environments/circleci"
 
 	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/argo"
 	"github.com/argonsecurity/go-environments/environments/azure"
+	"github.com/argonsecurity/go-environments/environments/bamboo"
 	"github.com/argonsecurity/go-environments/environments/bitbucket"
 	"github.com/argonsecurity/go-environments/environments/bitbucketserver"
+	"github.com/argonsecurity/go-environments/environments/buildkite"
+	"github.com/argonsecurity/go-environments/environments/cloudbuild"
+	"github.com/argonsecurity/go-environments/environments/codebuild"
+	"github.com/argonsecurity/go-environments/environments/codecommit"
+	"github.com/argonsecurity/go-environments/environments/codefresh"
+	"github.com/argonsecurity/go-environments/environments/concourse"
+	"github.com/argonsecurity/go-environments/environments/drone"
+	"github.com/argonsecurity/go-environments/environments/gerrit"
+	"github.com/argonsecurity/go-environments/environments/gitea"
 	"github.com/argonsecurity/go-environments/environments/github"
 	"github.com/argonsecurity/go-environments/environments/gitlab"
+	"github.com/argonsecurity/go-environments/environments/harness"
 	"github.com/argonsecurity/go-environments/environments/jenkins"
 	"github.com/argonsecurity/go-environments/environments/localhost"
+	"github.com/argonsecurity/go-environments/environments/semaphore"
+	"github.com/argonsecurity/go-environments/environments/sourcehut"
+	"github.com/argonsecurity/go-environments/environments/teamcity"
+	"github.com/argonsecurity/go-environments/environments/tekton"
+	"github.com/argonsecurity/go-environments/environments/travis"
+	"github.com/argonsecurity/go-environments/environments/utils"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/environments/woodpecker"
 	"github.com/argonsecurity/go-environments/models"
 )
 
 var (
 	environmentMapping map[enums.Source]Environment = map[enums.Source]Environment{
-		enums.Github:    github.Github,
-		enums.Gitlab:    gitlab.Gitlab,
-		enums.Azure:     azure.Azure,
-		enums.Bitbucket: bitbucket.Bitbucket,
-		enums.Jenkins:   jenkins.Jenkins,
-		enums.CircleCi:  circleci.CircleCi,
-		enums.Localhost: localhost.Localhost,
+		enums.Github:     github.Github,
+		enums.Gitlab:     gitlab.Gitlab,
+		enums.Azure:      azure.Azure,
+		enums.Bitbucket:  bitbucket.Bitbucket,
+		enums.Jenkins:    jenkins.Jenkins,
+		enums.CircleCi:   circleci.CircleCi,
+		enums.Travis:     travis.Travis,
+		enums.Buildkite:  buildkite.Buildkite,
+		enums.CodeBuild:  codebuild.CodeBuild,
+		enums.CloudBuild: cloudbuild.CloudBuild,
+		enums.TeamCity:   teamcity.TeamCity,
+		enums.Woodpecker: woodpecker.Woodpecker,
+		enums.Drone:      drone.Drone,
+		enums.Gitea:      gitea.Gitea,
+		enums.Bamboo:     bamboo.Bamboo,
+		enums.Tekton:     tekton.Tekton,
+		enums.Argo:       argo.Argo,
+		enums.Semaphore:  semaphore.Semaphore,
+		enums.Codefresh:  codefresh.Codefresh,
+		enums.Concourse:  concourse.Concourse,
+		enums.Harness:    harness.Harness,
+		enums.Localhost:  localhost.Localhost,
+	}
+
+	// detectionOrder is the priority of the built-in environments during detection.
+	// CI systems with dedicated marker variables come first, Jenkins comes last
+	// because its variables are often left over in images and agents that run on other systems
+	detectionOrder = []enums.Source{
+		enums.Gitea,
+		enums.Github,
+		enums.Gitlab,
+		enums.Azure,
+		enums.Bitbucket,
+		enums.CircleCi,
+		enums.Travis,
+		enums.Buildkite,
+		enums.CodeBuild,
+		enums.CloudBuild,
+		enums.TeamCity,
+		enums.Harness,
+		enums.Woodpecker,
+		enums.Drone,
+		enums.Bamboo,
+		enums.Semaphore,
+		enums.Codefresh,
+		enums.Argo,
+		enums.Tekton,
+		enums.Concourse,
+		enums.Jenkins,
 	}
+
+	// registeredEnvironments holds environments added with RegisterEnvironment.
+	// They take precedence over the built-in environments, both when getting an environment by name
+	// and when detecting, and are detected in the order they were registered
+	registeredEnvironments = map[enums.Source]Environment{}
+	registrationOrder      []enums.Source
+	registryLock           sync.RWMutex
 )
 
 type GetFileLineLinkFunc func(string, string, string, string, int, int) string
 type GetFileLinkFunc func(string, string, string, string) string
+type GetCommitLinkFunc func(string, string) string
+type GetCompareLinkFunc func(string, string, string) string
+type GetPullRequestLinkFunc func(string, string) string
+type GetRefLinkFunc func(string, string) string
+type ParseFileLineLinkFunc func(string) (string, string, string, int, int, error)
+
+// DetectionVariablesGetter is implemented by environments that can tell which variables they use for detection
+type DetectionVariablesGetter interface {
+	// DetectionVariables get the names of the variables checked by IsCurrentEnvironment
+	DetectionVariables() []string
+}
+
+// SourceLoader is implemented by environments that can build their configuration from an EnvSource
+type SourceLoader interface {
+	// GetConfigurationFrom get a environment configuration from the given source instead of the process environment
+	GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error)
+}
+
+// LinkBuilder is implemented by environments that can link to the commits, pull requests and refs of their repository
+type LinkBuilder interface {
+	// GetCommitLink get a link to a commit
+	GetCommitLink(commit string) string
+
+	// GetCompareLink get a link to the diff between two commits
+	GetCompareLink(baseCommit string, headCommit string) string
+
+	// GetPullRequestLink get a link to a pull request
+	GetPullRequestLink(pullRequestId string) string
+
+	// GetRefLink get a link to a branch or a tag
+	GetRefLink(ref string) string
+}
+
+// Refresher is implemented by environments that cache their configuration
+type Refresher interface {
+	// Refresh load the configuration again and replace the cached configuration
+	Refresh() (*models.Configuration, error)
+
+	// Reset clear the cached configuration
+	Reset()
+}
+
+// DetectionCandidate describes a single environment that was evaluated during detection
+type DetectionCandidate struct {
+	Source           enums.Source `json:"source"`
+	Environment      Environment  `json:"-"`
+	Matched          bool         `json:"matched"`
+	MatchedVariables []string     `json:"matchedVariables,omitempty"`
+}
+
+// DetectionReport is the result of DetectEnvironmentWithReport
+type DetectionReport struct {
+	Source      enums.Source         `json:"source"`
+	Environment Environment          `json:"-"`
+	Candidates  []DetectionCandidate `json:"candidates"`
+}
 
 // Environment is an interface for interacting with CI/CD environments
 type Environment interface {
@@ -54,22 +183,139 @@ type Environment interface {
 	IsCurrentEnvironment() bool
 }
 
+// RegisterEnvironment adds a third-party environment under the given source.
+// A registered environment overrides a built-in environment with the same source,
+// and is detected before any of the built-in environments.
+// Registering a source twice replaces the previous environment but keeps its detection priority
+func RegisterEnvironment(source enums.Source, env Environment) {
+	if env == nil {
+		panic(fmt.Sprintf("environments: RegisterEnvironment environment for %s is nil", source))
+	}
+
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	if _, ok := registeredEnvironments[source]; !ok {
+		registrationOrder = append(registrationOrder, source)
+	}
+	registeredEnvironments[source] = env
+}
+
+// UnregisterEnvironment removes an environment added with RegisterEnvironment.
+// If the source belongs to a built-in environment, the built-in environment is used again
+func UnregisterEnvironment(source enums.Source) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	if _, ok := registeredEnvironments[source]; !ok {
+		return
+	}
+	delete(registeredEnvironments, source)
+	for i, registered := range registrationOrder {
+		if registered == source {
+			registrationOrder = append(registrationOrder[:i:i], registrationOrder[i+1:]...)
+			break
+		}
+	}
+}
+
 // GetEnvironment get environment object that matches the name
 func GetEnvironment(name string) (Environment, error) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	if env, ok := registeredEnvironments[enums.Source(name)]; ok {
+		return env, nil
+	}
 	if env, ok := environmentMapping[enums.Source(name)]; ok {
 		return env, nil
 	}
 	return nil, fmt.Errorf("environment %s does not exist", name)
 }
 
+// Refresh loads the configuration of the environment again, environments that do not implement Refresher
+// load their configuration on every GetConfiguration
+func Refresh(env Environment) (*models.Configuration, error) {
+	if refresher, ok := env.(Refresher); ok {
+		return refresher.Refresh()
+	}
+	return env.GetConfiguration()
+}
+
+// GetConfigurationFrom builds the configuration of the environment from the given source, environments that do not implement SourceLoader
+// read the process environment with GetConfiguration
+func GetConfigurationFrom(env Environment, src envsource.EnvSource) (*models.Configuration, error) {
+	if loader, ok := env.(SourceLoader); ok {
+		return loader.GetConfigurationFrom(src)
+	}
+	return env.GetConfiguration()
+}
+
 // DetectEnvironment get environment by detecting
 func DetectEnvironment() Environment {
-	for _, env := range environmentMapping {
-		if env.IsCurrentEnvironment() {
-			return env
+	return DetectEnvironmentWithReport().Environment
+}
+
+// DetectEnvironmentWithReport detects the environment like DetectEnvironment,
+// and reports every environment that was evaluated, whether it matched and the variables that caused the match
+func DetectEnvironmentWithReport() *DetectionReport {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	report := &DetectionReport{}
+	for _, source := range detectionSources() {
+		env := registeredEnvironments[source]
+		if env == nil {
+			env = environmentMapping[source]
+		}
+
+		candidate := DetectionCandidate{
+			Source:      source,
+			Environment: env,
+			Matched:     env.IsCurrentEnvironment(),
+		}
+		if candidate.Matched {
+			candidate.MatchedVariables = getMatchedVariables(env)
+			if report.Environment == nil {
+				report.Environment = env
+				report.Source = source
+			}
+		}
+		report.Candidates = append(report.Candidates, candidate)
+	}
+
+	if report.Environment == nil {
+		report.Environment = localhost.Localhost
+		report.Source = enums.Localhost
+	}
+	return report
+}
+
+// detectionSources returns the sources in the order they should be detected -
+// registered environments first, then the built-in environments by their priority
+func detectionSources() []enums.Source {
+	sources := append([]enums.Source{}, registrationOrder...)
+	for _, source := range detectionOrder {
+		if _, overridden := registeredEnvironments[source]; !overridden {
+			sources = append(sources, source)
+		}
+	}
+	return sources
+}
+
+func getMatchedVariables(env Environment) []string {
+	getter, ok := env.(DetectionVariablesGetter)
+	if !ok {
+		return nil
+	}
+
+	variables := []string{}
+	for _, variable := range getter.DetectionVariables() {
+		if _, isExist := os.LookupEnv(variable); isExist {
+			variables = append(variables, variable)
 		}
 	}
-	return localhost.Localhost
+	return variables
 }
 
 func GetOrDetectEnvironment(name string) (Environment, error) {
@@ -84,6 +330,8 @@ func GetFileLineLink(source enums.Source, repositoryURL string, filename string,
 	switch source {
 	case enums.Github, enums.GithubServer:
 		f = github.GetFileLineLink
+	case enums.Gitea:
+		f = gitea.GetFileLineLink
 	case enums.Gitlab, enums.GitlabServer:
 		f = gitlab.GetFileLineLink
 	case enums.Azure, enums.AzureServer:
@@ -92,6 +340,12 @@ func GetFileLineLink(source enums.Source, repositoryURL string, filename string,
 		f = bitbucket.GetFileLineLink
 	case enums.BitbucketServer:
 		f = bitbucketserver.GetFileLineLink
+	case enums.Gerrit:
+		f = gerrit.GetFileLineLink
+	case enums.CodeCommit:
+		f = codecommit.GetFileLineLink
+	case enums.SourceHut:
+		f = sourcehut.GetFileLineLink
 	}
 
 	if f != nil {
@@ -106,6 +360,8 @@ func GetFileLink(source enums.Source, repositoryURL string, filename string, bra
 	switch source {
 	case enums.Github, enums.GithubServer:
 		f = github.GetFileLink
+	case enums.Gitea:
+		f = gitea.GetFileLink
 	case enums.Gitlab, enums.GitlabServer:
 		f = gitlab.GetFileLink
 	case enums.Azure, enums.AzureServer:
@@ -114,6 +370,12 @@ func GetFileLink(source enums.Source, repositoryURL string, filename string, bra
 		f = bitbucket.GetFileLink
 	case enums.BitbucketServer:
 		f = bitbucketserver.GetFileLink
+	case enums.Gerrit:
+		f = gerrit.GetFileLink
+	case enums.CodeCommit:
+		f = codecommit.GetFileLink
+	case enums.SourceHut:
+		f = sourcehut.GetFileLink
 	}
 
 	if f != nil {
@@ -122,3 +384,180 @@ func GetFileLink(source enums.Source, repositoryURL string, filename string, bra
 
 	return ""
 }
+
+// ParseFileLink is the inverse of GetFileLineLink, it returns the source, the repository url, the file, the ref and the lines of a file link.
+// The ref is refs/heads/<branch> for a branch, refs/tags/<tag> for a tag and the commit for a commit, a 40 character sha is a commit
+// when the link does not tell a branch from a commit. The end line is the start line when the link is to a single line.
+// GitHub, GitLab, Gitea, Gerrit and Bitbucket Cloud put the ref and the file in the same path, so a branch with a slash can't be told
+// from the file and the ref is read up to the first slash, use ParseFileLinkWithBranches to resolve such branches
+func ParseFileLink(link string) (enums.Source, string, string, string, int, int, error) {
+	return ParseFileLinkWithBranches(link, nil)
+}
+
+// ParseFileLinkWithBranches parses a file link like ParseFileLink, and resolves branches with slashes by the branches of the repository,
+// i.e. the branches of git ls-remote. Branches are names or refs/heads/ refs, the longest branch that the path of the link starts with is the ref
+func ParseFileLinkWithBranches(link string, branches []string) (enums.Source, string, string, string, int, int, error) {
+	source := utils.GetFileLinkSource(link)
+
+	var f ParseFileLineLinkFunc
+	switch source {
+	case enums.Github, enums.GithubServer:
+		f = github.ParseFileLineLink
+	case enums.Gitea:
+		f = gitea.ParseFileLineLink
+	case enums.Gitlab, enums.GitlabServer:
+		f = gitlab.ParseFileLineLink
+	case enums.Azure, enums.AzureServer:
+		f = azure.ParseFileLineLink
+	case enums.Bitbucket:
+		f = bitbucket.ParseFileLineLink
+	case enums.BitbucketServer:
+		f = bitbucketserver.ParseFileLineLink
+	case enums.Gerrit:
+		f = gerrit.ParseFileLineLink
+	case enums.CodeCommit:
+		f = codecommit.ParseFileLineLink
+	case enums.SourceHut:
+		f = sourcehut.ParseFileLineLink
+	}
+
+	if f == nil {
+		return enums.Unknown, "", "", "", 0, 0, fmt.Errorf("could not parse file link: %s", link)
+	}
+
+	repositoryURL, filename, ref, startLine, endLine, err := f(link)
+	if err != nil {
+		return enums.Unknown, "", "", "", 0, 0, err
+	}
+	if isPathRefSource(source) {
+		ref, filename = resolveBranch(ref, filename, branches)
+	}
+	return source, repositoryURL, filename, ref, startLine, endLine, nil
+}
+
+// isPathRefSource returns whether the links of the source put the ref and the file in the same path
+func isPathRefSource(source enums.Source) bool {
+	switch source {
+	case enums.Github, enums.GithubServer, enums.Gitlab, enums.GitlabServer, enums.Gitea, enums.Gerrit, enums.Bitbucket:
+		return true
+	}
+	return false
+}
+
+// resolveBranch moves the start of the file to the branch ref when the branch and the file match a longer branch,
+// i.e. refs/heads/feature and x/path/to/file are refs/heads/feature/x and path/to/file for the feature/x branch
+func resolveBranch(ref string, filename string, branches []string) (string, string) {
+	branch, isTag := utils.ParseRef(ref)
+	if isTag || branch == ref {
+		return ref, filename
+	}
+
+	path := branch + "/" + filename
+	resolved := ""
+	for _, candidate := range branches {
+		name, _ := utils.ParseRef(candidate)
+		if len(name) > len(resolved) && strings.HasPrefix(path, name+"/") {
+			resolved = name
+		}
+	}
+	if resolved == "" {
+		return ref, filename
+	}
+	return "refs/heads/" + resolved, path[len(resolved)+1:]
+}
+
+func GetCommitLink(source enums.Source, repositoryURL string, commit string) string {
+	var f GetCommitLinkFunc
+	switch source {
+	case enums.Github, enums.GithubServer:
+		f = github.GetCommitLink
+	case enums.Gitea:
+		f = gitea.GetCommitLink
+	case enums.Gitlab, enums.GitlabServer:
+		f = gitlab.GetCommitLink
+	case enums.Azure, enums.AzureServer:
+		f = azure.GetCommitLink
+	case enums.Bitbucket:
+		f = bitbucket.GetCommitLink
+	case enums.BitbucketServer:
+		f = bitbucketserver.GetCommitLink
+	}
+
+	if f != nil {
+		return f(repositoryURL, commit)
+	}
+
+	return ""
+}
+
+func GetCompareLink(source enums.Source, repositoryURL string, baseCommit string, headCommit string) string {
+	var f GetCompareLinkFunc
+	switch source {
+	case enums.Github, enums.GithubServer:
+		f = github.GetCompareLink
+	case enums.Gitea:
+		f = gitea.GetCompareLink
+	case enums.Gitlab, enums.GitlabServer:
+		f = gitlab.GetCompareLink
+	case enums.Azure, enums.AzureServer:
+		f = azure.GetCompareLink
+	case enums.Bitbucket:
+		f = bitbucket.GetCompareLink
+	case enums.BitbucketServer:
+		f = bitbucketserver.GetCompareLink
+	}
+
+	if f != nil {
+		return f(repositoryURL, baseCommit, headCommit)
+	}
+
+	return ""
+}
+
+func GetPullRequestLink(source enums.Source, repositoryURL string, pullRequestId string) string {
+	var f GetPullRequestLinkFunc
+	switch source {
+	case enums.Github, enums.GithubServer:
+		f = github.GetPullRequestLink
+	case enums.Gitea:
+		f = gitea.GetPullRequestLink
+	case enums.Gitlab, enums.GitlabServer:
+		f = gitlab.GetPullRequestLink
+	case enums.Azure, enums.AzureServer:
+		f = azure.GetPullRequestLink
+	case enums.Bitbucket:
+		f = bitbucket.GetPullRequestLink
+	case enums.BitbucketServer:
+		f = bitbucketserver.GetPullRequestLink
+	}
+
+	if f != nil {
+		return f(repositoryURL, pullRequestId)
+	}
+
+	return ""
+}
+
+func GetRefLink(source enums.Source, repositoryURL string, ref string) string {
+	var f GetRefLinkFunc
+	switch source {
+	case enums.Github, enums.GithubServer:
+		f = github.GetRefLink
+	case enums.Gitea:
+		f = gitea.GetRefLink
+	case enums.Gitlab, enums.GitlabServer:
+		f = gitlab.GetRefLink
+	case enums.Azure, enums.AzureServer:
+		f = azure.GetRefLink
+	case enums.Bitbucket:
+		f = bitbucket.GetRefLink
+	case enums.BitbucketServer:
+		f = bitbucketserver.GetRefLink
+	}
+
+	if f != nil {
+		return f(repositoryURL, ref)
+	}
+
+	return ""
+}
@@ -0,0 +1,337 @@
+package argo
+
+import (
+	"encoding/json"
+	"os"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/utils"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/models"
+)
+
+const (
+	builder = "Argo Workflows"
+
+	// the executor passes the template of the step to every container of the pod
+	argoTemplateEnv = "ARGO_TEMPLATE"
+	argoNodeIdEnv   = "ARGO_NODE_ID"
+	argoPodNameEnv  = "ARGO_POD_NAME"
+	argoWorkflowEnv = "ARGO_WORKFLOW_NAME"
+	hostnameEnv     = "HOSTNAME"
+
+	// the wait container has the pod annotations mounted, other containers can mount them with the downward API
+	argoPodMetadataDir = "/argo/podmetadata"
+
+	workflowLabel         = "workflows.argoproj.io/workflow"
+	workflowTemplateLabel = "workflows.argoproj.io/workflow-template"
+	cronWorkflowLabel     = "workflows.argoproj.io/cron-workflow"
+	nodeNameAnnotation    = "workflows.argoproj.io/node-name"
+)
+
+var (
+	// Argo environment
+	Argo = New()
+
+	// the input parameter names that commonly hold the git parameters of a workflow
+	repoParameters     = []string{"repo", "repo-url", "repoUrl", "git-repo", "git-url"}
+	revisionParameters = []string{"revision", "commit", "sha", "git-revision"}
+	branchParameters   = []string{"branch", "git-branch"}
+
+	shaRegex = regexp.MustCompile("^[0-9a-f]{40}$")
+)
+
+// Environment is the Argo environment
+type Environment struct {
+	cache utils.ConfigurationCache
+	root  string
+}
+
+// template is the part of the ARGO_TEMPLATE variable that describes the inputs of the step
+type template struct {
+	Name   string `json:"name"`
+	Inputs struct {
+		Parameters []struct {
+			Name  string `json:"name"`
+			Value string `json:"value"`
+		} `json:"parameters"`
+		Artifacts []struct {
+			Name string `json:"name"`
+			Path string `json:"path"`
+			Git  *struct {
+				Repo     string `json:"repo"`
+				Revision string `json:"revision"`
+				Branch   string `json:"branch"`
+			} `json:"git"`
+		} `json:"artifacts"`
+	} `json:"inputs"`
+}
+
+// gitInputs are the git parameters that were passed into the step
+type gitInputs struct {
+	repo     string
+	revision string
+	branch   string
+	path     string
+}
+
+// New creates an Argo environment with its own configuration cache
+func New() *Environment {
+	return &Environment{}
+}
+
+// WithRoot sets the directory that the pod files, i.e. /argo/podmetadata and /etc/podinfo, are read from instead of "/"
+func (e *Environment) WithRoot(root string) *Environment {
+	e.root = root
+	return e
+}
+
+func (e *Environment) GetConfiguration() (*models.Configuration, error) {
+	return e.cache.Get(e.load)
+}
+
+// Refresh loads the configuration again and replaces the cached configuration
+func (e *Environment) Refresh() (*models.Configuration, error) {
+	return e.cache.Refresh(e.load)
+}
+
+// Reset clears the cached configuration, it is loaded again on the next GetConfiguration
+func (e *Environment) Reset() {
+	e.cache.Reset()
+}
+
+func (e *Environment) load() (*models.Configuration, error) {
+	return loadConfiguration(envsource.OSWithRoot(e.root))
+}
+
+func (e *Environment) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return loadConfiguration(src)
+}
+
+func loadConfiguration(src envsource.EnvSource) (*models.Configuration, error) {
+	stepTemplate, err := parseTemplate(src.Getenv(argoTemplateEnv))
+	if err != nil {
+		return nil, err
+	}
+	inputs := getGitInputs(stepTemplate)
+	labels := utils.ReadPodLabels(src, utils.PodInfoDir)
+	annotations := getPodAnnotations(src)
+
+	cloneUrl := inputs.repo
+	if cloneUrl == "" {
+		if cloneUrl, err = envsource.GitClient(src).GetGitRemoteURL(envsource.Path(src, inputs.path)); err != nil {
+			return nil, err
+		}
+	}
+	cloneUrl = utils.StripCredentialsFromUrl(cloneUrl)
+
+	source, apiUrl := utils.GetRepositorySource(cloneUrl)
+	repoUrl, org, repoName, repoFullName, err := utils.ParseDataFromCloneUrl(cloneUrl, apiUrl, source)
+	if err != nil {
+		return nil, err
+	}
+
+	// the revision of a git artifact is either a commit or a branch
+	commit, branch := "", inputs.branch
+	if shaRegex.MatchString(inputs.revision) {
+		commit = inputs.revision
+	} else if branch == "" {
+		branch = strings.TrimPrefix(inputs.revision, "refs/heads/")
+	}
+	if commit == "" && inputs.path != "" {
+		if commit, err = envsource.GitClient(src).GetGitCommit(envsource.Path(src, inputs.path)); err != nil {
+			return nil, err
+		}
+	}
+
+	var warnings []models.Warning
+	if branch == "" && inputs.path != "" {
+		branch, warnings, _ = envsource.GetGitBranch(src, inputs.path, commit)
+	}
+
+	workflowName := getWorkflowName(src, labels, annotations)
+	return &models.Configuration{
+		SCMApiUrl: apiUrl,
+		LocalPath: inputs.path,
+		CommitSha: commit,
+		Branch:    branch,
+		Namespace: utils.GetPodNamespace(src, utils.PodInfoDir),
+		Repository: models.Repository{
+			Name:     repoName,
+			FullName: repoFullName,
+			Url:      repoUrl,
+			CloneUrl: cloneUrl,
+			Source:   source,
+		},
+		Organization: models.Entity{
+			Name: org,
+		},
+		Pipeline: models.Pipeline{
+			Entity: models.Entity{
+				Id:   getPipelineName(labels, workflowName),
+				Name: getPipelineName(labels, workflowName),
+			},
+		},
+		Job: models.Entity{
+			Id:   src.Getenv(argoNodeIdEnv),
+			Name: stepTemplate.Name,
+		},
+		Run: models.BuildRun{
+			BuildId: workflowName,
+		},
+		Runner: models.Runner{
+			Name:         getRunnerName(src),
+			OS:           runtime.GOOS,
+			Architecture: runtime.GOARCH,
+		},
+		Builder: builder,
+		Pusher: models.Pusher{
+			Username: utils.DetectPusherFrom(src),
+		},
+		PipelinePaths: []string{},
+		Environment:   enums.Argo,
+		ScmId:         utils.GenerateScmId(cloneUrl),
+		Warnings:      warnings,
+	}, nil
+}
+
+func parseTemplate(value string) (*template, error) {
+	stepTemplate := &template{}
+	if value == "" {
+		return stepTemplate, nil
+	}
+	if err := json.Unmarshal([]byte(value), stepTemplate); err != nil {
+		return nil, err
+	}
+	return stepTemplate, nil
+}
+
+// getGitInputs returns the git parameters of the step from its git input artifact,
+// or from input parameters with the conventional names
+func getGitInputs(stepTemplate *template) gitInputs {
+	inputs := gitInputs{}
+	for _, artifact := range stepTemplate.Inputs.Artifacts {
+		if artifact.Git != nil {
+			inputs = gitInputs{
+				repo:     artifact.Git.Repo,
+				revision: artifact.Git.Revision,
+				branch:   artifact.Git.Branch,
+				path:     artifact.Path,
+			}
+			break
+		}
+	}
+
+	parameters := map[string]string{}
+	for _, parameter := range stepTemplate.Inputs.Parameters {
+		parameters[parameter.Name] = parameter.Value
+	}
+	if inputs.repo == "" {
+		inputs.repo = firstParameter(parameters, repoParameters)
+	}
+	if inputs.revision == "" {
+		inputs.revision = firstParameter(parameters, revisionParameters)
+	}
+	if inputs.branch == "" {
+		inputs.branch = firstParameter(parameters, branchParameters)
+	}
+	return inputs
+}
+
+func firstParameter(parameters map[string]string, names []string) string {
+	for _, name := range names {
+		if value := parameters[name]; value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// getPodAnnotations returns the annotations that the executor mounts, or that were mounted with the downward API
+func getPodAnnotations(src envsource.EnvSource) map[string]string {
+	if annotations := utils.ReadPodAnnotations(src, argoPodMetadataDir); len(annotations) > 0 {
+		return annotations
+	}
+	return utils.ReadPodAnnotations(src, utils.PodInfoDir)
+}
+
+// getWorkflowName returns the name of the workflow, the node name of a step starts with the workflow name, i.e. build-x7k2p.checkout
+func getWorkflowName(src envsource.EnvSource, labels map[string]string, annotations map[string]string) string {
+	if workflowName := src.Getenv(argoWorkflowEnv); workflowName != "" {
+		return workflowName
+	}
+	if workflowName := labels[workflowLabel]; workflowName != "" {
+		return workflowName
+	}
+	nodeName := annotations[nodeNameAnnotation]
+	if index := strings.Index(nodeName, "."); index != -1 {
+		return nodeName[:index]
+	}
+	return nodeName
+}
+
+// getPipelineName returns the WorkflowTemplate or CronWorkflow that the workflow was submitted from, or the workflow itself
+func getPipelineName(labels map[string]string, workflowName string) string {
+	for _, label := range []string{workflowTemplateLabel, cronWorkflowLabel} {
+		if name := labels[label]; name != "" {
+			return name
+		}
+	}
+	return workflowName
+}
+
+func getRunnerName(src envsource.EnvSource) string {
+	if podName := src.Getenv(argoPodNameEnv); podName != "" {
+		return podName
+	}
+	return src.Getenv(hostnameEnv)
+}
+
+// GetStepLink returns an empty link, the Argo server URL is not known to the workflow pods
+func (e *Environment) GetStepLink() string {
+	return ""
+}
+
+// GetBuildLink returns an empty link, the Argo server URL is not known to the workflow pods
+func (e *Environment) GetBuildLink() string {
+	return ""
+}
+
+func (e *Environment) GetFileLink(filename string, branch string, commit string) string {
+	return ""
+}
+
+func (e *Environment) GetFileLineLink(filename string, branch string, commit string, startLine int, endLine int) string {
+	return ""
+}
+
+func (e *Environment) IsCurrentEnvironment() bool {
+	_, isExist := os.LookupEnv(argoTemplateEnv)
+	return isExist
+}
+
+// DetectionVariables returns the variables used by IsCurrentEnvironment
+func (e *Environment) DetectionVariables() []string {
+	return []string{argoTemplateEnv}
+}
+
+// ExpectedFields returns the configuration fields the environment is expected to fill and the variables they are filled from
+func (e *Environment) ExpectedFields() []models.ExpectedField {
+	return []models.ExpectedField{
+		{Field: "repository.url", SourceEnv: argoTemplateEnv, Severity: models.SeverityCritical},
+		{Field: "repository.cloneUrl", SourceEnv: argoTemplateEnv, Severity: models.SeverityCritical},
+		{Field: "commitSha", SourceEnv: argoTemplateEnv, Severity: models.SeverityCritical},
+		{Field: "branch", SourceEnv: argoTemplateEnv, Severity: models.SeverityWarning},
+		{Field: "namespace", SourceEnv: utils.PodInfoDir, Severity: models.SeverityWarning},
+		{Field: "pipeline.name", SourceEnv: workflowLabel, Severity: models.SeverityWarning},
+		{Field: "job.id", SourceEnv: argoNodeIdEnv, Severity: models.SeverityWarning},
+		{Field: "run.buildId", SourceEnv: workflowLabel, Severity: models.SeverityWarning},
+	}
+}
+
+func (e *Environment) Name() string {
+	return "argo"
+}
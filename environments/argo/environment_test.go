@@ -0,0 +1,181 @@
+package argo
+
+import (
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/testutils"
+	"github.com/argonsecurity/go-environments/models"
+	"github.com/otiai10/copy"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	argoArtifactEnvsFilePath   = "testdata/argo-artifact-env.json"
+	argoParametersEnvsFilePath = "testdata/argo-parameters-env.json"
+	artifactRootPath           = "testdata/artifact-root"
+	parametersRootPath         = "testdata/parameters-root"
+	testRootPath               = "/tmp/argo/root"
+)
+
+func Test_environment_GetConfiguration(t *testing.T) {
+	tests := []struct {
+		name         string
+		rootPath     string
+		envsFilePath string
+		want         *models.Configuration
+		wantErr      bool
+	}{
+		{
+			name:         "Argo git artifact configuration",
+			rootPath:     artifactRootPath,
+			envsFilePath: argoArtifactEnvsFilePath,
+			want: &models.Configuration{
+				SCMApiUrl: "https://api.github.com",
+				LocalPath: "/src",
+				CommitSha: "2c6e3880fd94ddb7ef72d34e683cdc0c47bec6e6",
+				Branch:    "main",
+				Namespace: "argo",
+				Repository: models.Repository{
+					Name:     "test-repo",
+					FullName: "test-org/test-repo",
+					Url:      "https://github.com/test-org/test-repo",
+					CloneUrl: "https://github.com/test-org/test-repo.git",
+					Source:   enums.Github,
+				},
+				Organization: models.Entity{
+					Name: "test-org",
+				},
+				Pipeline: models.Pipeline{
+					Entity: models.Entity{
+						Id:   "build",
+						Name: "build",
+					},
+				},
+				Job: models.Entity{
+					Id:   "build-x7k2p-3187253518",
+					Name: "build",
+				},
+				Run: models.BuildRun{
+					BuildId: "build-x7k2p",
+				},
+				Runner: models.Runner{
+					Name:         "build-x7k2p-build-3187253518",
+					OS:           runtime.GOOS,
+					Architecture: runtime.GOARCH,
+				},
+				Builder:       "Argo Workflows",
+				PipelinePaths: []string{},
+				Environment:   enums.Argo,
+				ScmId:         "b30f418cdcc9970849d3d031de5df54f",
+			},
+		},
+		{
+			name:         "Argo input parameters configuration",
+			rootPath:     parametersRootPath,
+			envsFilePath: argoParametersEnvsFilePath,
+			want: &models.Configuration{
+				SCMApiUrl: "https://gitlab.com/api/v4",
+				Branch:    "feature/test",
+				Namespace: "ci",
+				Repository: models.Repository{
+					Name:     "test-repo",
+					FullName: "test-group/test-repo",
+					Url:      "https://gitlab.com/test-group/test-repo",
+					CloneUrl: "https://gitlab.com/test-group/test-repo.git",
+					Source:   enums.Gitlab,
+				},
+				Organization: models.Entity{
+					Name: "test-group",
+				},
+				Pipeline: models.Pipeline{
+					Entity: models.Entity{
+						Id:   "nightly-scan-1709546400",
+						Name: "nightly-scan-1709546400",
+					},
+				},
+				Job: models.Entity{
+					Id:   "nightly-scan-1709546400-1125732011",
+					Name: "scan",
+				},
+				Run: models.BuildRun{
+					BuildId: "nightly-scan-1709546400",
+				},
+				Runner: models.Runner{
+					Name:         "nightly-scan-1709546400-scan-1125732011",
+					OS:           runtime.GOOS,
+					Architecture: runtime.GOARCH,
+				},
+				Builder:       "Argo Workflows",
+				PipelinePaths: []string{},
+				Environment:   enums.Argo,
+				ScmId:         "5992c2ba95a922426aae55f34afec4ed",
+			},
+		},
+		{
+			name:         "Invalid template",
+			envsFilePath: "testdata/argo-invalid-template-env.json",
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := prepareTest(t, tt.rootPath, tt.envsFilePath)
+			got, err := e.GetConfiguration()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("environment.GetConfiguration() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_environment_IsCurrentEnvironment(t *testing.T) {
+	tests := []struct {
+		name         string
+		envsFilePath string
+		want         bool
+	}{
+		{
+			name:         "Argo environment",
+			envsFilePath: argoArtifactEnvsFilePath,
+			want:         true,
+		},
+		{
+			name:         "Jenkins environment",
+			envsFilePath: "../jenkins/testdata/jenkins-github-main-full-env.json",
+			want:         false,
+		},
+		{
+			name:         "Not Argo environment",
+			envsFilePath: "",
+			want:         false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := prepareTest(t, "", tt.envsFilePath)
+			if got := e.IsCurrentEnvironment(); got != tt.want {
+				t.Errorf("environment.IsCurrentEnvironment() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// prepareTest lays out the files of the workflow pod under testRootPath
+func prepareTest(t *testing.T, rootPath string, envsFilePath string) *Environment {
+	e := New().WithRoot(testRootPath)
+	os.RemoveAll(testRootPath)
+	if rootPath != "" {
+		if err := copy.Copy(rootPath, testRootPath); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Cleanup(func() { os.RemoveAll(testRootPath) })
+	envCleanup := testutils.SetEnvsFromFile(envsFilePath)
+	t.Cleanup(envCleanup)
+	return e
+}
@@ -12,8 +12,7 @@ import (
 
 	"github.com/argonsecurity/go-environments/enums"
 	"github.com/argonsecurity/go-environments/environments/utils"
-	"github.com/argonsecurity/go-environments/environments/utils/git"
-	"github.com/argonsecurity/go-environments/logger"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
 	"github.com/argonsecurity/go-environments/models"
 	schemavalidator "github.com/argonsecurity/go-environments/schema-validator"
 )
@@ -66,121 +65,148 @@ var (
 	azurePipelinesSchema []byte
 
 	// Azure environment
-	Azure         = environment{}
-	configuration *models.Configuration
-	baseUrlRegex  = regexp.MustCompile(`https:\/\/[\w.]*(dev.azure.com|vsassets.io|vsassets.io|msauth.net|msftauth.net|visualstudio.com|azure.net|microsoft.com|azurecomcdn.azureedge.net|live.com|microsoftonline.com|management.azure.com|sharepointonline.com|.windows.net|azureedge.net)`)
+	Azure        = New()
+	baseUrlRegex = regexp.MustCompile(`https:\/\/[\w.]*(dev.azure.com|vsassets.io|vsassets.io|msauth.net|msftauth.net|visualstudio.com|azure.net|microsoft.com|azurecomcdn.azureedge.net|live.com|microsoftonline.com|management.azure.com|sharepointonline.com|.windows.net|azureedge.net)`)
 )
 
-type environment struct{}
+// Environment is the Azure environment
+type Environment struct {
+	cache utils.ConfigurationCache
+}
 
-func (e environment) GetConfiguration() (*models.Configuration, error) {
-	if configuration == nil {
-		if err := loadConfiguration(); err != nil {
-			return nil, err
-		}
-	}
-	return configuration, nil
+// New creates an Azure environment with its own configuration cache
+func New() *Environment {
+	return &Environment{}
+}
+
+func (e *Environment) GetConfiguration() (*models.Configuration, error) {
+	return e.cache.Get(e.load)
+}
+
+// Refresh loads the configuration again and replaces the cached configuration
+func (e *Environment) Refresh() (*models.Configuration, error) {
+	return e.cache.Refresh(e.load)
+}
+
+// Reset clears the cached configuration, it is loaded again on the next GetConfiguration
+func (e *Environment) Reset() {
+	e.cache.Reset()
 }
 
-func loadConfiguration() error {
-	repoPath := os.Getenv(repositoryPathEnv)
-	repoUrl := os.Getenv(repositoryUriEnv)
-	cloneUrl, err := git.GetGitRemoteURL(repoPath)
+func (e *Environment) load() (*models.Configuration, error) {
+	return loadConfiguration(envsource.OS)
+}
+
+func (e *Environment) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return loadConfiguration(src)
+}
 
+func loadConfiguration(src envsource.EnvSource) (*models.Configuration, error) {
+	repoPath := src.Getenv(repositoryPathEnv)
+	repoUrl := src.Getenv(repositoryUriEnv)
+	cloneUrl, err := envsource.GitClient(src).GetGitRemoteURL(envsource.Path(src, repoPath))
+
+	var warnings []models.Warning
+	if err != nil {
+		warnings = append(warnings, models.NewWarning(models.GitRemoteUrlWarning, err, "failed to get the git remote url of %s, using %s", repoPath, repoUrl))
+	}
 	if err != nil || cloneUrl == "" || !strings.HasSuffix(cloneUrl, ".git") {
 		cloneUrl = fmt.Sprintf("%s.git", repoUrl)
 	}
 
 	strippedCloneUrl := utils.StripCredentialsFromUrl(cloneUrl)
 	scmId := utils.GenerateScmId(strippedCloneUrl)
-	source := getSource()
+	source := getSource(src)
 
-	userName := os.Getenv(usernameEnv)
+	userName := src.Getenv(usernameEnv)
 	if userName == "" {
-		userName = utils.DetectPusher()
+		userName = utils.DetectPusherFrom(src)
 	}
 
-	configuration = &models.Configuration{
-		Url:       os.Getenv(endpointURLEnv),
-		SCMApiUrl: os.Getenv(azureDevopsApiUrlEnv),
+	pipelinePaths, pipelinesWarnings := getPipelinePaths(src, repoPath)
+	warnings = append(warnings, pipelinesWarnings...)
+
+	return &models.Configuration{
+		Url:       src.Getenv(endpointURLEnv),
+		SCMApiUrl: src.Getenv(azureDevopsApiUrlEnv),
 		LocalPath: repoPath,
-		Branch:    getBranch(),
-		ProjectId: os.Getenv(projectIDEnv),
-		CommitSha: os.Getenv(commitShaEnv),
+		Branch:    getBranch(src),
+		ProjectId: src.Getenv(projectIDEnv),
+		CommitSha: src.Getenv(commitShaEnv),
 		Organization: models.Entity{
-			Id:   os.Getenv(collectionIdEnv),
-			Name: getOrganizationName(os.Getenv(collectionUriEnv)),
+			Id:   src.Getenv(collectionIdEnv),
+			Name: getOrganizationName(src.Getenv(collectionUriEnv)),
 		},
 		Repository: models.Repository{
-			Id:       os.Getenv(repositoryIdEnv),
-			Name:     os.Getenv(repositoryNameEnv),
-			FullName: os.Getenv(repositoryFullNameEnv),
+			Id:       src.Getenv(repositoryIdEnv),
+			Name:     src.Getenv(repositoryNameEnv),
+			FullName: src.Getenv(repositoryFullNameEnv),
 			Url:      repoUrl,
 			CloneUrl: strippedCloneUrl,
 			Source:   source,
 		},
 		Pusher: models.Pusher{
 			Username: userName,
-			Email:    os.Getenv(userEmailEnv),
+			Email:    src.Getenv(userEmailEnv),
 		},
 		Job: models.Entity{
-			Id:   os.Getenv(jobNameEnv),
-			Name: os.Getenv(jobNameEnv),
+			Id:   src.Getenv(jobNameEnv),
+			Name: src.Getenv(jobNameEnv),
 		},
 		Pipeline: models.Pipeline{
 			Entity: models.Entity{
-				Id:   os.Getenv(definitionIDEnv),
-				Name: os.Getenv(pipelineNameEnv),
+				Id:   src.Getenv(definitionIDEnv),
+				Name: src.Getenv(pipelineNameEnv),
 			},
 		},
 		Run: models.BuildRun{
-			BuildId:     os.Getenv(buildIDEnv),
-			BuildNumber: os.Getenv(buildNumberEnv),
+			BuildId:     src.Getenv(buildIDEnv),
+			BuildNumber: src.Getenv(buildNumberEnv),
 		},
 		Runner: models.Runner{
-			Id:           os.Getenv(agentIDEnv),
-			Name:         os.Getenv(agentNameEnv),
-			OS:           os.Getenv(agentOSEnv),
-			Distribution: os.Getenv(imageOSEnv),
-			Architecture: os.Getenv(agentOSArchitectureEnv),
+			Id:           src.Getenv(agentIDEnv),
+			Name:         src.Getenv(agentNameEnv),
+			OS:           src.Getenv(agentOSEnv),
+			Distribution: src.Getenv(imageOSEnv),
+			Architecture: src.Getenv(agentOSArchitectureEnv),
 		},
 		PullRequest: models.PullRequest{
-			Id: os.Getenv(pullRequestIdEnv),
+			Id: src.Getenv(pullRequestIdEnv),
 			SourceRef: models.Ref{
-				Branch: os.Getenv(pullRequestSourceBranchEnv),
+				Branch: src.Getenv(pullRequestSourceBranchEnv),
 			},
 			TargetRef: models.Ref{
-				Branch: os.Getenv(pullRequestTargetBranchEnv),
+				Branch: src.Getenv(pullRequestTargetBranchEnv),
 			},
 		},
-		PipelinePaths: getPipelinePaths(repoPath),
+		PipelinePaths: pipelinePaths,
 		Environment:   source,
 		ScmId:         scmId,
-	}
-	return nil
+		Warnings:      warnings,
+	}, nil
 }
 
-func (e environment) GetStepLink() string {
+func (e *Environment) GetStepLink() string {
 	return fmt.Sprintf("%s%s/_build/results?buildId=%s&view=logs&j=%s&t=%s", os.Getenv(endpointURLEnv), os.Getenv(projectNameEnv),
 		os.Getenv(buildIDEnv), os.Getenv(jobIDEnv), os.Getenv(taskInstanceIDEnv))
 }
 
-func (e environment) GetBuildLink() string {
+func (e *Environment) GetBuildLink() string {
 	return fmt.Sprintf("%s%s/_build?definitionId=%s&_a=summary", os.Getenv(endpointURLEnv), os.Getenv(projectNameEnv), os.Getenv(definitionIDEnv))
 }
 
-func (e environment) GetFileLink(filename string, branch string, commit string) string {
+func (e *Environment) GetFileLink(filename string, branch string, commit string) string {
 	return GetFileLink(
-		fmt.Sprintf("%s_git/%s", os.Getenv(endpointURLEnv), os.Getenv(repositoryNameEnv)),
+		getRepositoryUrl(),
 		filename,
 		branch,
 		commit,
 	)
 }
 
-func (e environment) GetFileLineLink(filename string, branch string, commit string, startLine int, endLine int) string {
+func (e *Environment) GetFileLineLink(filename string, branch string, commit string, startLine int, endLine int) string {
 	return GetFileLineLink(
-		fmt.Sprintf("%s_git/%s", os.Getenv(endpointURLEnv), os.Getenv(repositoryNameEnv)),
+		getRepositoryUrl(),
 		filename,
 		branch,
 		commit,
@@ -189,6 +215,22 @@ func (e environment) GetFileLineLink(filename string, branch string, commit stri
 	)
 }
 
+func (e *Environment) GetCommitLink(commit string) string {
+	return GetCommitLink(getRepositoryUrl(), commit)
+}
+
+func (e *Environment) GetCompareLink(baseCommit string, headCommit string) string {
+	return GetCompareLink(getRepositoryUrl(), baseCommit, headCommit)
+}
+
+func (e *Environment) GetPullRequestLink(pullRequestId string) string {
+	return GetPullRequestLink(getRepositoryUrl(), pullRequestId)
+}
+
+func (e *Environment) GetRefLink(ref string) string {
+	return GetRefLink(getRepositoryUrl(), ref)
+}
+
 func GetFileLineLink(repositoryURL string, filename string, branch string, commit string, startLine, endLine int) string {
 	fileLink := GetFileLink(repositoryURL, filename, branch, commit)
 	if startLine != 0 {
@@ -219,16 +261,16 @@ func GetFileLink(repositoryURL string, filename string, branch string, commit st
 	)
 }
 
-func getBranch() string {
-	if os.Getenv(buildReasonEnv) == azurePullRequestEvent {
-		return os.Getenv(pullRequestSourceBranchEnv)
+func getBranch(src envsource.EnvSource) string {
+	if src.Getenv(buildReasonEnv) == azurePullRequestEvent {
+		return src.Getenv(pullRequestSourceBranchEnv)
 	}
 
-	return os.Getenv(branchEnv)
+	return src.Getenv(branchEnv)
 }
 
-func getSource() enums.Source {
-	if baseUrlRegex.MatchString(os.Getenv(collectionUriEnv)) {
+func getSource(src envsource.EnvSource) enums.Source {
+	if baseUrlRegex.MatchString(src.Getenv(collectionUriEnv)) {
 		return enums.Azure
 	}
 	return enums.AzureServer
@@ -244,35 +286,78 @@ func getOrganizationName(collectionURI string) string {
 	return split[len(split)-1]
 }
 
-func (e environment) IsCurrentEnvironment() bool {
-	_, isExist := os.LookupEnv("BUILD_BUILDID")
+func (e *Environment) IsCurrentEnvironment() bool {
+	_, isExist := os.LookupEnv(DetectionVariable)
 	return isExist
 }
 
-func (e environment) Name() string {
+// DetectionVariables returns the variables used by IsCurrentEnvironment
+func (e *Environment) DetectionVariables() []string {
+	return []string{DetectionVariable}
+}
+
+// ExpectedFields returns the configuration fields the environment is expected to fill and the variables they are filled from
+func (e *Environment) ExpectedFields() []models.ExpectedField {
+	return []models.ExpectedField{
+		{Field: "repository.url", SourceEnv: repositoryUriEnv, Severity: models.SeverityCritical},
+		{Field: "repository.cloneUrl", SourceEnv: repositoryUriEnv, Severity: models.SeverityCritical},
+		{Field: "commitSha", SourceEnv: commitShaEnv, Severity: models.SeverityCritical},
+		{Field: "branch", SourceEnv: branchEnv, Severity: models.SeverityWarning},
+		{Field: "url", SourceEnv: endpointURLEnv, Severity: models.SeverityWarning},
+		{Field: "localPath", SourceEnv: repositoryPathEnv, Severity: models.SeverityWarning},
+		{Field: "projectId", SourceEnv: projectIDEnv, Severity: models.SeverityWarning},
+		{Field: "repository.id", SourceEnv: repositoryIdEnv, Severity: models.SeverityWarning},
+		{Field: "repository.name", SourceEnv: repositoryNameEnv, Severity: models.SeverityWarning},
+		{Field: "pipeline.id", SourceEnv: definitionIDEnv, Severity: models.SeverityWarning},
+		{Field: "job.name", SourceEnv: jobNameEnv, Severity: models.SeverityWarning},
+		{Field: "run.buildId", SourceEnv: buildIDEnv, Severity: models.SeverityWarning},
+		{Field: "runner.name", SourceEnv: agentNameEnv, Severity: models.SeverityWarning},
+		{Field: "organization.name", SourceEnv: collectionUriEnv, Severity: models.SeverityWarning},
+	}
+}
+
+func (e *Environment) Name() string {
 	return "azure"
 }
 
-func getPipelinePaths(rootDir string) []string {
+func getPipelinePaths(src envsource.EnvSource, rootDir string) ([]string, []models.Warning) {
 	paths := make([]string, 0)
+	var warnings []models.Warning
 
-	filepath.Walk(rootDir, func(path string, info fs.FileInfo, err error) error {
-		if (filepath.Ext(path) == ".yml" || filepath.Ext(path) == ".yaml") && isAzurePipeline(path, azurePipelinesSchema) {
+	err := envsource.Walk(src, rootDir, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			warnings = append(warnings, models.NewWarning(models.PipelinePathsWarning, err, "failed to search %s for pipeline files", path))
+			return nil
+		}
+		if filepath.Ext(path) != ".yml" && filepath.Ext(path) != ".yaml" {
+			return nil
+		}
+		isPipeline, err := isAzurePipeline(src, path, azurePipelinesSchema)
+		if err != nil {
+			warnings = append(warnings, models.NewWarning(models.PipelinePathsWarning, err, "failed to read yml file %s", path))
+		} else if isPipeline {
 			paths = append(paths, path)
 		}
 		return nil
 	})
+	if err != nil {
+		warnings = append(warnings, models.NewWarning(models.PipelinePathsWarning, err, "failed to search %s for pipeline files", rootDir))
+	}
 
-	return paths
+	return paths, warnings
 }
 
-func isAzurePipeline(filePath string, schema []byte) bool {
-	fileData, err := os.ReadFile(filePath)
+func isAzurePipeline(src envsource.EnvSource, filePath string, schema []byte) (bool, error) {
+	fileData, err := envsource.ReadFile(src, filePath)
 	if err != nil {
-		logger.Warnf("Failed to read yml file %s", filePath)
-		return false
+		return false, err
 	}
 
 	err = schemavalidator.ValidateYaml(fileData, schema)
-	return err == nil
+	return err == nil, nil
+}
+
+// getRepositoryUrl returns the url of the repository on Azure DevOps
+func getRepositoryUrl() string {
+	return fmt.Sprintf("%s_git/%s", os.Getenv(endpointURLEnv), os.Getenv(repositoryNameEnv))
 }
@@ -3,10 +3,13 @@ package azure
 import (
 	_ "embed"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/argonsecurity/go-environments/enums"
 	"github.com/argonsecurity/go-environments/environments/testutils"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
 	"github.com/argonsecurity/go-environments/models"
 	"github.com/stretchr/testify/assert"
 )
@@ -411,6 +414,14 @@ func Test_environment_GetFileLineLink(t *testing.T) {
 	}
 }
 
+func Test_environment_GetCommitAndRefLinks(t *testing.T) {
+	e := prepareTest(t, azureMainEnvsFilePath)
+	assert.Equal(t, "https://dev.azure.com/test-organization/_git/test-repo/commit/0a1b2c", e.GetCommitLink("0a1b2c"))
+	assert.Equal(t, "https://dev.azure.com/test-organization/_git/test-repo/branchCompare?baseVersion=GC9f8e7d&targetVersion=GC0a1b2c&_a=files", e.GetCompareLink("9f8e7d", "0a1b2c"))
+	assert.Equal(t, "https://dev.azure.com/test-organization/_git/test-repo/pullrequest/12", e.GetPullRequestLink("12"))
+	assert.Equal(t, "https://dev.azure.com/test-organization/_git/test-repo?version=GTv1.0", e.GetRefLink("refs/tags/v1.0"))
+}
+
 func TestGetFileLink(t *testing.T) {
 	type args struct {
 		repositoryURL string
@@ -571,9 +582,8 @@ func Test_environment_IsCurrentEnvironment(t *testing.T) {
 	}
 }
 
-func prepareTest(t *testing.T, envsFilePath string) environment {
-	e := environment{}
-	configuration = nil
+func prepareTest(t *testing.T, envsFilePath string) *Environment {
+	e := New()
 	testRepoCleanup := testutils.PrepareTestGitRepository(testRepoPath, testRepoCloneUrl, testdataPath)
 	t.Cleanup(testRepoCleanup)
 	envCleanup := testutils.SetEnvsFromFile(envsFilePath)
@@ -627,3 +637,15 @@ func Test_getOrganizationName(t *testing.T) {
 		})
 	}
 }
+
+func Test_getPipelinePathsWarnings(t *testing.T) {
+	rootDir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(rootDir, "azure-pipelines.yml"), []byte("steps:\n  - script: echo test\n"), 0644))
+	assert.NoError(t, os.Symlink(filepath.Join(rootDir, "missing.yml"), filepath.Join(rootDir, "broken.yml")))
+
+	paths, warnings := getPipelinePaths(envsource.OS, rootDir)
+	assert.Equal(t, []string{filepath.Join(rootDir, "azure-pipelines.yml")}, paths)
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, models.PipelinePathsWarning, warnings[0].Code)
+	assert.Contains(t, warnings[0].Error(), "failed to read yml file "+filepath.Join(rootDir, "broken.yml"))
+}
@@ -0,0 +1,96 @@
+package azure
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/argonsecurity/go-environments/environments/utils"
+)
+
+// Azure Repos addresses versions with a type prefix, GC for a commit, GB for a branch and GT for a tag
+
+func GetCommitLink(repositoryURL string, commit string) string {
+	if commit == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/commit/%s", repositoryURL, commit)
+}
+
+func GetCompareLink(repositoryURL string, baseCommit string, headCommit string) string {
+	if baseCommit == "" || headCommit == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/branchCompare?baseVersion=%s&targetVersion=%s&_a=files",
+		repositoryURL,
+		url.QueryEscape(fmt.Sprintf("GC%s", baseCommit)),
+		url.QueryEscape(fmt.Sprintf("GC%s", headCommit)),
+	)
+}
+
+func GetPullRequestLink(repositoryURL string, pullRequestId string) string {
+	if pullRequestId == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/pullrequest/%s", repositoryURL, pullRequestId)
+}
+
+func GetRefLink(repositoryURL string, ref string) string {
+	name, isTag := utils.ParseRef(ref)
+	if name == "" {
+		return ""
+	}
+	version := fmt.Sprintf("GB%s", name)
+	if isTag {
+		version = fmt.Sprintf("GT%s", name)
+	}
+	return fmt.Sprintf("%s?version=%s", repositoryURL, url.QueryEscape(version))
+}
+
+// ParseFileLineLink returns the repository url, the file, the ref and the lines of a link built by GetFileLineLink,
+// the ref is refs/heads/<branch> for a GB version, refs/tags/<tag> for a GT version and the commit for a GC version.
+// The end line is the start line for a single line
+func ParseFileLineLink(link string) (string, string, string, int, int, error) {
+	repositoryURL, rawQuery, found := strings.Cut(link, "?")
+	if !found {
+		return "", "", "", 0, 0, fmt.Errorf("could not parse file link: %s", link)
+	}
+
+	// The path and the version are path escaped, so + is not a space like in url.ParseQuery
+	query := map[string]string{}
+	for _, parameter := range strings.Split(rawQuery, "&") {
+		key, value, _ := strings.Cut(parameter, "=")
+		unescaped, err := url.PathUnescape(value)
+		if err != nil {
+			return "", "", "", 0, 0, fmt.Errorf("could not parse file link: %s", link)
+		}
+		query[key] = unescaped
+	}
+
+	filename, version := query["path"], query["version"]
+	if filename == "" || len(version) < 2 {
+		return "", "", "", 0, 0, fmt.Errorf("could not parse file link: %s", link)
+	}
+	ref := version[2:]
+	switch version[:2] {
+	case "GB":
+		ref = fmt.Sprintf("refs/heads/%s", ref)
+	case "GT":
+		ref = fmt.Sprintf("refs/tags/%s", ref)
+	case "GC":
+	default:
+		return "", "", "", 0, 0, fmt.Errorf("could not parse file link: %s", link)
+	}
+
+	startLine, _ := strconv.Atoi(query["line"])
+	endLine := startLine
+	if lineEnd, err := strconv.Atoi(query["lineEnd"]); err == nil && startLine != 0 {
+		endLine = lineEnd
+		// GetFileLineLink ends the selection at the first column of the line after the end line
+		if query["lineEndColumn"] == "1" && lineEnd > startLine {
+			endLine--
+		}
+	}
+	return repositoryURL, filename, ref, startLine, endLine, nil
+}
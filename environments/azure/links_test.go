@@ -0,0 +1,186 @@
+package azure
+
+import (
+	"testing"
+)
+
+func TestGetCommitLink(t *testing.T) {
+	tests := []struct {
+		name   string
+		commit string
+		want   string
+	}{
+		{
+			name:   "With commit",
+			commit: "0a1b2c",
+			want:   "https://dev.azure.com/org/project/_git/repo/commit/0a1b2c",
+		},
+		{
+			name:   "Without commit",
+			commit: "",
+			want:   "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetCommitLink("https://dev.azure.com/org/project/_git/repo", tt.commit); got != tt.want {
+				t.Errorf("GetCommitLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetCompareLink(t *testing.T) {
+	tests := []struct {
+		name       string
+		baseCommit string
+		headCommit string
+		want       string
+	}{
+		{
+			name:       "With base and head commits",
+			baseCommit: "9f8e7d",
+			headCommit: "0a1b2c",
+			want:       "https://dev.azure.com/org/project/_git/repo/branchCompare?baseVersion=GC9f8e7d&targetVersion=GC0a1b2c&_a=files",
+		},
+		{
+			name:       "Without base commit",
+			headCommit: "0a1b2c",
+			want:       "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetCompareLink("https://dev.azure.com/org/project/_git/repo", tt.baseCommit, tt.headCommit); got != tt.want {
+				t.Errorf("GetCompareLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetPullRequestLink(t *testing.T) {
+	tests := []struct {
+		name          string
+		pullRequestId string
+		want          string
+	}{
+		{
+			name:          "With pull request id",
+			pullRequestId: "12",
+			want:          "https://dev.azure.com/org/project/_git/repo/pullrequest/12",
+		},
+		{
+			name:          "Without pull request id",
+			pullRequestId: "",
+			want:          "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetPullRequestLink("https://dev.azure.com/org/project/_git/repo", tt.pullRequestId); got != tt.want {
+				t.Errorf("GetPullRequestLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetRefLink(t *testing.T) {
+	tests := []struct {
+		name string
+		ref  string
+		want string
+	}{
+		{
+			name: "Branch name",
+			ref:  "feature/x",
+			want: "https://dev.azure.com/org/project/_git/repo?version=GBfeature%2Fx",
+		},
+		{
+			name: "Branch ref",
+			ref:  "refs/heads/feature/x",
+			want: "https://dev.azure.com/org/project/_git/repo?version=GBfeature%2Fx",
+		},
+		{
+			name: "Tag ref",
+			ref:  "refs/tags/v1.0",
+			want: "https://dev.azure.com/org/project/_git/repo?version=GTv1.0",
+		},
+		{
+			name: "Empty ref",
+			ref:  "",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetRefLink("https://dev.azure.com/org/project/_git/repo", tt.ref); got != tt.want {
+				t.Errorf("GetRefLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFileLineLink(t *testing.T) {
+	tests := []struct {
+		name              string
+		link              string
+		wantRepositoryURL string
+		wantFilename      string
+		wantRef           string
+		wantStartLine     int
+		wantEndLine       int
+		wantErr           bool
+	}{
+		{
+			name:              "Branch lines",
+			link:              "https://dev.azure.com/org/project/_git/repo?path=path%2Fto%2Ffile&version=GBfeature%2Fx&_a=contents&line=10&lineEnd=21&lineStartColumn=1&lineEndColumn=1&lineStyle=plain",
+			wantRepositoryURL: "https://dev.azure.com/org/project/_git/repo",
+			wantFilename:      "path/to/file",
+			wantRef:           "refs/heads/feature/x",
+			wantStartLine:     10,
+			wantEndLine:       20,
+		},
+		{
+			name:              "Commit",
+			link:              "https://dev.azure.com/org/project/_git/repo?path=path%2Fto%2Ffile&version=GC0a1b2c&_a=contents",
+			wantRepositoryURL: "https://dev.azure.com/org/project/_git/repo",
+			wantFilename:      "path/to/file",
+			wantRef:           "0a1b2c",
+			wantStartLine:     0,
+			wantEndLine:       0,
+		},
+		{
+			name:              "Tag selection that ends in the end line",
+			link:              "https://dev.azure.com/org/project/_git/repo?path=/path/to/file&version=GTv1.0&line=10&lineEnd=20&lineStartColumn=1&lineEndColumn=15",
+			wantRepositoryURL: "https://dev.azure.com/org/project/_git/repo",
+			wantFilename:      "/path/to/file",
+			wantRef:           "refs/tags/v1.0",
+			wantStartLine:     10,
+			wantEndLine:       20,
+		},
+		{
+			name:    "Unknown version type",
+			link:    "https://dev.azure.com/org/project/_git/repo?path=path%2Fto%2Ffile&version=XXmain",
+			wantErr: true,
+		},
+		{
+			name:    "Without query",
+			link:    "https://dev.azure.com/org/project/_git/repo",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repositoryURL, filename, ref, startLine, endLine, err := ParseFileLineLink(tt.link)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFileLineLink() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if repositoryURL != tt.wantRepositoryURL || filename != tt.wantFilename || ref != tt.wantRef {
+				t.Errorf("ParseFileLineLink() = %v, %v, %v, want %v, %v, %v", repositoryURL, filename, ref, tt.wantRepositoryURL, tt.wantFilename, tt.wantRef)
+			}
+			if startLine != tt.wantStartLine || endLine != tt.wantEndLine {
+				t.Errorf("ParseFileLineLink() lines = %v-%v, want %v-%v", startLine, endLine, tt.wantStartLine, tt.wantEndLine)
+			}
+		})
+	}
+}
@@ -0,0 +1,381 @@
+package bamboo
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/bitbucketserver"
+	"github.com/argonsecurity/go-environments/environments/utils"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/models"
+)
+
+const (
+	builder = "Bamboo"
+
+	buildKeyEnv        = "bamboo_buildKey"
+	buildResultKeyEnv  = "bamboo_buildResultKey"
+	buildNumberEnv     = "bamboo_buildNumber"
+	buildResultsUrlEnv = "bamboo_buildResultsUrl"
+
+	planKeyEnv       = "bamboo_planKey"
+	planNameEnv      = "bamboo_planName"
+	shortPlanNameEnv = "bamboo_shortPlanName"
+	shortJobNameEnv  = "bamboo_shortJobName"
+
+	agentIdEnv = "bamboo_agentId"
+
+	repositoryCloneUrlEnv = "bamboo_planRepository_repositoryUrl"
+	repositoryPathEnv     = "bamboo_build_working_directory"
+	commitShaEnv          = "bamboo_planRepository_revision"
+	previousCommitShaEnv  = "bamboo_planRepository_previousRevision"
+	branchEnv             = "bamboo_planRepository_branchName"
+
+	pullRequestKeyEnv          = "bamboo_repository_pr_key"
+	pullRequestSourceBranchEnv = "bamboo_repository_pr_sourceBranch"
+	pullRequestTargetBranchEnv = "bamboo_repository_pr_targetBranch"
+
+	manualBuildUserEnv = "bamboo_ManualBuildTriggerReason_userName"
+
+	specsDir               = "bamboo-specs"
+	browsePath             = "/browse/"
+	scmPath                = "/scm/"
+	bitbucketServerSshPort = "7999"
+)
+
+var (
+	// Bamboo environment
+	Bamboo = New()
+
+	// bitbucketServerSshUrlRegexp matches the ssh clone urls of Bitbucket Server, which listens on port 7999 by default
+	bitbucketServerSshUrlRegexp = regexp.MustCompile(`^ssh://(?:.+@)?([^/:]+):` + bitbucketServerSshPort + `/`)
+)
+
+// Environment is the Bamboo environment
+type Environment struct {
+	cache utils.ConfigurationCache
+}
+
+// New creates a Bamboo environment with its own configuration cache
+func New() *Environment {
+	return &Environment{}
+}
+
+func (e *Environment) GetConfiguration() (*models.Configuration, error) {
+	return e.cache.Get(e.load)
+}
+
+// Refresh loads the configuration again and replaces the cached configuration
+func (e *Environment) Refresh() (*models.Configuration, error) {
+	return e.cache.Refresh(e.load)
+}
+
+// Reset clears the cached configuration, it is loaded again on the next GetConfiguration
+func (e *Environment) Reset() {
+	e.cache.Reset()
+}
+
+func (e *Environment) load() (*models.Configuration, error) {
+	return loadConfiguration(envsource.OS)
+}
+
+func (e *Environment) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return loadConfiguration(src)
+}
+
+func loadConfiguration(src envsource.EnvSource) (*models.Configuration, error) {
+	var err error
+	repoPath := src.Getenv(repositoryPathEnv)
+	cloneUrl := src.Getenv(repositoryCloneUrlEnv)
+	if cloneUrl == "" {
+		if cloneUrl, err = envsource.GitClient(src).GetGitRemoteURL(envsource.Path(src, repoPath)); err != nil {
+			return nil, err
+		}
+	}
+	cloneUrl = utils.StripCredentialsFromUrl(cloneUrl)
+
+	source, apiUrl := GetRepositorySource(cloneUrl)
+	repoUrl, org, repoName, repoFullName, err := utils.ParseDataFromCloneUrl(cloneUrl, apiUrl, source)
+	if err != nil {
+		return nil, err
+	}
+
+	var warnings []models.Warning
+	commit := src.Getenv(commitShaEnv)
+	branch := getBranch(src)
+	if branch == "" && commit != "" {
+		branch, warnings, _ = envsource.GetGitBranch(src, repoPath, commit)
+	}
+
+	pullRequest := models.PullRequest{}
+	if prKey := src.Getenv(pullRequestKeyEnv); prKey != "" {
+		pullRequest = models.PullRequest{
+			Id: prKey,
+			SourceRef: models.Ref{
+				Branch: branch,
+				Sha:    commit,
+			},
+			TargetRef: models.Ref{
+				Branch: src.Getenv(pullRequestTargetBranchEnv),
+			},
+		}
+	}
+
+	pipelinePaths, pipelinesWarnings := getPipelinePaths(src, repoPath)
+	warnings = append(warnings, pipelinesWarnings...)
+	return &models.Configuration{
+		Url:             getServerUrl(src.Getenv(buildResultsUrlEnv)),
+		SCMApiUrl:       apiUrl,
+		LocalPath:       repoPath,
+		CommitSha:       commit,
+		BeforeCommitSha: src.Getenv(previousCommitShaEnv),
+		Branch:          branch,
+		Repository: models.Repository{
+			Name:     repoName,
+			FullName: repoFullName,
+			Url:      repoUrl,
+			CloneUrl: cloneUrl,
+			Source:   source,
+		},
+		Organization: models.Entity{
+			Name: org,
+		},
+		Pipeline: models.Pipeline{
+			Entity: models.Entity{
+				Id:   src.Getenv(planKeyEnv),
+				Name: getPlanName(src),
+			},
+			Path: getPipelinePath(repoPath, pipelinePaths),
+		},
+		Job: models.Entity{
+			Id:   src.Getenv(buildKeyEnv),
+			Name: src.Getenv(shortJobNameEnv),
+		},
+		Run: models.BuildRun{
+			BuildId:     src.Getenv(buildResultKeyEnv),
+			BuildNumber: src.Getenv(buildNumberEnv),
+		},
+		Runner: models.Runner{
+			Id:           src.Getenv(agentIdEnv),
+			OS:           runtime.GOOS,
+			Architecture: runtime.GOARCH,
+		},
+		PullRequest:   pullRequest,
+		Builder:       builder,
+		Pusher:        getPusher(src),
+		PipelinePaths: pipelinePaths,
+		Environment:   enums.Bamboo,
+		ScmId:         utils.GenerateScmId(cloneUrl),
+		Warnings:      warnings,
+	}, nil
+}
+
+// GetRepositorySource detects the SCM of a clone url by its hostname,
+// clone urls of other hosts in the Bitbucket Server format, i.e. https://bitbucket.company.com/scm/project/repo.git,
+// are Bitbucket Server repositories and their server url is returned as the api url
+func GetRepositorySource(cloneUrl string) (enums.Source, string) {
+	if source, apiUrl := utils.GetRepositorySource(cloneUrl); source != enums.Unknown {
+		return source, apiUrl
+	}
+
+	if index := strings.Index(cloneUrl, scmPath); index != -1 && strings.HasPrefix(cloneUrl, "http") {
+		return enums.BitbucketServer, cloneUrl[:index]
+	}
+	if result := bitbucketServerSshUrlRegexp.FindStringSubmatch(cloneUrl); result != nil {
+		return enums.BitbucketServer, fmt.Sprintf("https://%s", result[1])
+	}
+
+	return enums.Unknown, ""
+}
+
+// getBranch returns the source branch of pull request builds, and the branch of the plan repository otherwise
+func getBranch(src envsource.EnvSource) string {
+	if branch := src.Getenv(pullRequestSourceBranchEnv); branch != "" {
+		return branch
+	}
+	return src.Getenv(branchEnv)
+}
+
+func getPlanName(src envsource.EnvSource) string {
+	if name := src.Getenv(shortPlanNameEnv); name != "" {
+		return name
+	}
+	return src.Getenv(planNameEnv)
+}
+
+func getPusher(src envsource.EnvSource) models.Pusher {
+	if user := src.Getenv(manualBuildUserEnv); user != "" {
+		return models.Pusher{
+			Username: user,
+		}
+	}
+	return models.Pusher{
+		Username: utils.DetectPusherFrom(src),
+	}
+}
+
+// getServerUrl returns the url of the Bamboo server from a result url, i.e. https://bamboo.company.com/browse/PROJ-PLAN-JOB1-42
+func getServerUrl(resultsUrl string) string {
+	if index := strings.Index(resultsUrl, browsePath); index != -1 {
+		return resultsUrl[:index]
+	}
+	return ""
+}
+
+// getRepositoryUrl returns the url of the plan repository when it is hosted on Bitbucket Server
+func getRepositoryUrl() string {
+	cloneUrl := utils.StripCredentialsFromUrl(os.Getenv(repositoryCloneUrlEnv))
+	source, apiUrl := GetRepositorySource(cloneUrl)
+	if source != enums.BitbucketServer {
+		return ""
+	}
+	repoUrl, _, _, _, err := utils.ParseDataFromCloneUrl(cloneUrl, apiUrl, source)
+	if err != nil {
+		return ""
+	}
+	return repoUrl
+}
+
+// GetStepLink returns the result link of the job
+func (e *Environment) GetStepLink() string {
+	return os.Getenv(buildResultsUrlEnv)
+}
+
+// GetBuildLink returns the result link of the plan, i.e. https://bamboo.company.com/browse/PROJ-PLAN-42
+func (e *Environment) GetBuildLink() string {
+	serverUrl := getServerUrl(os.Getenv(buildResultsUrlEnv))
+	if serverUrl == "" || os.Getenv(planKeyEnv) == "" {
+		return os.Getenv(buildResultsUrlEnv)
+	}
+	return fmt.Sprintf("%s%s%s-%s", serverUrl, browsePath, os.Getenv(planKeyEnv), os.Getenv(buildNumberEnv))
+}
+
+// GetFileLink returns the link of a file in the plan repository, links are supported for Bitbucket Server repositories
+func (e *Environment) GetFileLink(filename string, branch string, commit string) string {
+	repoUrl := getRepositoryUrl()
+	if repoUrl == "" {
+		return ""
+	}
+	return bitbucketserver.GetFileLink(repoUrl, filename, branch, commit)
+}
+
+// GetFileLineLink returns the link of lines in a file of the plan repository, links are supported for Bitbucket Server repositories
+func (e *Environment) GetFileLineLink(filename string, branch string, commit string, startLine int, endLine int) string {
+	repoUrl := getRepositoryUrl()
+	if repoUrl == "" {
+		return ""
+	}
+	return bitbucketserver.GetFileLineLink(repoUrl, filename, branch, commit, startLine, endLine)
+}
+
+// GetCommitLink returns the link of a commit in the plan repository, links are supported for Bitbucket Server repositories
+func (e *Environment) GetCommitLink(commit string) string {
+	repoUrl := getRepositoryUrl()
+	if repoUrl == "" {
+		return ""
+	}
+	return bitbucketserver.GetCommitLink(repoUrl, commit)
+}
+
+func (e *Environment) GetCompareLink(baseCommit string, headCommit string) string {
+	repoUrl := getRepositoryUrl()
+	if repoUrl == "" {
+		return ""
+	}
+	return bitbucketserver.GetCompareLink(repoUrl, baseCommit, headCommit)
+}
+
+func (e *Environment) GetPullRequestLink(pullRequestId string) string {
+	repoUrl := getRepositoryUrl()
+	if repoUrl == "" {
+		return ""
+	}
+	return bitbucketserver.GetPullRequestLink(repoUrl, pullRequestId)
+}
+
+func (e *Environment) GetRefLink(ref string) string {
+	repoUrl := getRepositoryUrl()
+	if repoUrl == "" {
+		return ""
+	}
+	return bitbucketserver.GetRefLink(repoUrl, ref)
+}
+
+func (e *Environment) IsCurrentEnvironment() bool {
+	_, isExist := os.LookupEnv(buildKeyEnv)
+	return isExist
+}
+
+// DetectionVariables returns the variables used by IsCurrentEnvironment
+func (e *Environment) DetectionVariables() []string {
+	return []string{buildKeyEnv}
+}
+
+// ExpectedFields returns the configuration fields the environment is expected to fill and the variables they are filled from
+func (e *Environment) ExpectedFields() []models.ExpectedField {
+	return []models.ExpectedField{
+		{Field: "repository.url", SourceEnv: repositoryCloneUrlEnv, Severity: models.SeverityCritical},
+		{Field: "repository.cloneUrl", SourceEnv: repositoryCloneUrlEnv, Severity: models.SeverityCritical},
+		{Field: "commitSha", SourceEnv: commitShaEnv, Severity: models.SeverityCritical},
+		{Field: "branch", SourceEnv: branchEnv, Severity: models.SeverityWarning},
+		{Field: "url", SourceEnv: buildResultsUrlEnv, Severity: models.SeverityWarning},
+		{Field: "localPath", SourceEnv: repositoryPathEnv, Severity: models.SeverityWarning},
+		{Field: "pipeline.id", SourceEnv: planKeyEnv, Severity: models.SeverityWarning},
+		{Field: "job.id", SourceEnv: buildKeyEnv, Severity: models.SeverityWarning},
+		{Field: "run.buildNumber", SourceEnv: buildNumberEnv, Severity: models.SeverityWarning},
+	}
+}
+
+func (e *Environment) Name() string {
+	return "bamboo"
+}
+
+// getPipelinePath returns the main specs file relative to the repository, i.e. bamboo-specs/bamboo.yml
+func getPipelinePath(rootDir string, pipelinePaths []string) string {
+	for _, path := range pipelinePaths {
+		base := filepath.Base(path)
+		if strings.TrimSuffix(base, filepath.Ext(base)) == "bamboo" {
+			return strings.TrimPrefix(path, rootDir+string(filepath.Separator))
+		}
+	}
+	return ""
+}
+
+// getPipelinePaths returns the YAML specs files of the bamboo-specs directory,
+// the subdirectories of Java specs projects are not searched
+func getPipelinePaths(src envsource.EnvSource, rootDir string) ([]string, []models.Warning) {
+	paths := make([]string, 0)
+	var warnings []models.Warning
+
+	dir := filepath.Join(rootDir, specsDir)
+	if _, err := envsource.Stat(src, dir); err != nil {
+		return paths, warnings
+	}
+	err := envsource.Walk(src, dir, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			warnings = append(warnings, models.NewWarning(models.PipelinePathsWarning, err, "failed to search %s for pipeline files", path))
+			return nil
+		}
+		if info.IsDir() {
+			if path != dir {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) == ".yml" || filepath.Ext(path) == ".yaml" {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		warnings = append(warnings, models.NewWarning(models.PipelinePathsWarning, err, "failed to search %s for pipeline files", dir))
+	}
+
+	return paths, warnings
+}
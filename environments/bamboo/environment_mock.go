@@ -0,0 +1,128 @@
+package bamboo
+
+import (
+	"fmt"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/bitbucketserver"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/models"
+)
+
+var (
+	MockProjectKey  = "proj"
+	MockRepoName    = "test-repo"
+	MockServerUrl   = "https://bamboo.company.com"
+	MockScmUrl      = "https://bitbucket.company.com"
+	MockPlanKey     = "PROJ-PLAN"
+	MockBuildNumber = "42"
+)
+
+var mockConfiguration *models.Configuration
+
+type EnvironmentMock struct{}
+
+func (em *EnvironmentMock) GetConfiguration() (*models.Configuration, error) {
+	if mockConfiguration == nil {
+		if err := loadMockConfiguration(); err != nil {
+			return nil, err
+		}
+	}
+	return mockConfiguration, nil
+}
+
+func (em *EnvironmentMock) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return em.GetConfiguration()
+}
+
+func (em *EnvironmentMock) Refresh() (*models.Configuration, error) {
+	em.Reset()
+	return em.GetConfiguration()
+}
+
+func (em *EnvironmentMock) Reset() {
+	mockConfiguration = nil
+}
+
+func loadMockConfiguration() error {
+	mockConfiguration = &models.Configuration{
+		Url:       MockServerUrl,
+		SCMApiUrl: MockScmUrl,
+		LocalPath: "/var/atlassian/bamboo-agent/xml-data/build-dir/PROJ-PLAN-JOB1",
+		CommitSha: "2c6e3880fd94ddb7ef72d34e683cdc0c47bec6e6",
+		Branch:    "main",
+		Run: models.BuildRun{
+			BuildId:     fmt.Sprintf("%s-JOB1-%s", MockPlanKey, MockBuildNumber),
+			BuildNumber: MockBuildNumber,
+		},
+		Job: models.Entity{
+			Id:   fmt.Sprintf("%s-JOB1", MockPlanKey),
+			Name: "Default Job",
+		},
+		Pipeline: models.Pipeline{
+			Entity: models.Entity{
+				Id:   MockPlanKey,
+				Name: "Test plan",
+			},
+			Path: "bamboo-specs/bamboo.yml",
+		},
+		Runner: models.Runner{
+			Id: "131073",
+		},
+		Repository: models.Repository{
+			Name:     MockRepoName,
+			FullName: fmt.Sprintf("scm/%s/%s", MockProjectKey, MockRepoName),
+			Url:      fmt.Sprintf("%s/projects/%s/repos/%s", MockScmUrl, MockProjectKey, MockRepoName),
+			CloneUrl: fmt.Sprintf("%s/scm/%s/%s.git", MockScmUrl, MockProjectKey, MockRepoName),
+			Source:   enums.BitbucketServer,
+		},
+		Builder: "Bamboo",
+		Organization: models.Entity{
+			Name: MockProjectKey,
+		},
+		PipelinePaths: []string{"/var/atlassian/bamboo-agent/xml-data/build-dir/PROJ-PLAN-JOB1/bamboo-specs/bamboo.yml"},
+		Environment:   enums.Bamboo,
+	}
+
+	return nil
+}
+
+func (em *EnvironmentMock) GetBuildLink() string {
+	return fmt.Sprintf("%s/browse/%s-%s", MockServerUrl, MockPlanKey, MockBuildNumber)
+}
+
+func (em *EnvironmentMock) GetStepLink() string {
+	return fmt.Sprintf("%s/browse/%s-JOB1-%s", MockServerUrl, MockPlanKey, MockBuildNumber)
+}
+
+func (em *EnvironmentMock) GetFileLink(filename string, branch string, commit string) string {
+	return bitbucketserver.GetFileLink(fmt.Sprintf("%s/projects/%s/repos/%s", MockScmUrl, MockProjectKey, MockRepoName), filename, branch, commit)
+}
+
+func (em *EnvironmentMock) GetFileLineLink(filename string, branch string, commit string, startLine int, endLine int) string {
+	return bitbucketserver.GetFileLineLink(fmt.Sprintf("%s/projects/%s/repos/%s", MockScmUrl, MockProjectKey, MockRepoName), filename, branch, commit, startLine, endLine)
+}
+
+func (em *EnvironmentMock) GetCommitLink(commit string) string {
+	return ""
+}
+
+func (em *EnvironmentMock) GetCompareLink(baseCommit string, headCommit string) string {
+	return ""
+}
+
+func (em *EnvironmentMock) GetPullRequestLink(pullRequestId string) string {
+	return ""
+}
+
+func (em *EnvironmentMock) GetRefLink(ref string) string {
+	return ""
+}
+
+func (em *EnvironmentMock) IsCurrentEnvironment() bool {
+	return true
+}
+
+func (em *EnvironmentMock) Name() string {
+	return "bamboo"
+}
@@ -0,0 +1,312 @@
+package bamboo
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/testutils"
+	"github.com/argonsecurity/go-environments/models"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	bambooMainEnvsFilePath   = "testdata/bamboo-bitbucket-server-main-env.json"
+	bambooPrEnvsFilePath     = "testdata/bamboo-bitbucket-server-pr-env.json"
+	bambooGithubEnvsFilePath = "testdata/bamboo-github-main-env.json"
+	testRepoPath             = "/tmp/bamboo/repo"
+	testRepoUrl              = "https://bitbucket.company.com/projects/proj/repos/test-repo"
+	testRepoCloneUrl         = "https://bitbucket.company.com/scm/proj/test-repo.git"
+	testdataPath             = "../bamboo/testdata/repo"
+	testPipelinePaths        = []string{"/tmp/bamboo/repo/bamboo-specs/bamboo.yml", "/tmp/bamboo/repo/bamboo-specs/deployment.yaml"}
+)
+
+func Test_environment_GetConfiguration(t *testing.T) {
+	tests := []struct {
+		name         string
+		envsFilePath string
+		want         *models.Configuration
+		wantErr      bool
+	}{
+		{
+			name:         "Bamboo Bitbucket Server configuration",
+			envsFilePath: bambooMainEnvsFilePath,
+			want: &models.Configuration{
+				Url:             "https://bamboo.company.com",
+				SCMApiUrl:       "https://bitbucket.company.com",
+				LocalPath:       testRepoPath,
+				CommitSha:       "2c6e3880fd94ddb7ef72d34e683cdc0c47bec6e6",
+				BeforeCommitSha: "fe8c38a965d13d9794eb36918cb24cebe49a45c2",
+				Branch:          "main",
+				Repository: models.Repository{
+					Name:     "test-repo",
+					FullName: "scm/proj/test-repo",
+					Url:      testRepoUrl,
+					CloneUrl: testRepoCloneUrl,
+					Source:   enums.BitbucketServer,
+				},
+				Organization: models.Entity{
+					Name: "proj",
+				},
+				Pipeline: models.Pipeline{
+					Entity: models.Entity{
+						Id:   "PROJ-PLAN",
+						Name: "Test plan",
+					},
+					Path: "bamboo-specs/bamboo.yml",
+				},
+				Job: models.Entity{
+					Id:   "PROJ-PLAN-JOB1",
+					Name: "Default Job",
+				},
+				Run: models.BuildRun{
+					BuildId:     "PROJ-PLAN-JOB1-42",
+					BuildNumber: "42",
+				},
+				Runner: models.Runner{
+					Id:           "131073",
+					OS:           runtime.GOOS,
+					Architecture: runtime.GOARCH,
+				},
+				Pusher: models.Pusher{
+					Username: "test-user",
+				},
+				Builder:       "Bamboo",
+				PipelinePaths: testPipelinePaths,
+				Environment:   enums.Bamboo,
+				ScmId:         "bc64d4a8f5cbd856284b8340f0d38038",
+			},
+		},
+		{
+			name:         "Bamboo Bitbucket Server pull request configuration",
+			envsFilePath: bambooPrEnvsFilePath,
+			want: &models.Configuration{
+				Url:       "https://bamboo.company.com",
+				SCMApiUrl: "https://bitbucket.company.com",
+				LocalPath: testRepoPath,
+				CommitSha: "mky2jknpc4fuz6qsn0vtouqwfjbno39itu0hifvs",
+				Branch:    "feature/test",
+				Repository: models.Repository{
+					Name:     "test-repo",
+					FullName: "proj/test-repo",
+					Url:      testRepoUrl,
+					CloneUrl: "ssh://bitbucket.company.com:7999/proj/test-repo.git",
+					Source:   enums.BitbucketServer,
+				},
+				Organization: models.Entity{
+					Name: "proj",
+				},
+				Pipeline: models.Pipeline{
+					Entity: models.Entity{
+						Id:   "PROJ-PLAN3",
+						Name: "feature-test",
+					},
+					Path: "bamboo-specs/bamboo.yml",
+				},
+				Job: models.Entity{
+					Id:   "PROJ-PLAN3-JOB1",
+					Name: "Default Job",
+				},
+				Run: models.BuildRun{
+					BuildId:     "PROJ-PLAN3-JOB1-7",
+					BuildNumber: "7",
+				},
+				Runner: models.Runner{
+					Id:           "131074",
+					OS:           runtime.GOOS,
+					Architecture: runtime.GOARCH,
+				},
+				PullRequest: models.PullRequest{
+					Id: "12",
+					SourceRef: models.Ref{
+						Branch: "feature/test",
+						Sha:    "mky2jknpc4fuz6qsn0vtouqwfjbno39itu0hifvs",
+					},
+					TargetRef: models.Ref{
+						Branch: "main",
+					},
+				},
+				Builder:       "Bamboo",
+				PipelinePaths: testPipelinePaths,
+				Environment:   enums.Bamboo,
+				ScmId:         "5edcc4dfca39b8072bbb38c035137d5d",
+			},
+		},
+		{
+			name:         "Bamboo GitHub configuration",
+			envsFilePath: bambooGithubEnvsFilePath,
+			want: &models.Configuration{
+				Url:       "https://bamboo.company.com",
+				SCMApiUrl: "https://api.github.com",
+				LocalPath: testRepoPath,
+				CommitSha: "2c6e3880fd94ddb7ef72d34e683cdc0c47bec6e6",
+				Branch:    "main",
+				Repository: models.Repository{
+					Name:     "test-repo",
+					FullName: "test-org/test-repo",
+					Url:      "https://github.com/test-org/test-repo",
+					CloneUrl: "https://github.com/test-org/test-repo.git",
+					Source:   enums.Github,
+				},
+				Organization: models.Entity{
+					Name: "test-org",
+				},
+				Pipeline: models.Pipeline{
+					Entity: models.Entity{
+						Id:   "PROJ-GH",
+						Name: "GitHub plan",
+					},
+					Path: "bamboo-specs/bamboo.yml",
+				},
+				Job: models.Entity{
+					Id:   "PROJ-GH-JOB1",
+					Name: "Default Job",
+				},
+				Run: models.BuildRun{
+					BuildId:     "PROJ-GH-JOB1-3",
+					BuildNumber: "3",
+				},
+				Runner: models.Runner{
+					Id:           "131073",
+					OS:           runtime.GOOS,
+					Architecture: runtime.GOARCH,
+				},
+				Builder:       "Bamboo",
+				PipelinePaths: testPipelinePaths,
+				Environment:   enums.Bamboo,
+				ScmId:         "b30f418cdcc9970849d3d031de5df54f",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := prepareTest(t, tt.envsFilePath)
+			got, err := e.GetConfiguration()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("environment.GetConfiguration() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_environment_GetBuildLink(t *testing.T) {
+	e := prepareTest(t, bambooMainEnvsFilePath)
+	assert.Equal(t, "https://bamboo.company.com/browse/PROJ-PLAN-42", e.GetBuildLink())
+	assert.Equal(t, "https://bamboo.company.com/browse/PROJ-PLAN-JOB1-42", e.GetStepLink())
+}
+
+func Test_environment_GetFileLineLink(t *testing.T) {
+	tests := []struct {
+		name         string
+		envsFilePath string
+		want         string
+	}{
+		{
+			name:         "Bitbucket Server https clone url",
+			envsFilePath: bambooMainEnvsFilePath,
+			want:         "https://bitbucket.company.com/projects/proj/repos/test-repo/browse/path/to/file?at=commit#3-5",
+		},
+		{
+			name:         "Bitbucket Server ssh clone url",
+			envsFilePath: bambooPrEnvsFilePath,
+			want:         "https://bitbucket.company.com/projects/proj/repos/test-repo/browse/path/to/file?at=commit#3-5",
+		},
+		{
+			name:         "Not a Bitbucket Server repository",
+			envsFilePath: bambooGithubEnvsFilePath,
+			want:         "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := prepareTest(t, tt.envsFilePath)
+			if got := e.GetFileLineLink("path/to/file", "branch", "commit", 3, 5); got != tt.want {
+				t.Errorf("environment.GetFileLineLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetRepositorySource(t *testing.T) {
+	tests := []struct {
+		name       string
+		cloneUrl   string
+		wantSource enums.Source
+		wantApiUrl string
+	}{
+		{
+			name:       "Bitbucket Server https clone url",
+			cloneUrl:   "https://bitbucket.company.com/bitbucket/scm/proj/test-repo.git",
+			wantSource: enums.BitbucketServer,
+			wantApiUrl: "https://bitbucket.company.com/bitbucket",
+		},
+		{
+			name:       "Bitbucket Server ssh clone url",
+			cloneUrl:   "ssh://git@bitbucket.company.com:7999/proj/test-repo.git",
+			wantSource: enums.BitbucketServer,
+			wantApiUrl: "https://bitbucket.company.com",
+		},
+		{
+			name:       "Bitbucket cloud clone url",
+			cloneUrl:   "https://bitbucket.org/test-org/test-repo.git",
+			wantSource: enums.Bitbucket,
+			wantApiUrl: "https://api.bitbucket.org/2.0",
+		},
+		{
+			name:       "Unknown clone url",
+			cloneUrl:   "https://git.company.com/test-org/test-repo.git",
+			wantSource: enums.Unknown,
+			wantApiUrl: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, apiUrl := GetRepositorySource(tt.cloneUrl)
+			assert.Equal(t, tt.wantSource, source)
+			assert.Equal(t, tt.wantApiUrl, apiUrl)
+		})
+	}
+}
+
+func Test_environment_IsCurrentEnvironment(t *testing.T) {
+	tests := []struct {
+		name         string
+		envsFilePath string
+		want         bool
+	}{
+		{
+			name:         "Bamboo environment",
+			envsFilePath: bambooMainEnvsFilePath,
+			want:         true,
+		},
+		{
+			name:         "Jenkins environment",
+			envsFilePath: "../jenkins/testdata/jenkins-github-main-full-env.json",
+			want:         false,
+		},
+		{
+			name:         "Not Bamboo environment",
+			envsFilePath: "",
+			want:         false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := prepareTest(t, tt.envsFilePath)
+			if got := e.IsCurrentEnvironment(); got != tt.want {
+				t.Errorf("environment.IsCurrentEnvironment() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func prepareTest(t *testing.T, envsFilePath string) *Environment {
+	e := New()
+	testRepoCleanup := testutils.PrepareTestGitRepository(testRepoPath, testRepoCloneUrl, testdataPath)
+	t.Cleanup(testRepoCleanup)
+	envCleanup := testutils.SetEnvsFromFile(envsFilePath)
+	t.Cleanup(envCleanup)
+	return e
+}
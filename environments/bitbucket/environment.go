@@ -10,6 +10,7 @@ import (
 
 	"github.com/argonsecurity/go-environments/enums"
 	"github.com/argonsecurity/go-environments/environments/utils"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
 	"github.com/argonsecurity/go-environments/models"
 )
 
@@ -20,6 +21,7 @@ const (
 	repositoryUrlEnv       = "BITBUCKET_GIT_HTTP_ORIGIN"
 	repositoryFullNameEnv  = "BITBUCKET_REPO_FULL_NAME"
 	workspaceEnv           = "BITBUCKET_WORKSPACE"
+	projectKeyEnv          = "BITBUCKET_PROJECT_KEY"
 	prDestinationBranchEnv = "BITBUCKET_PR_DESTINATION_BRANCH"
 
 	buildNumber = "BITBUCKET_BUILD_NUMBER"
@@ -40,99 +42,120 @@ var (
 )
 
 var (
-	Bitbucket     = environment{}
-	configuration *models.Configuration
+	Bitbucket = New()
 
 	bitbucketPipelines = []string{bitbucketPipelineFile}
 )
 
-type environment struct{}
+// Environment is the Bitbucket environment
+type Environment struct {
+	cache utils.ConfigurationCache
+}
 
-func (e environment) GetConfiguration() (*models.Configuration, error) {
-	if configuration == nil {
-		loadConfiguration()
-	}
-	return configuration, nil
+// New creates a Bitbucket environment with its own configuration cache
+func New() *Environment {
+	return &Environment{}
+}
+
+func (e *Environment) GetConfiguration() (*models.Configuration, error) {
+	return e.cache.Get(e.load)
+}
+
+// Refresh loads the configuration again and replaces the cached configuration
+func (e *Environment) Refresh() (*models.Configuration, error) {
+	return e.cache.Refresh(e.load)
+}
+
+// Reset clears the cached configuration, it is loaded again on the next GetConfiguration
+func (e *Environment) Reset() {
+	e.cache.Reset()
+}
+
+func (e *Environment) load() (*models.Configuration, error) {
+	return loadConfiguration(envsource.OS), nil
 }
 
-func loadConfiguration() *models.Configuration {
+func (e *Environment) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return loadConfiguration(src), nil
+}
+
+func loadConfiguration(src envsource.EnvSource) *models.Configuration {
 	source := enums.Bitbucket
-	repoPath := os.Getenv(repositoryPathEnv)
-	cloneUrl := fmt.Sprintf("%s.git", os.Getenv(repositoryUrlEnv))
+	repoPath := src.Getenv(repositoryPathEnv)
+	cloneUrl := fmt.Sprintf("%s.git", src.Getenv(repositoryUrlEnv))
 	strippedCloneUrl := utils.StripCredentialsFromUrl(cloneUrl)
 	if !strings.HasSuffix(strippedCloneUrl, ".git") {
 		strippedCloneUrl += ".git"
 	}
 	scmId := utils.GenerateScmId(strippedCloneUrl)
 
-	configuration = &models.Configuration{
+	return &models.Configuration{
 		Url:       bitbucketUrl,
 		SCMApiUrl: bitbucketApiUrl,
 		LocalPath: repoPath,
-		Branch:    os.Getenv(branchEnv),
-		CommitSha: os.Getenv(commitShaEnv),
+		Branch:    src.Getenv(branchEnv),
+		CommitSha: src.Getenv(commitShaEnv),
 		Repository: models.Repository{
-			Id:       os.Getenv(repositoryIdEnv),
-			Name:     os.Getenv(repositoryNameEnv),
-			FullName: os.Getenv(repositoryFullNameEnv),
-			Url:      os.Getenv(repositoryUrlEnv),
+			Id:       src.Getenv(repositoryIdEnv),
+			Name:     src.Getenv(repositoryNameEnv),
+			FullName: src.Getenv(repositoryFullNameEnv),
+			Url:      src.Getenv(repositoryUrlEnv),
 			CloneUrl: strippedCloneUrl,
 			Source:   source,
 		},
 		Organization: models.Entity{
-			Name: os.Getenv(workspaceEnv),
+			Name: src.Getenv(workspaceEnv),
 		},
 		Pipeline: models.Pipeline{
 			Entity: models.Entity{
-				Id:   os.Getenv(pipelineIdEnv),
-				Name: os.Getenv(repositoryNameEnv),
+				Id:   src.Getenv(pipelineIdEnv),
+				Name: src.Getenv(repositoryNameEnv),
 			},
 			Path: bitbucketPipelineFile,
 		},
 		Run: models.BuildRun{
-			BuildId:     os.Getenv(buildNumber),
-			BuildNumber: os.Getenv(buildNumber),
+			BuildId:     src.Getenv(buildNumber),
+			BuildNumber: src.Getenv(buildNumber),
 		},
 		Pusher: models.Pusher{
-			Username: utils.DetectPusher(),
+			Username: utils.DetectPusherFrom(src),
 		},
 		Runner: models.Runner{
 			OS:           runtime.GOOS,
 			Architecture: runtime.GOARCH,
 		},
 		PullRequest: models.PullRequest{
-			Id: os.Getenv(mergeRequestIdEnv),
+			Id: src.Getenv(mergeRequestIdEnv),
 			TargetRef: models.Ref{
-				Branch: os.Getenv(prDestinationBranchEnv),
+				Branch: src.Getenv(prDestinationBranchEnv),
 			},
 		},
-		PipelinePaths: getPipelinePaths(repoPath),
+		PipelinePaths: getPipelinePaths(src, repoPath),
 		Environment:   source,
 		ScmId:         scmId,
 	}
-	return configuration
 }
 
-func (e environment) GetStepLink() string {
+func (e *Environment) GetStepLink() string {
 	return fmt.Sprintf("%s/%s/pipelines/results/%s/steps/%s", bitbucketUrl, os.Getenv(repositoryFullNameEnv), os.Getenv(buildNumber), os.Getenv(stepIdEnv))
 }
 
-func (e environment) GetBuildLink() string {
+func (e *Environment) GetBuildLink() string {
 	return fmt.Sprintf("%s/%s/pipelines/results/%s", bitbucketUrl, os.Getenv(repositoryFullNameEnv), url.PathEscape(os.Getenv(buildNumber)))
 }
 
-func (e environment) GetFileLink(filename string, branch string, commit string) string {
+func (e *Environment) GetFileLink(filename string, branch string, commit string) string {
 	return GetFileLink(
-		fmt.Sprintf("%s/%s", bitbucketUrl, os.Getenv(repositoryFullNameEnv)),
+		getRepositoryUrl(),
 		filename,
 		branch,
 		commit,
 	)
 }
 
-func (e environment) GetFileLineLink(filename string, branch string, commit string, startLine int, endLine int) string {
+func (e *Environment) GetFileLineLink(filename string, branch string, commit string, startLine int, endLine int) string {
 	return GetFileLineLink(
-		fmt.Sprintf("%s/%s", bitbucketUrl, os.Getenv(repositoryFullNameEnv)),
+		getRepositoryUrl(),
 		filename,
 		branch,
 		commit,
@@ -141,6 +164,22 @@ func (e environment) GetFileLineLink(filename string, branch string, commit stri
 	)
 }
 
+func (e *Environment) GetCommitLink(commit string) string {
+	return GetCommitLink(getRepositoryUrl(), commit)
+}
+
+func (e *Environment) GetCompareLink(baseCommit string, headCommit string) string {
+	return GetCompareLink(getRepositoryUrl(), baseCommit, headCommit)
+}
+
+func (e *Environment) GetPullRequestLink(pullRequestId string) string {
+	return GetPullRequestLink(getRepositoryUrl(), pullRequestId)
+}
+
+func (e *Environment) GetRefLink(ref string) string {
+	return GetRefLink(getRepositoryUrl(), ref)
+}
+
 func GetFileLineLink(repositoryURL string, filename string, branch string, commit string, startLine, endLine int) string {
 	link := GetFileLink(repositoryURL, filename, branch, commit)
 	if startLine != 0 {
@@ -177,24 +216,50 @@ func GetFileLink(repositoryURL string, filename string, branch string, commit st
 	}
 }
 
-func (e environment) IsCurrentEnvironment() bool {
-	_, isExist := os.LookupEnv("BITBUCKET_PROJECT_KEY")
+func (e *Environment) IsCurrentEnvironment() bool {
+	_, isExist := os.LookupEnv(projectKeyEnv)
 	return isExist
 }
 
-func (e environment) Name() string {
+// DetectionVariables returns the variables used by IsCurrentEnvironment
+func (e *Environment) DetectionVariables() []string {
+	return []string{projectKeyEnv}
+}
+
+// ExpectedFields returns the configuration fields the environment is expected to fill and the variables they are filled from
+func (e *Environment) ExpectedFields() []models.ExpectedField {
+	return []models.ExpectedField{
+		{Field: "repository.url", SourceEnv: repositoryUrlEnv, Severity: models.SeverityCritical},
+		{Field: "repository.cloneUrl", SourceEnv: repositoryUrlEnv, Severity: models.SeverityCritical},
+		{Field: "commitSha", SourceEnv: commitShaEnv, Severity: models.SeverityCritical},
+		{Field: "branch", SourceEnv: branchEnv, Severity: models.SeverityWarning},
+		{Field: "localPath", SourceEnv: repositoryPathEnv, Severity: models.SeverityWarning},
+		{Field: "repository.id", SourceEnv: repositoryIdEnv, Severity: models.SeverityWarning},
+		{Field: "repository.fullName", SourceEnv: repositoryFullNameEnv, Severity: models.SeverityWarning},
+		{Field: "pipeline.id", SourceEnv: pipelineIdEnv, Severity: models.SeverityWarning},
+		{Field: "run.buildId", SourceEnv: buildNumber, Severity: models.SeverityWarning},
+		{Field: "organization.name", SourceEnv: workspaceEnv, Severity: models.SeverityWarning},
+	}
+}
+
+func (e *Environment) Name() string {
 	return "bitbucket"
 }
 
-func getPipelinePaths(rootDir string) []string {
+func getPipelinePaths(src envsource.EnvSource, rootDir string) []string {
 	paths := make([]string, 0)
 
 	for _, pipelineFile := range bitbucketPipelines {
 		path := filepath.Join(rootDir, pipelineFile)
-		if _, err := os.Stat(path); err == nil {
+		if _, err := envsource.Stat(src, path); err == nil {
 			paths = append(paths, path)
 		}
 	}
 
 	return paths
 }
+
+// getRepositoryUrl returns the url of the repository on Bitbucket Cloud
+func getRepositoryUrl() string {
+	return fmt.Sprintf("%s/%s", bitbucketUrl, os.Getenv(repositoryFullNameEnv))
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
 	"github.com/argonsecurity/go-environments/models"
 )
 
@@ -28,6 +29,19 @@ func (em *EnvironmentMock) GetConfiguration() (*models.Configuration, error) {
 	return mockConfiguration, nil
 }
 
+func (em *EnvironmentMock) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return em.GetConfiguration()
+}
+
+func (em *EnvironmentMock) Refresh() (*models.Configuration, error) {
+	em.Reset()
+	return em.GetConfiguration()
+}
+
+func (em *EnvironmentMock) Reset() {
+	mockConfiguration = nil
+}
+
 func loadMockConfiguration() error {
 	mockConfiguration = &models.Configuration{
 		Url:       "https://bitbucket.org",
@@ -89,6 +103,22 @@ func (em *EnvironmentMock) GetFileLineLink(filename string, branch string, commi
 	return fmt.Sprintf("https:///bitbucket.org/%s/src/%s/%s#lines%d", MockRepositoryName, branch, filename, startLine)
 }
 
+func (em *EnvironmentMock) GetCommitLink(commit string) string {
+	return ""
+}
+
+func (em *EnvironmentMock) GetCompareLink(baseCommit string, headCommit string) string {
+	return ""
+}
+
+func (em *EnvironmentMock) GetPullRequestLink(pullRequestId string) string {
+	return ""
+}
+
+func (em *EnvironmentMock) GetRefLink(ref string) string {
+	return ""
+}
+
 func (em *EnvironmentMock) IsCurrentEnvironment() bool {
 	return true
 }
@@ -388,6 +388,14 @@ func Test_environment_GetFileLineLink(t *testing.T) {
 	}
 }
 
+func Test_environment_GetCommitAndRefLinks(t *testing.T) {
+	e := prepareTest(t, bitbucketMainEnvsFilePath)
+	assert.Equal(t, "https://bitbucket.org/test-workspace/test-repo/commits/0a1b2c", e.GetCommitLink("0a1b2c"))
+	assert.Equal(t, "https://bitbucket.org/test-workspace/test-repo/branches/compare/0a1b2c%0D9f8e7d", e.GetCompareLink("9f8e7d", "0a1b2c"))
+	assert.Equal(t, "https://bitbucket.org/test-workspace/test-repo/pull-requests/12", e.GetPullRequestLink("12"))
+	assert.Equal(t, "https://bitbucket.org/test-workspace/test-repo/src/v1.0", e.GetRefLink("refs/tags/v1.0"))
+}
+
 func TestGetFileLink(t *testing.T) {
 	type args struct {
 		repositoryURL string
@@ -570,9 +578,8 @@ func Test_environment_IsCurrentEnvironment(t *testing.T) {
 	}
 }
 
-func prepareTest(t *testing.T, envsFilePath string) environment {
-	e := environment{}
-	configuration = nil
+func prepareTest(t *testing.T, envsFilePath string) *Environment {
+	e := New()
 	testRepoCleanup := testutils.PrepareTestGitRepository(testRepoPath, testRepoCloneUrl, testdataPath)
 	t.Cleanup(testRepoCleanup)
 	envCleanup := testutils.SetEnvsFromFile(envsFilePath)
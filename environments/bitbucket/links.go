@@ -0,0 +1,73 @@
+package bitbucket
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/argonsecurity/go-environments/environments/utils"
+)
+
+func GetCommitLink(repositoryURL string, commit string) string {
+	if commit == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/commits/%s", repositoryURL, commit)
+}
+
+// GetCompareLink returns the link to the diff between two commits,
+// Bitbucket compares the source with the destination separated by a carriage return, i.e. compare/<head>%0D<base>
+func GetCompareLink(repositoryURL string, baseCommit string, headCommit string) string {
+	if baseCommit == "" || headCommit == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/branches/compare/%s%%0D%s", repositoryURL, headCommit, baseCommit)
+}
+
+func GetPullRequestLink(repositoryURL string, pullRequestId string) string {
+	if pullRequestId == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/pull-requests/%s", repositoryURL, pullRequestId)
+}
+
+// GetRefLink returns the link to the source of a branch or tag, Bitbucket serves both under src/<name>
+func GetRefLink(repositoryURL string, ref string) string {
+	name, _ := utils.ParseRef(ref)
+	if name == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/src/%s", repositoryURL, url.PathEscape(name))
+}
+
+var (
+	// https://bitbucket.org/workspace/repo/src/<ref>/path/to/file, with ?at=<branch> when the ref is a commit of the branch
+	fileLinkRegexp = regexp.MustCompile(`^(.+?)/src/([^/]+)/([^?]+)(?:\?at=.*)?$`)
+	// #lines-10:20, or #lines-10 for a single line
+	linesRegexp = regexp.MustCompile(`^lines-(\d+)(?::(\d+))?$`)
+)
+
+// ParseFileLineLink returns the repository url, the file, the ref and the lines of a link built by GetFileLineLink,
+// the ref is refs/heads/<branch> for a branch and the commit for a 40 character commit sha in the path of the link,
+// the branch of the at parameter is dropped.
+// The end line is the start line for a single line
+// Branches with slashes can't be told from the file, the ref is read up to the first slash
+func ParseFileLineLink(link string) (string, string, string, int, int, error) {
+	link, fragment, _ := strings.Cut(link, "#")
+	result := fileLinkRegexp.FindStringSubmatch(link)
+	if result == nil {
+		return "", "", "", 0, 0, fmt.Errorf("could not parse file link: %s", link)
+	}
+
+	startLine, endLine := 0, 0
+	if lines := linesRegexp.FindStringSubmatch(fragment); lines != nil {
+		startLine, _ = strconv.Atoi(lines[1])
+		endLine = startLine
+		if lines[2] != "" {
+			endLine, _ = strconv.Atoi(lines[2])
+		}
+	}
+	return result[1], result[3], utils.GetLinkRef(result[2]), startLine, endLine, nil
+}
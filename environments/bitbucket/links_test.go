@@ -0,0 +1,181 @@
+package bitbucket
+
+import (
+	"testing"
+)
+
+func TestGetCommitLink(t *testing.T) {
+	tests := []struct {
+		name   string
+		commit string
+		want   string
+	}{
+		{
+			name:   "With commit",
+			commit: "0a1b2c",
+			want:   "https://bitbucket.org/org/repo/commits/0a1b2c",
+		},
+		{
+			name:   "Without commit",
+			commit: "",
+			want:   "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetCommitLink("https://bitbucket.org/org/repo", tt.commit); got != tt.want {
+				t.Errorf("GetCommitLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetCompareLink(t *testing.T) {
+	tests := []struct {
+		name       string
+		baseCommit string
+		headCommit string
+		want       string
+	}{
+		{
+			name:       "With base and head commits",
+			baseCommit: "9f8e7d",
+			headCommit: "0a1b2c",
+			want:       "https://bitbucket.org/org/repo/branches/compare/0a1b2c%0D9f8e7d",
+		},
+		{
+			name:       "Without base commit",
+			headCommit: "0a1b2c",
+			want:       "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetCompareLink("https://bitbucket.org/org/repo", tt.baseCommit, tt.headCommit); got != tt.want {
+				t.Errorf("GetCompareLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetPullRequestLink(t *testing.T) {
+	tests := []struct {
+		name          string
+		pullRequestId string
+		want          string
+	}{
+		{
+			name:          "With pull request id",
+			pullRequestId: "12",
+			want:          "https://bitbucket.org/org/repo/pull-requests/12",
+		},
+		{
+			name:          "Without pull request id",
+			pullRequestId: "",
+			want:          "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetPullRequestLink("https://bitbucket.org/org/repo", tt.pullRequestId); got != tt.want {
+				t.Errorf("GetPullRequestLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetRefLink(t *testing.T) {
+	tests := []struct {
+		name string
+		ref  string
+		want string
+	}{
+		{
+			name: "Branch name",
+			ref:  "feature/x",
+			want: "https://bitbucket.org/org/repo/src/feature%2Fx",
+		},
+		{
+			name: "Branch ref",
+			ref:  "refs/heads/feature/x",
+			want: "https://bitbucket.org/org/repo/src/feature%2Fx",
+		},
+		{
+			name: "Tag ref",
+			ref:  "refs/tags/v1.0",
+			want: "https://bitbucket.org/org/repo/src/v1.0",
+		},
+		{
+			name: "Empty ref",
+			ref:  "",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetRefLink("https://bitbucket.org/org/repo", tt.ref); got != tt.want {
+				t.Errorf("GetRefLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFileLineLink(t *testing.T) {
+	tests := []struct {
+		name              string
+		link              string
+		wantRepositoryURL string
+		wantFilename      string
+		wantRef           string
+		wantStartLine     int
+		wantEndLine       int
+		wantErr           bool
+	}{
+		{
+			name:              "Lines",
+			link:              "https://bitbucket.org/workspace/repo/src/main/path/to/file#lines-10:20",
+			wantRepositoryURL: "https://bitbucket.org/workspace/repo",
+			wantFilename:      "path/to/file",
+			wantRef:           "refs/heads/main",
+			wantStartLine:     10,
+			wantEndLine:       20,
+		},
+		{
+			name:              "Single line",
+			link:              "https://bitbucket.org/workspace/repo/src/main/path/to/file#lines-10",
+			wantRepositoryURL: "https://bitbucket.org/workspace/repo",
+			wantFilename:      "path/to/file",
+			wantRef:           "refs/heads/main",
+			wantStartLine:     10,
+			wantEndLine:       10,
+		},
+		{
+			name:              "Commit of a branch",
+			link:              "https://bitbucket.org/workspace/repo/src/0a1b2c3d4e5f60718293a4b5c6d7e8f901234567/path/to/file?at=feature%2Fx#lines-10",
+			wantRepositoryURL: "https://bitbucket.org/workspace/repo",
+			wantFilename:      "path/to/file",
+			wantRef:           "0a1b2c3d4e5f60718293a4b5c6d7e8f901234567",
+			wantStartLine:     10,
+			wantEndLine:       10,
+		},
+		{
+			name:    "Not a file link",
+			link:    "https://bitbucket.org/workspace/repo/commits/0a1b2c",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repositoryURL, filename, ref, startLine, endLine, err := ParseFileLineLink(tt.link)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFileLineLink() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if repositoryURL != tt.wantRepositoryURL || filename != tt.wantFilename || ref != tt.wantRef {
+				t.Errorf("ParseFileLineLink() = %v, %v, %v, want %v, %v, %v", repositoryURL, filename, ref, tt.wantRepositoryURL, tt.wantFilename, tt.wantRef)
+			}
+			if startLine != tt.wantStartLine || endLine != tt.wantEndLine {
+				t.Errorf("ParseFileLineLink() lines = %v-%v, want %v-%v", startLine, endLine, tt.wantStartLine, tt.wantEndLine)
+			}
+		})
+	}
+}
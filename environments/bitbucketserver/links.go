@@ -2,7 +2,12 @@ package bitbucketserver
 
 import (
 	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
 	"strings"
+
+	"github.com/argonsecurity/go-environments/environments/utils"
 )
 
 func GetFileLink(repositoryURL string, filename string, branch string, commit string) string {
@@ -24,15 +29,85 @@ func GetFileLink(repositoryURL string, filename string, branch string, commit st
 }
 
 func GetFileLineLink(repositoryURL string, filename string, branch string, commit string, startLine int, endLine int) string {
-	url := GetFileLink(repositoryURL, filename, branch, commit)
+	link := GetFileLink(repositoryURL, filename, branch, commit)
 	if startLine != 0 {
 		lines := fmt.Sprintf("#%d", startLine)
 		if endLine != 0 && endLine != startLine {
 			lines = fmt.Sprintf("%s-%d", lines, endLine)
 		}
 
-		url += lines
+		link += lines
+	}
+
+	return link
+}
+
+func GetCommitLink(repositoryURL string, commit string) string {
+	if commit == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/commits/%s", strings.TrimSuffix(repositoryURL, "/browse"), commit)
+}
+
+func GetCompareLink(repositoryURL string, baseCommit string, headCommit string) string {
+	if baseCommit == "" || headCommit == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/compare/diff?sourceBranch=%s&targetBranch=%s",
+		strings.TrimSuffix(repositoryURL, "/browse"),
+		headCommit,
+		baseCommit)
+}
+
+func GetPullRequestLink(repositoryURL string, pullRequestId string) string {
+	if pullRequestId == "" {
+		return ""
 	}
+	return fmt.Sprintf("%s/pull-requests/%s", strings.TrimSuffix(repositoryURL, "/browse"), pullRequestId)
+}
 
-	return url
+// GetRefLink returns the link to browse a branch or tag, Bitbucket Server expects the full ref in the at parameter
+func GetRefLink(repositoryURL string, ref string) string {
+	name, isTag := utils.ParseRef(ref)
+	if name == "" {
+		return ""
+	}
+	fullRef := fmt.Sprintf("refs/heads/%s", name)
+	if isTag {
+		fullRef = fmt.Sprintf("refs/tags/%s", name)
+	}
+	return fmt.Sprintf("%s/browse?at=%s", strings.TrimSuffix(repositoryURL, "/browse"), url.QueryEscape(fullRef))
+}
+
+var (
+	// https://bitbucket.company.com/projects/PROJ/repos/repo/browse/path/to/file?at=<ref>
+	fileLinkRegexp = regexp.MustCompile(`^(.+?)/browse/([^?]+)\?at=(.+)$`)
+	// #10-20, or #10 for a single line
+	linesRegexp = regexp.MustCompile(`^(\d+)(?:-(\d+))?$`)
+)
+
+// ParseFileLineLink returns the repository url, the file, the ref and the lines of a link built by GetFileLineLink,
+// the ref is refs/heads/<branch> for a branch and the commit for a 40 character commit sha in the at parameter,
+// and the end line is the start line for a single line
+func ParseFileLineLink(link string) (string, string, string, int, int, error) {
+	link, fragment, _ := strings.Cut(link, "#")
+	result := fileLinkRegexp.FindStringSubmatch(link)
+	if result == nil {
+		return "", "", "", 0, 0, fmt.Errorf("could not parse file link: %s", link)
+	}
+	// Links copied from Bitbucket Server escape the ref, i.e. ?at=refs%2Fheads%2Fmaster
+	ref, err := url.PathUnescape(result[3])
+	if err != nil {
+		return "", "", "", 0, 0, fmt.Errorf("could not parse file link: %s", link)
+	}
+
+	startLine, endLine := 0, 0
+	if lines := linesRegexp.FindStringSubmatch(fragment); lines != nil {
+		startLine, _ = strconv.Atoi(lines[1])
+		endLine = startLine
+		if lines[2] != "" {
+			endLine, _ = strconv.Atoi(lines[2])
+		}
+	}
+	return result[1], result[2], utils.GetLinkRef(ref), startLine, endLine, nil
 }
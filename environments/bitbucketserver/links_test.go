@@ -135,3 +135,179 @@ func TestGetFileLineLink(t *testing.T) {
 		})
 	}
 }
+
+func TestGetCommitLink(t *testing.T) {
+	tests := []struct {
+		name   string
+		commit string
+		want   string
+	}{
+		{
+			name:   "With commit",
+			commit: "0a1b2c",
+			want:   "https://bitbucket-server.com/projects/ar/repos/reponame/commits/0a1b2c",
+		},
+		{
+			name:   "Without commit",
+			commit: "",
+			want:   "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetCommitLink("https://bitbucket-server.com/projects/ar/repos/reponame", tt.commit); got != tt.want {
+				t.Errorf("GetCommitLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetCompareLink(t *testing.T) {
+	tests := []struct {
+		name       string
+		baseCommit string
+		headCommit string
+		want       string
+	}{
+		{
+			name:       "With base and head commits",
+			baseCommit: "9f8e7d",
+			headCommit: "0a1b2c",
+			want:       "https://bitbucket-server.com/projects/ar/repos/reponame/compare/diff?sourceBranch=0a1b2c&targetBranch=9f8e7d",
+		},
+		{
+			name:       "Without base commit",
+			headCommit: "0a1b2c",
+			want:       "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetCompareLink("https://bitbucket-server.com/projects/ar/repos/reponame", tt.baseCommit, tt.headCommit); got != tt.want {
+				t.Errorf("GetCompareLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetPullRequestLink(t *testing.T) {
+	tests := []struct {
+		name          string
+		pullRequestId string
+		want          string
+	}{
+		{
+			name:          "With pull request id",
+			pullRequestId: "12",
+			want:          "https://bitbucket-server.com/projects/ar/repos/reponame/pull-requests/12",
+		},
+		{
+			name:          "Without pull request id",
+			pullRequestId: "",
+			want:          "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetPullRequestLink("https://bitbucket-server.com/projects/ar/repos/reponame", tt.pullRequestId); got != tt.want {
+				t.Errorf("GetPullRequestLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetRefLink(t *testing.T) {
+	tests := []struct {
+		name string
+		ref  string
+		want string
+	}{
+		{
+			name: "Branch name",
+			ref:  "feature/x",
+			want: "https://bitbucket-server.com/projects/ar/repos/reponame/browse?at=refs%2Fheads%2Ffeature%2Fx",
+		},
+		{
+			name: "Branch ref",
+			ref:  "refs/heads/feature/x",
+			want: "https://bitbucket-server.com/projects/ar/repos/reponame/browse?at=refs%2Fheads%2Ffeature%2Fx",
+		},
+		{
+			name: "Tag ref",
+			ref:  "refs/tags/v1.0",
+			want: "https://bitbucket-server.com/projects/ar/repos/reponame/browse?at=refs%2Ftags%2Fv1.0",
+		},
+		{
+			name: "Empty ref",
+			ref:  "",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetRefLink("https://bitbucket-server.com/projects/ar/repos/reponame", tt.ref); got != tt.want {
+				t.Errorf("GetRefLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFileLineLink(t *testing.T) {
+	tests := []struct {
+		name              string
+		link              string
+		wantRepositoryURL string
+		wantFilename      string
+		wantRef           string
+		wantStartLine     int
+		wantEndLine       int
+		wantErr           bool
+	}{
+		{
+			name:              "Lines",
+			link:              "https://bitbucket-server.com/projects/ar/repos/reponame/browse/path/to/file?at=0a1b2c3d4e5f60718293a4b5c6d7e8f901234567#5-8",
+			wantRepositoryURL: "https://bitbucket-server.com/projects/ar/repos/reponame",
+			wantFilename:      "path/to/file",
+			wantRef:           "0a1b2c3d4e5f60718293a4b5c6d7e8f901234567",
+			wantStartLine:     5,
+			wantEndLine:       8,
+		},
+		{
+			name:              "Single line",
+			link:              "https://bitbucket-server.com/projects/ar/repos/reponame/browse/path/to/file?at=branch#5",
+			wantRepositoryURL: "https://bitbucket-server.com/projects/ar/repos/reponame",
+			wantFilename:      "path/to/file",
+			wantRef:           "refs/heads/branch",
+			wantStartLine:     5,
+			wantEndLine:       5,
+		},
+		{
+			name:              "Escaped ref",
+			link:              "https://bitbucket-server.com/projects/ar/repos/reponame/browse/path/to/file?at=refs%2Fheads%2Fmaster",
+			wantRepositoryURL: "https://bitbucket-server.com/projects/ar/repos/reponame",
+			wantFilename:      "path/to/file",
+			wantRef:           "refs/heads/master",
+			wantStartLine:     0,
+			wantEndLine:       0,
+		},
+		{
+			name:    "Without ref",
+			link:    "https://bitbucket-server.com/projects/ar/repos/reponame/browse/path/to/file",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repositoryURL, filename, ref, startLine, endLine, err := ParseFileLineLink(tt.link)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFileLineLink() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if repositoryURL != tt.wantRepositoryURL || filename != tt.wantFilename || ref != tt.wantRef {
+				t.Errorf("ParseFileLineLink() = %v, %v, %v, want %v, %v, %v", repositoryURL, filename, ref, tt.wantRepositoryURL, tt.wantFilename, tt.wantRef)
+			}
+			if startLine != tt.wantStartLine || endLine != tt.wantEndLine {
+				t.Errorf("ParseFileLineLink() lines = %v-%v, want %v-%v", startLine, endLine, tt.wantStartLine, tt.wantEndLine)
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package buildkite
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/utils"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/models"
+)
+
+const (
+	builder      = "Buildkite"
+	buildkiteEnv = "BUILDKITE"
+
+	organizationSlugEnv = "BUILDKITE_ORGANIZATION_SLUG"
+	pipelineSlugEnv     = "BUILDKITE_PIPELINE_SLUG"
+	pipelineNameEnv     = "BUILDKITE_PIPELINE_NAME"
+	pipelineIdEnv       = "BUILDKITE_PIPELINE_ID"
+
+	buildIdEnv     = "BUILDKITE_BUILD_ID"
+	buildNumberEnv = "BUILDKITE_BUILD_NUMBER"
+	buildUrlEnv    = "BUILDKITE_BUILD_URL"
+
+	jobIdEnv    = "BUILDKITE_JOB_ID"
+	jobLabelEnv = "BUILDKITE_LABEL"
+
+	agentIdEnv   = "BUILDKITE_AGENT_ID"
+	agentNameEnv = "BUILDKITE_AGENT_NAME"
+
+	buildAuthorEnv      = "BUILDKITE_BUILD_AUTHOR"
+	buildAuthorEmailEnv = "BUILDKITE_BUILD_AUTHOR_EMAIL"
+
+	repositoryCloneUrlEnv = "BUILDKITE_REPO"
+	repositoryPathEnv     = "BUILDKITE_BUILD_CHECKOUT_PATH"
+	commitShaEnv          = "BUILDKITE_COMMIT"
+	branchEnv             = "BUILDKITE_BRANCH"
+
+	pullRequestEnv           = "BUILDKITE_PULL_REQUEST"
+	pullRequestBaseBranchEnv = "BUILDKITE_PULL_REQUEST_BASE_BRANCH"
+
+	noPullRequest = "false"
+
+	buildkiteUrl          = "https://buildkite.com"
+	buildkitePipelineFile = ".buildkite/pipeline.yml"
+)
+
+var (
+	// Buildkite environment
+	Buildkite = New()
+)
+
+// Environment is the Buildkite environment
+type Environment struct {
+	cache utils.ConfigurationCache
+}
+
+// New creates a Buildkite environment with its own configuration cache
+func New() *Environment {
+	return &Environment{}
+}
+
+func (e *Environment) GetConfiguration() (*models.Configuration, error) {
+	return e.cache.Get(e.load)
+}
+
+// Refresh loads the configuration again and replaces the cached configuration
+func (e *Environment) Refresh() (*models.Configuration, error) {
+	return e.cache.Refresh(e.load)
+}
+
+// Reset clears the cached configuration, it is loaded again on the next GetConfiguration
+func (e *Environment) Reset() {
+	e.cache.Reset()
+}
+
+func (e *Environment) load() (*models.Configuration, error) {
+	return loadConfiguration(envsource.OS)
+}
+
+func (e *Environment) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return loadConfiguration(src)
+}
+
+func loadConfiguration(src envsource.EnvSource) (*models.Configuration, error) {
+	cloneUrl := utils.StripCredentialsFromUrl(src.Getenv(repositoryCloneUrlEnv))
+	source, apiUrl := utils.GetRepositorySource(cloneUrl)
+	repoUrl, org, repoName, repoFullName, err := utils.ParseDataFromCloneUrl(cloneUrl, apiUrl, source)
+	if err != nil {
+		return nil, err
+	}
+
+	repoPath := src.Getenv(repositoryPathEnv)
+	branch := src.Getenv(branchEnv)
+	commit := src.Getenv(commitShaEnv)
+
+	pullRequest := models.PullRequest{}
+	if prNumber := src.Getenv(pullRequestEnv); prNumber != "" && prNumber != noPullRequest {
+		pullRequest = models.PullRequest{
+			Id: prNumber,
+			SourceRef: models.Ref{
+				Branch: branch,
+				Sha:    commit,
+			},
+			TargetRef: models.Ref{
+				Branch: src.Getenv(pullRequestBaseBranchEnv),
+			},
+		}
+	}
+
+	return &models.Configuration{
+		Url:       buildkiteUrl,
+		SCMApiUrl: apiUrl,
+		LocalPath: repoPath,
+		CommitSha: commit,
+		Branch:    branch,
+		Repository: models.Repository{
+			Name:     repoName,
+			FullName: repoFullName,
+			Url:      repoUrl,
+			CloneUrl: cloneUrl,
+			Source:   source,
+		},
+		Organization: models.Entity{
+			Id:   src.Getenv(organizationSlugEnv),
+			Name: org,
+		},
+		Pipeline: models.Pipeline{
+			Entity: models.Entity{
+				Id:   src.Getenv(pipelineIdEnv),
+				Name: getPipelineName(src),
+			},
+			Path: getPipelinePath(src, repoPath),
+		},
+		Job: models.Entity{
+			Id:   src.Getenv(jobIdEnv),
+			Name: src.Getenv(jobLabelEnv),
+		},
+		Run: models.BuildRun{
+			BuildId:     src.Getenv(buildIdEnv),
+			BuildNumber: src.Getenv(buildNumberEnv),
+		},
+		Runner: models.Runner{
+			Id:           src.Getenv(agentIdEnv),
+			Name:         src.Getenv(agentNameEnv),
+			OS:           runtime.GOOS,
+			Architecture: runtime.GOARCH,
+		},
+		PullRequest:   pullRequest,
+		Builder:       builder,
+		Pusher:        getPusher(src),
+		PipelinePaths: getPipelinePaths(src, repoPath),
+		Environment:   enums.Buildkite,
+		ScmId:         utils.GenerateScmId(cloneUrl),
+	}, nil
+}
+
+func getPipelineName(src envsource.EnvSource) string {
+	if name := src.Getenv(pipelineNameEnv); name != "" {
+		return name
+	}
+	return src.Getenv(pipelineSlugEnv)
+}
+
+func getPusher(src envsource.EnvSource) models.Pusher {
+	if author := src.Getenv(buildAuthorEnv); author != "" {
+		return models.Pusher{
+			Username: author,
+			Email:    src.Getenv(buildAuthorEmailEnv),
+		}
+	}
+	return models.Pusher{
+		Username: utils.DetectPusherFrom(src),
+	}
+}
+
+func getBuildUrl() string {
+	if buildUrl := os.Getenv(buildUrlEnv); buildUrl != "" {
+		return buildUrl
+	}
+	return fmt.Sprintf("%s/%s/%s/builds/%s", buildkiteUrl, os.Getenv(organizationSlugEnv), os.Getenv(pipelineSlugEnv), os.Getenv(buildNumberEnv))
+}
+
+func (e *Environment) GetStepLink() string {
+	return fmt.Sprintf("%s#%s", getBuildUrl(), os.Getenv(jobIdEnv))
+}
+
+func (e *Environment) GetBuildLink() string {
+	return getBuildUrl()
+}
+
+func (e *Environment) GetFileLink(filename string, branch string, commit string) string {
+	return ""
+}
+
+func (e *Environment) GetFileLineLink(filename string, branch string, commit string, startLine int, endLine int) string {
+	return ""
+}
+
+func (e *Environment) IsCurrentEnvironment() bool {
+	_, isExist := os.LookupEnv(buildkiteEnv)
+	return isExist
+}
+
+// DetectionVariables returns the variables used by IsCurrentEnvironment
+func (e *Environment) DetectionVariables() []string {
+	return []string{buildkiteEnv}
+}
+
+// ExpectedFields returns the configuration fields the environment is expected to fill and the variables they are filled from
+func (e *Environment) ExpectedFields() []models.ExpectedField {
+	return []models.ExpectedField{
+		{Field: "repository.url", SourceEnv: repositoryCloneUrlEnv, Severity: models.SeverityCritical},
+		{Field: "repository.cloneUrl", SourceEnv: repositoryCloneUrlEnv, Severity: models.SeverityCritical},
+		{Field: "commitSha", SourceEnv: commitShaEnv, Severity: models.SeverityCritical},
+		{Field: "branch", SourceEnv: branchEnv, Severity: models.SeverityWarning},
+		{Field: "localPath", SourceEnv: repositoryPathEnv, Severity: models.SeverityWarning},
+		{Field: "organization.id", SourceEnv: organizationSlugEnv, Severity: models.SeverityWarning},
+		{Field: "pipeline.name", SourceEnv: pipelineSlugEnv, Severity: models.SeverityWarning},
+		{Field: "job.id", SourceEnv: jobIdEnv, Severity: models.SeverityWarning},
+		{Field: "run.buildNumber", SourceEnv: buildNumberEnv, Severity: models.SeverityWarning},
+		{Field: "runner.name", SourceEnv: agentNameEnv, Severity: models.SeverityWarning},
+	}
+}
+
+func (e *Environment) Name() string {
+	return "buildkite"
+}
+
+func getPipelinePaths(src envsource.EnvSource, rootDir string) []string {
+	paths := make([]string, 0)
+
+	path := filepath.Join(rootDir, buildkitePipelineFile)
+	if _, err := envsource.Stat(src, path); err == nil {
+		paths = append(paths, path)
+	}
+
+	return paths
+}
+
+// getPipelinePath returns the path of the pipeline file relative to the repository, or an empty string when the repository has none
+func getPipelinePath(src envsource.EnvSource, rootDir string) string {
+	if _, err := envsource.Stat(src, filepath.Join(rootDir, buildkitePipelineFile)); err != nil {
+		return ""
+	}
+	return buildkitePipelineFile
+}
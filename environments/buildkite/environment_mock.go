@@ -0,0 +1,112 @@
+package buildkite
+
+import (
+	"fmt"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/models"
+)
+
+var (
+	MockOrgName      = "test-org"
+	MockRepoName     = "test-repo"
+	MockPipelineSlug = "test-pipeline"
+	MockBuildNumber  = "42"
+	MockJobId        = "0188f3b2-8b2c-4d3e-9f4a-5b6c7d8e9f0a"
+)
+
+var mockConfiguration *models.Configuration
+
+type EnvironmentMock struct{}
+
+func (em *EnvironmentMock) GetConfiguration() (*models.Configuration, error) {
+	if mockConfiguration == nil {
+		if err := loadMockConfiguration(); err != nil {
+			return nil, err
+		}
+	}
+	return mockConfiguration, nil
+}
+
+func (em *EnvironmentMock) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return em.GetConfiguration()
+}
+
+func (em *EnvironmentMock) Refresh() (*models.Configuration, error) {
+	em.Reset()
+	return em.GetConfiguration()
+}
+
+func (em *EnvironmentMock) Reset() {
+	mockConfiguration = nil
+}
+
+func loadMockConfiguration() error {
+	mockConfiguration = &models.Configuration{
+		Url:       "https://buildkite.com",
+		SCMApiUrl: "https://api.github.com",
+		LocalPath: fmt.Sprintf("/var/lib/buildkite-agent/builds/agent-linux-1/%s/%s", MockOrgName, MockPipelineSlug),
+		CommitSha: "3s32e4s818c6d1s5a0f585sf73112673a9bfcfc7",
+		Branch:    "main",
+		Run: models.BuildRun{
+			BuildId:     "0188f3b2-7a1b-4c2d-8e3f-4a5b6c7d8e9f",
+			BuildNumber: MockBuildNumber,
+		},
+		Job: models.Entity{
+			Id:   MockJobId,
+			Name: ":go: test",
+		},
+		Pipeline: models.Pipeline{
+			Entity: models.Entity{
+				Id:   "0188f3b2-1a2b-4c3d-8e4f-5a6b7c8d9e0f",
+				Name: MockPipelineSlug,
+			},
+			Path: ".buildkite/pipeline.yml",
+		},
+		Runner: models.Runner{
+			Name: "agent-linux-1",
+			OS:   "linux",
+		},
+		Repository: models.Repository{
+			Name:     MockRepoName,
+			FullName: fmt.Sprintf("%s/%s", MockOrgName, MockRepoName),
+			Url:      fmt.Sprintf("https://github.com/%s/%s", MockOrgName, MockRepoName),
+			CloneUrl: fmt.Sprintf("https://github.com/%s/%s.git", MockOrgName, MockRepoName),
+			Source:   enums.Github,
+		},
+		Builder: "Buildkite",
+		Organization: models.Entity{
+			Id:   MockOrgName,
+			Name: MockOrgName,
+		},
+		PipelinePaths: []string{".buildkite/pipeline.yml"},
+		Environment:   enums.Buildkite,
+	}
+
+	return nil
+}
+
+func (em *EnvironmentMock) GetBuildLink() string {
+	return fmt.Sprintf("https://buildkite.com/%s/%s/builds/%s", MockOrgName, MockPipelineSlug, MockBuildNumber)
+}
+
+func (em *EnvironmentMock) GetStepLink() string {
+	return fmt.Sprintf("https://buildkite.com/%s/%s/builds/%s#%s", MockOrgName, MockPipelineSlug, MockBuildNumber, MockJobId)
+}
+
+func (em *EnvironmentMock) GetFileLink(filename string, branch string, commit string) string {
+	return ""
+}
+
+func (em *EnvironmentMock) GetFileLineLink(filename string, branch string, commit string, startLine int, endLine int) string {
+	return ""
+}
+
+func (em *EnvironmentMock) IsCurrentEnvironment() bool {
+	return true
+}
+
+func (em *EnvironmentMock) Name() string {
+	return "buildkite"
+}
@@ -0,0 +1,259 @@
+package buildkite
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/testutils"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/models"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	buildkiteMainEnvsFilePath = "testdata/buildkite-github-main-env.json"
+	buildkitePrEnvsFilePath   = "testdata/buildkite-github-pr-env.json"
+	testRepoPath              = "/tmp/buildkite/repo"
+	testRepoUrl               = "https://github.com/test-organization/test-repo"
+	testRepoCloneUrl          = fmt.Sprintf("%s%s", testRepoUrl, ".git")
+	testdataPath              = "../buildkite/testdata/repo"
+)
+
+func Test_environment_GetConfiguration(t *testing.T) {
+	tests := []struct {
+		name         string
+		envsFilePath string
+		want         *models.Configuration
+		wantErr      bool
+	}{
+		{
+			name:         "Buildkite main configuration",
+			envsFilePath: buildkiteMainEnvsFilePath,
+			want: &models.Configuration{
+				Url:       "https://buildkite.com",
+				SCMApiUrl: "https://api.github.com",
+				LocalPath: testRepoPath,
+				CommitSha: "kcy8v2oazy4acuo1475rgtzg0nh403p23vw812lv",
+				Branch:    "main",
+				Repository: models.Repository{
+					Name:     "test-repo",
+					FullName: "test-organization/test-repo",
+					Url:      testRepoUrl,
+					CloneUrl: testRepoCloneUrl,
+					Source:   enums.Github,
+				},
+				Organization: models.Entity{
+					Id:   "test-organization",
+					Name: "test-organization",
+				},
+				Pipeline: models.Pipeline{
+					Entity: models.Entity{
+						Id:   "0188f3b2-1a2b-4c3d-8e4f-5a6b7c8d9e0f",
+						Name: "Test Pipeline",
+					},
+					Path: ".buildkite/pipeline.yml",
+				},
+				Job: models.Entity{
+					Id:   "0188f3b2-8b2c-4d3e-9f4a-5b6c7d8e9f0a",
+					Name: ":go: test",
+				},
+				Run: models.BuildRun{
+					BuildId:     "0188f3b2-7a1b-4c2d-8e3f-4a5b6c7d8e9f",
+					BuildNumber: "42",
+				},
+				Runner: models.Runner{
+					Id:           "0188f3b2-5c8a-4d7e-9b1a-2f3c4d5e6f70",
+					Name:         "agent-linux-1",
+					OS:           runtime.GOOS,
+					Architecture: runtime.GOARCH,
+				},
+				Pusher: models.Pusher{
+					Username: "test-user",
+					Email:    "test-user@example.com",
+				},
+				Builder:       "Buildkite",
+				PipelinePaths: []string{"/tmp/buildkite/repo/.buildkite/pipeline.yml"},
+				Environment:   enums.Buildkite,
+				ScmId:         "8891c0db39f3064732cc1b4ac02c9b9f",
+			},
+		},
+		{
+			name:         "Buildkite pull request configuration with ssh clone url",
+			envsFilePath: buildkitePrEnvsFilePath,
+			want: &models.Configuration{
+				Url:       "https://buildkite.com",
+				SCMApiUrl: "https://api.github.com",
+				LocalPath: testRepoPath,
+				CommitSha: "y2nc0ns6qk8qs4dfbsvnjrf2o8dvmrfrthmx9nbl",
+				Branch:    "feature/buildkite",
+				Repository: models.Repository{
+					Name:     "test-repo",
+					FullName: "test-organization/test-repo",
+					Url:      testRepoUrl,
+					CloneUrl: "git@github.com:test-organization/test-repo.git",
+					Source:   enums.Github,
+				},
+				Organization: models.Entity{
+					Id:   "test-organization",
+					Name: "test-organization",
+				},
+				Pipeline: models.Pipeline{
+					Entity: models.Entity{
+						Id:   "0188f3b2-1a2b-4c3d-8e4f-5a6b7c8d9e0f",
+						Name: "test-pipeline",
+					},
+					Path: ".buildkite/pipeline.yml",
+				},
+				Job: models.Entity{
+					Id:   "0188f3c5-3e4f-4a5b-8c7d-8e9f0a1b2c3d",
+					Name: ":go: test",
+				},
+				Run: models.BuildRun{
+					BuildId:     "0188f3c5-2d3e-4f5a-9b6c-7d8e9f0a1b2c",
+					BuildNumber: "43",
+				},
+				Runner: models.Runner{
+					Id:           "0188f3b2-5c8a-4d7e-9b1a-2f3c4d5e6f70",
+					Name:         "agent-linux-1",
+					OS:           runtime.GOOS,
+					Architecture: runtime.GOARCH,
+				},
+				PullRequest: models.PullRequest{
+					Id: "7",
+					SourceRef: models.Ref{
+						Branch: "feature/buildkite",
+						Sha:    "y2nc0ns6qk8qs4dfbsvnjrf2o8dvmrfrthmx9nbl",
+					},
+					TargetRef: models.Ref{
+						Branch: "main",
+					},
+				},
+				Pusher: models.Pusher{
+					Username: "test-user",
+				},
+				Builder:       "Buildkite",
+				PipelinePaths: []string{"/tmp/buildkite/repo/.buildkite/pipeline.yml"},
+				Environment:   enums.Buildkite,
+				ScmId:         "8891c0db39f3064732cc1b4ac02c9b9f",
+			},
+		},
+		{
+			name:         "Missing repository",
+			envsFilePath: "",
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := prepareTest(t, tt.envsFilePath)
+			got, err := e.GetConfiguration()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("environment.GetConfiguration() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_environment_GetConfigurationWithoutPipelineFile(t *testing.T) {
+	envs := testutils.LoadEnvsFromFile(buildkiteMainEnvsFilePath)
+	envs[repositoryPathEnv] = t.TempDir()
+
+	got, err := New().GetConfigurationFrom(envsource.New(envs))
+	assert.NoError(t, err)
+	assert.Equal(t, "", got.Pipeline.Path)
+	assert.Empty(t, got.PipelinePaths)
+}
+
+func Test_environment_GetStepLink(t *testing.T) {
+	tests := []struct {
+		name         string
+		envsFilePath string
+		want         string
+	}{
+		{
+			name:         "Buildkite environment",
+			envsFilePath: buildkiteMainEnvsFilePath,
+			want:         "https://buildkite.com/test-organization/test-pipeline/builds/42#0188f3b2-8b2c-4d3e-9f4a-5b6c7d8e9f0a",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := prepareTest(t, tt.envsFilePath)
+			if got := e.GetStepLink(); got != tt.want {
+				t.Errorf("environment.GetStepLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_environment_GetBuildLink(t *testing.T) {
+	tests := []struct {
+		name         string
+		envsFilePath string
+		unsetEnvs    []string
+		want         string
+	}{
+		{
+			name:         "Buildkite environment",
+			envsFilePath: buildkiteMainEnvsFilePath,
+			want:         "https://buildkite.com/test-organization/test-pipeline/builds/42",
+		},
+		{
+			name:         "Buildkite environment without build url",
+			envsFilePath: buildkiteMainEnvsFilePath,
+			unsetEnvs:    []string{buildUrlEnv},
+			want:         "https://buildkite.com/test-organization/test-pipeline/builds/42",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := prepareTest(t, tt.envsFilePath)
+			for _, env := range tt.unsetEnvs {
+				t.Setenv(env, "")
+			}
+			if got := e.GetBuildLink(); got != tt.want {
+				t.Errorf("environment.GetBuildLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_environment_IsCurrentEnvironment(t *testing.T) {
+	tests := []struct {
+		name         string
+		envsFilePath string
+		want         bool
+	}{
+		{
+			name:         "Buildkite environment",
+			envsFilePath: buildkiteMainEnvsFilePath,
+			want:         true,
+		},
+		{
+			name:         "Not Buildkite environment",
+			envsFilePath: "",
+			want:         false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := prepareTest(t, tt.envsFilePath)
+			if got := e.IsCurrentEnvironment(); got != tt.want {
+				t.Errorf("environment.IsCurrentEnvironment() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func prepareTest(t *testing.T, envsFilePath string) *Environment {
+	e := New()
+	testRepoCleanup := testutils.PrepareTestGitRepository(testRepoPath, testRepoCloneUrl, testdataPath)
+	t.Cleanup(testRepoCleanup)
+	envCleanup := testutils.SetEnvsFromFile(envsFilePath)
+	t.Cleanup(envCleanup)
+	return e
+}
@@ -12,11 +12,13 @@ import (
 
 	"github.com/argonsecurity/go-environments/enums"
 	"github.com/argonsecurity/go-environments/environments/utils"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
 	"github.com/argonsecurity/go-environments/models"
 )
 
 const (
 	builder               = "CircleCi"
+	circleCiEnv           = "CIRCLECI"
 	buildNumberEnv        = "CIRCLE_BUILD_NUM"
 	repositoryCloneURLEnv = "CIRCLE_REPOSITORY_URL"
 	commitShaEnv          = "CIRCLE_SHA1"
@@ -30,52 +32,64 @@ const (
 	workingDirectoryEnv   = "CIRCLE_WORKING_DIRECTORY"
 	pipelinePath          = ".circleci/config.yml"
 
-	githubHostname    = "github.com"
-	gitlabHostname    = "gitlab.com"
-	azureHostname     = "dev.azure.com"
-	bitbucketHostname = "bitbucket.org"
-
-	githubApiUrl    = "https://api.github.com"
-	gitlabApiUrl    = "https://gitlab.com/api/v4"
-	azureApiUrl     = ""
-	bitbucketApiUrl = "https://api.bitbucket.org/2.0"
+	githubApiUrl = utils.GithubApiUrl
 )
 
 var (
-	CircleCi      = environment{}
-	configuration *models.Configuration
+	CircleCi = New()
 )
 
-type environment struct{}
+// Environment is the CircleCI environment
+type Environment struct {
+	cache utils.ConfigurationCache
+}
 
-func (e environment) GetBuildLink() string {
+// New creates a CircleCI environment with its own configuration cache
+func New() *Environment {
+	return &Environment{}
+}
+
+func (e *Environment) GetBuildLink() string {
 	return os.Getenv(buildUrlEnv)
 }
 
-func (e environment) GetStepLink() string {
+func (e *Environment) GetStepLink() string {
 	return ""
 }
 
-func (e environment) GetFileLink(filename string, ref string, commit string) string {
+func (e *Environment) GetFileLink(filename string, ref string, commit string) string {
 	return ""
 }
 
-func (e environment) GetFileLineLink(filename string, ref string, commit string, startLine int, endLine int) string {
+func (e *Environment) GetFileLineLink(filename string, ref string, commit string, startLine int, endLine int) string {
 	return ""
 }
 
-func (e environment) GetConfiguration() (*models.Configuration, error) {
-	if configuration == nil {
-		loadedConfiguration, err := loadConfiguration()
-		configuration = loadedConfiguration
-		return configuration, err
-	}
-	return configuration, nil
+func (e *Environment) GetConfiguration() (*models.Configuration, error) {
+	return e.cache.Get(e.load)
+}
+
+// Refresh loads the configuration again and replaces the cached configuration
+func (e *Environment) Refresh() (*models.Configuration, error) {
+	return e.cache.Refresh(e.load)
+}
+
+// Reset clears the cached configuration, it is loaded again on the next GetConfiguration
+func (e *Environment) Reset() {
+	e.cache.Reset()
+}
+
+func (e *Environment) load() (*models.Configuration, error) {
+	return loadConfiguration(envsource.OS)
+}
+
+func (e *Environment) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return loadConfiguration(src)
 }
 
-func loadConfiguration() (*models.Configuration, error) {
-	repoPath := os.Getenv(workingDirectoryEnv)
-	repoCloneUrl := os.Getenv(repositoryCloneURLEnv)
+func loadConfiguration(src envsource.EnvSource) (*models.Configuration, error) {
+	repoPath := src.Getenv(workingDirectoryEnv)
+	repoCloneUrl := src.Getenv(repositoryCloneURLEnv)
 	source, apiUrl := GetRepositorySource(repoCloneUrl)
 	scmUrl, org, _, repositoryFullName, err := utils.ParseDataFromCloneUrl(repoCloneUrl, apiUrl, source)
 	scmLink := scmUrl
@@ -87,22 +101,22 @@ func loadConfiguration() (*models.Configuration, error) {
 	}
 	scmId := utils.GenerateScmId(scmLink)
 
-	pullRequestUrl := os.Getenv(circlePullRequestUrl)
+	pullRequestUrl := src.Getenv(circlePullRequestUrl)
 	pullRequestId := path.Base(pullRequestUrl)
 	prNumber, _ := strconv.Atoi(pullRequestId)
 	if err != nil {
 		return nil, err
 	}
-	targetBranch, _ := github.GetPullRequestTargetBranch(org, os.Getenv(repositoryNameEnv), prNumber)
+	targetBranch, _ := github.GetPullRequestTargetBranchFrom(src, org, src.Getenv(repositoryNameEnv), prNumber)
 
-	configuration = &models.Configuration{
+	return &models.Configuration{
 		Url:       "https://app.circleci.com",
 		SCMApiUrl: apiUrl,
 		LocalPath: repoCloneUrl,
-		Branch:    os.Getenv(branchEnv),
-		CommitSha: os.Getenv(commitShaEnv),
+		Branch:    src.Getenv(branchEnv),
+		CommitSha: src.Getenv(commitShaEnv),
 		Repository: models.Repository{
-			Name:     os.Getenv(repositoryNameEnv),
+			Name:     src.Getenv(repositoryNameEnv),
 			FullName: repositoryFullName,
 			CloneUrl: scmLink,
 			Source:   source,
@@ -110,25 +124,25 @@ func loadConfiguration() (*models.Configuration, error) {
 		},
 		Pipeline: models.Pipeline{
 			Entity: models.Entity{
-				Id:   os.Getenv(workflowIdEnv),
-				Name: os.Getenv(workflowIdEnv),
+				Id:   src.Getenv(workflowIdEnv),
+				Name: src.Getenv(workflowIdEnv),
 			},
-			Path: getPipelinePath(repoPath),
+			Path: getPipelinePath(src, repoPath),
 		},
 		Job: models.Entity{
-			Id:   os.Getenv(jobIdEnv),
-			Name: os.Getenv(jobNameEnv),
+			Id:   src.Getenv(jobIdEnv),
+			Name: src.Getenv(jobNameEnv),
 		},
 		Builder: builder,
 		Organization: models.Entity{
 			Name: org,
 		},
 		Run: models.BuildRun{
-			BuildId:     os.Getenv(buildNumberEnv),
-			BuildNumber: os.Getenv(buildNumberEnv),
+			BuildId:     src.Getenv(buildNumberEnv),
+			BuildNumber: src.Getenv(buildNumberEnv),
 		},
 		Pusher: models.Pusher{
-			Username: utils.DetectPusher(),
+			Username: utils.DetectPusherFrom(src),
 		},
 		Runner: models.Runner{
 			OS:           runtime.GOOS,
@@ -137,7 +151,7 @@ func loadConfiguration() (*models.Configuration, error) {
 		PullRequest: models.PullRequest{
 			Id: pullRequestId,
 			SourceRef: models.Ref{
-				Branch: os.Getenv(branchEnv),
+				Branch: src.Getenv(branchEnv),
 			},
 			TargetRef: models.Ref{
 				Branch: targetBranch,
@@ -145,40 +159,47 @@ func loadConfiguration() (*models.Configuration, error) {
 		},
 		Environment:   enums.CircleCi,
 		ScmId:         scmId,
-		PipelinePaths: []string{getPipelinePath(repoPath)},
-	}
-
-	return configuration, nil
+		PipelinePaths: []string{getPipelinePath(src, repoPath)},
+	}, nil
 }
 
-func getPipelinePath(repoPath string) string {
+func getPipelinePath(src envsource.EnvSource, repoPath string) string {
 	path := fmt.Sprintf("%s/%s", repoPath, pipelinePath)
-	if _, err := os.Stat(path); err == nil {
+	if _, err := envsource.Stat(src, path); err == nil {
 		return path
 	}
 	return ""
 }
 
-func (e environment) Name() string {
+func (e *Environment) Name() string {
 	return "circleci"
 }
 
-func (e environment) IsCurrentEnvironment() bool {
-	circleCi := os.Getenv("CIRCLECI")
+func (e *Environment) IsCurrentEnvironment() bool {
+	circleCi := os.Getenv(circleCiEnv)
 	return circleCi == "true"
 }
 
-func GetRepositorySource(cloneUrl string) (enums.Source, string) {
-	switch {
-	case strings.Contains(cloneUrl, bitbucketHostname):
-		return enums.Bitbucket, bitbucketApiUrl
-	case strings.Contains(cloneUrl, githubHostname):
-		return enums.Github, githubApiUrl
-	case strings.Contains(cloneUrl, azureHostname):
-		return enums.Azure, azureApiUrl
-	case strings.Contains(cloneUrl, gitlabHostname):
-		return enums.Gitlab, gitlabApiUrl
+// DetectionVariables returns the variables used by IsCurrentEnvironment
+func (e *Environment) DetectionVariables() []string {
+	return []string{circleCiEnv}
+}
+
+// ExpectedFields returns the configuration fields the environment is expected to fill and the variables they are filled from
+func (e *Environment) ExpectedFields() []models.ExpectedField {
+	return []models.ExpectedField{
+		{Field: "repository.url", SourceEnv: repositoryCloneURLEnv, Severity: models.SeverityCritical},
+		{Field: "repository.cloneUrl", SourceEnv: repositoryCloneURLEnv, Severity: models.SeverityCritical},
+		{Field: "commitSha", SourceEnv: commitShaEnv, Severity: models.SeverityCritical},
+		{Field: "branch", SourceEnv: branchEnv, Severity: models.SeverityWarning},
+		{Field: "localPath", SourceEnv: workingDirectoryEnv, Severity: models.SeverityWarning},
+		{Field: "repository.name", SourceEnv: repositoryNameEnv, Severity: models.SeverityWarning},
+		{Field: "pipeline.id", SourceEnv: workflowIdEnv, Severity: models.SeverityWarning},
+		{Field: "job.name", SourceEnv: jobNameEnv, Severity: models.SeverityWarning},
+		{Field: "run.buildId", SourceEnv: buildNumberEnv, Severity: models.SeverityWarning},
 	}
+}
 
-	return enums.Unknown, ""
+func GetRepositorySource(cloneUrl string) (enums.Source, string) {
+	return utils.GetRepositorySource(cloneUrl)
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
 	"github.com/argonsecurity/go-environments/models"
 )
 
@@ -25,6 +26,19 @@ func (em *EnvironmentGithubMock) GetConfiguration() (*models.Configuration, erro
 	return mockGithubConfiguration, nil
 }
 
+func (em *EnvironmentGithubMock) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return em.GetConfiguration()
+}
+
+func (em *EnvironmentGithubMock) Refresh() (*models.Configuration, error) {
+	em.Reset()
+	return em.GetConfiguration()
+}
+
+func (em *EnvironmentGithubMock) Reset() {
+	mockGithubConfiguration = nil
+}
+
 func loadMockGithubConfiguration() error {
 	mockGithubConfiguration = &models.Configuration{
 		Url:       "https://github.com",
@@ -123,9 +123,8 @@ func Test_environment_IsCurrentEnvironment(t *testing.T) {
 	}
 }
 
-func prepareTest(t *testing.T, envsFilePath string) environment {
-	e := environment{}
-	configuration = nil
+func prepareTest(t *testing.T, envsFilePath string) *Environment {
+	e := New()
 	testRepoCleanup := testutils.PrepareTestGitRepository(testRepoPath, testRepoCloneUrl, testdataPath)
 	t.Cleanup(testRepoCleanup)
 	envCleanup := testutils.SetEnvsFromFile(envsFilePath)
@@ -2,13 +2,20 @@ package github
 
 import (
 	"context"
+
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
 	"github.com/google/go-github/v44/github"
 	"golang.org/x/oauth2"
-	"os"
 )
 
+const githubTokenEnv = "GITHUB_TOKEN"
+
 func GetPullRequestTargetBranch(owner, repo string, prNumber int) (string, error) {
-	client, err := newGithubClient(os.Getenv("GITHUB_TOKEN"))
+	return GetPullRequestTargetBranchFrom(envsource.OS, owner, repo, prNumber)
+}
+
+func GetPullRequestTargetBranchFrom(src envsource.EnvSource, owner, repo string, prNumber int) (string, error) {
+	client, err := newGithubClient(src.Getenv(githubTokenEnv))
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,242 @@
+package cloudbuild
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/utils"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/models"
+)
+
+const (
+	builder = "Google Cloud Build"
+
+	buildIdEnv   = "BUILD_ID"
+	projectIdEnv = "PROJECT_ID"
+	locationEnv  = "LOCATION"
+
+	repositoryNameEnv    = "REPO_NAME"
+	headRepositoryUrlEnv = "_HEAD_REPO_URL"
+	branchEnv            = "BRANCH_NAME"
+	commitShaEnv         = "COMMIT_SHA"
+
+	triggerNameEnv       = "TRIGGER_NAME"
+	triggerConfigPathEnv = "TRIGGER_BUILD_CONFIG_PATH"
+
+	headBranchEnv        = "_HEAD_BRANCH"
+	baseBranchEnv        = "_BASE_BRANCH"
+	pullRequestNumberEnv = "_PR_NUMBER"
+
+	consoleUrl = "https://console.cloud.google.com/cloud-build"
+)
+
+var (
+	// CloudBuild environment
+	CloudBuild = New()
+
+	// workspacePath is the working directory of the build steps, where the source is checked out
+	workspacePath = "/workspace"
+
+	pipelineFiles = []string{"cloudbuild.yaml", "cloudbuild.json"}
+
+	// Cloud Build ids are uuids, unlike the numeric BUILD_ID of Jenkins
+	buildIdRegexp = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+)
+
+// Environment is the Google Cloud Build environment
+type Environment struct {
+	cache utils.ConfigurationCache
+}
+
+// New creates a Google Cloud Build environment with its own configuration cache
+func New() *Environment {
+	return &Environment{}
+}
+
+func (e *Environment) GetConfiguration() (*models.Configuration, error) {
+	return e.cache.Get(e.load)
+}
+
+// Refresh loads the configuration again and replaces the cached configuration
+func (e *Environment) Refresh() (*models.Configuration, error) {
+	return e.cache.Refresh(e.load)
+}
+
+// Reset clears the cached configuration, it is loaded again on the next GetConfiguration
+func (e *Environment) Reset() {
+	e.cache.Reset()
+}
+
+func (e *Environment) load() (*models.Configuration, error) {
+	return loadConfiguration(envsource.OS)
+}
+
+func (e *Environment) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return loadConfiguration(src)
+}
+
+func loadConfiguration(src envsource.EnvSource) (*models.Configuration, error) {
+	var warnings []models.Warning
+	cloneUrl, err := envsource.GitClient(src).GetGitRemoteURL(envsource.Path(src, workspacePath))
+	if err != nil {
+		// builds of uploaded sources have no git metadata, pull request triggers still set the head repository url
+		cloneUrl = src.Getenv(headRepositoryUrlEnv)
+		if cloneUrl == "" {
+			return nil, err
+		}
+		warnings = append(warnings, models.NewWarning(models.GitRemoteUrlWarning, err, "failed to get the git remote url of %s, using %s", workspacePath, cloneUrl))
+	}
+	cloneUrl = utils.StripCredentialsFromUrl(cloneUrl)
+
+	source, apiUrl := utils.GetRepositorySource(cloneUrl)
+	repoUrl, org, repoName, repoFullName, err := utils.ParseDataFromCloneUrl(cloneUrl, apiUrl, source)
+	if err != nil {
+		return nil, err
+	}
+	if name := src.Getenv(repositoryNameEnv); name != "" {
+		repoName = name
+	}
+
+	commit := src.Getenv(commitShaEnv)
+	branch := src.Getenv(branchEnv)
+	pullRequest := models.PullRequest{}
+	if prNumber := src.Getenv(pullRequestNumberEnv); prNumber != "" {
+		branch = src.Getenv(headBranchEnv)
+		pullRequest = models.PullRequest{
+			Id: prNumber,
+			SourceRef: models.Ref{
+				Branch: branch,
+				Sha:    commit,
+			},
+			TargetRef: models.Ref{
+				Branch: src.Getenv(baseBranchEnv),
+			},
+		}
+	}
+
+	return &models.Configuration{
+		Url:       consoleUrl,
+		SCMApiUrl: apiUrl,
+		LocalPath: workspacePath,
+		CommitSha: commit,
+		Branch:    branch,
+		ProjectId: src.Getenv(projectIdEnv),
+		Repository: models.Repository{
+			Name:     repoName,
+			FullName: repoFullName,
+			Url:      repoUrl,
+			CloneUrl: cloneUrl,
+			Source:   source,
+		},
+		Organization: models.Entity{
+			Name: org,
+		},
+		Pipeline: models.Pipeline{
+			Entity: models.Entity{
+				Id:   src.Getenv(triggerNameEnv),
+				Name: src.Getenv(triggerNameEnv),
+			},
+			Path: getPipelinePath(src, workspacePath),
+		},
+		Run: models.BuildRun{
+			BuildId: src.Getenv(buildIdEnv),
+		},
+		Runner: models.Runner{
+			OS:           runtime.GOOS,
+			Architecture: runtime.GOARCH,
+		},
+		PullRequest: pullRequest,
+		Builder:     builder,
+		Pusher: models.Pusher{
+			Username: utils.DetectPusherFrom(src),
+		},
+		PipelinePaths: getPipelinePaths(src, workspacePath),
+		Environment:   enums.CloudBuild,
+		ScmId:         utils.GenerateScmId(cloneUrl),
+		Warnings:      warnings,
+	}, nil
+}
+
+// getPipelinePath returns the build config of the trigger, or the build config found in the workspace,
+// or an empty string when there is none
+func getPipelinePath(src envsource.EnvSource, rootDir string) string {
+	if path := src.Getenv(triggerConfigPathEnv); path != "" {
+		return path
+	}
+	for _, file := range pipelineFiles {
+		if _, err := envsource.Stat(src, filepath.Join(rootDir, file)); err == nil {
+			return file
+		}
+	}
+	return ""
+}
+
+func (e *Environment) GetStepLink() string {
+	return e.GetBuildLink()
+}
+
+func (e *Environment) GetBuildLink() string {
+	buildsUrl := fmt.Sprintf("%s/builds", consoleUrl)
+	if location := os.Getenv(locationEnv); location != "" && location != "global" {
+		buildsUrl = fmt.Sprintf("%s;region=%s", buildsUrl, location)
+	}
+	return fmt.Sprintf("%s/%s?project=%s", buildsUrl, os.Getenv(buildIdEnv), os.Getenv(projectIdEnv))
+}
+
+func (e *Environment) GetFileLink(filename string, branch string, commit string) string {
+	return ""
+}
+
+func (e *Environment) GetFileLineLink(filename string, branch string, commit string, startLine int, endLine int) string {
+	return ""
+}
+
+// IsCurrentEnvironment checks for a project id and a build id in the format of Cloud Build,
+// so Jenkins builds, which also set BUILD_ID, are not detected as Cloud Build
+func (e *Environment) IsCurrentEnvironment() bool {
+	if _, isExist := os.LookupEnv(projectIdEnv); !isExist {
+		return false
+	}
+	return buildIdRegexp.MatchString(os.Getenv(buildIdEnv))
+}
+
+// DetectionVariables returns the variables used by IsCurrentEnvironment
+func (e *Environment) DetectionVariables() []string {
+	return []string{projectIdEnv, buildIdEnv}
+}
+
+// ExpectedFields returns the configuration fields the environment is expected to fill and the variables they are filled from
+func (e *Environment) ExpectedFields() []models.ExpectedField {
+	return []models.ExpectedField{
+		{Field: "repository.url", SourceEnv: headRepositoryUrlEnv, Severity: models.SeverityCritical},
+		{Field: "repository.cloneUrl", SourceEnv: headRepositoryUrlEnv, Severity: models.SeverityCritical},
+		{Field: "commitSha", SourceEnv: commitShaEnv, Severity: models.SeverityCritical},
+		{Field: "branch", SourceEnv: branchEnv, Severity: models.SeverityWarning},
+		{Field: "projectId", SourceEnv: projectIdEnv, Severity: models.SeverityWarning},
+		{Field: "repository.name", SourceEnv: repositoryNameEnv, Severity: models.SeverityWarning},
+		{Field: "pipeline.name", SourceEnv: triggerNameEnv, Severity: models.SeverityWarning},
+		{Field: "run.buildId", SourceEnv: buildIdEnv, Severity: models.SeverityWarning},
+	}
+}
+
+func (e *Environment) Name() string {
+	return "cloudbuild"
+}
+
+func getPipelinePaths(src envsource.EnvSource, rootDir string) []string {
+	paths := make([]string, 0)
+
+	for _, file := range pipelineFiles {
+		path := filepath.Join(rootDir, file)
+		if _, err := envsource.Stat(src, path); err == nil {
+			paths = append(paths, path)
+		}
+	}
+
+	return paths
+}
@@ -0,0 +1,105 @@
+package cloudbuild
+
+import (
+	"fmt"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/models"
+)
+
+var (
+	MockOrgName   = "test-org"
+	MockRepoName  = "test-repo"
+	MockProjectId = "test-project"
+	MockBuildId   = "5b2d8f1e-3c4a-4e6b-9d7f-0a1b2c3d4e5f"
+)
+
+var mockConfiguration *models.Configuration
+
+type EnvironmentMock struct{}
+
+func (em *EnvironmentMock) GetConfiguration() (*models.Configuration, error) {
+	if mockConfiguration == nil {
+		if err := loadMockConfiguration(); err != nil {
+			return nil, err
+		}
+	}
+	return mockConfiguration, nil
+}
+
+func (em *EnvironmentMock) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return em.GetConfiguration()
+}
+
+func (em *EnvironmentMock) Refresh() (*models.Configuration, error) {
+	em.Reset()
+	return em.GetConfiguration()
+}
+
+func (em *EnvironmentMock) Reset() {
+	mockConfiguration = nil
+}
+
+func loadMockConfiguration() error {
+	mockConfiguration = &models.Configuration{
+		Url:       "https://console.cloud.google.com/cloud-build",
+		SCMApiUrl: "https://api.github.com",
+		LocalPath: "/workspace",
+		CommitSha: "3s32e4s818c6d1s5a0f585sf73112673a9bfcfc7",
+		Branch:    "main",
+		ProjectId: MockProjectId,
+		Run: models.BuildRun{
+			BuildId: MockBuildId,
+		},
+		Pipeline: models.Pipeline{
+			Entity: models.Entity{
+				Id:   "test-trigger",
+				Name: "test-trigger",
+			},
+			Path: "cloudbuild.yaml",
+		},
+		Runner: models.Runner{
+			OS: "linux",
+		},
+		Repository: models.Repository{
+			Name:     MockRepoName,
+			FullName: fmt.Sprintf("%s/%s", MockOrgName, MockRepoName),
+			Url:      fmt.Sprintf("https://github.com/%s/%s", MockOrgName, MockRepoName),
+			CloneUrl: fmt.Sprintf("https://github.com/%s/%s.git", MockOrgName, MockRepoName),
+			Source:   enums.Github,
+		},
+		Builder: "Google Cloud Build",
+		Organization: models.Entity{
+			Name: MockOrgName,
+		},
+		PipelinePaths: []string{"/workspace/cloudbuild.yaml"},
+		Environment:   enums.CloudBuild,
+	}
+
+	return nil
+}
+
+func (em *EnvironmentMock) GetBuildLink() string {
+	return fmt.Sprintf("https://console.cloud.google.com/cloud-build/builds/%s?project=%s", MockBuildId, MockProjectId)
+}
+
+func (em *EnvironmentMock) GetStepLink() string {
+	return em.GetBuildLink()
+}
+
+func (em *EnvironmentMock) GetFileLink(filename string, branch string, commit string) string {
+	return ""
+}
+
+func (em *EnvironmentMock) GetFileLineLink(filename string, branch string, commit string, startLine int, endLine int) string {
+	return ""
+}
+
+func (em *EnvironmentMock) IsCurrentEnvironment() bool {
+	return true
+}
+
+func (em *EnvironmentMock) Name() string {
+	return "cloudbuild"
+}
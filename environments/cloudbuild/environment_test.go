@@ -0,0 +1,234 @@
+package cloudbuild
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/testutils"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/models"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	cloudbuildMainEnvsFilePath       = "testdata/cloudbuild-github-main-env.json"
+	cloudbuildPrEnvsFilePath         = "testdata/cloudbuild-github-pr-env.json"
+	jenkinsWithProjectIdEnvsFilePath = "testdata/jenkins-with-project-id-env.json"
+	testRepoPath                     = "/tmp/cloudbuild/repo"
+	testRepoUrl                      = "https://github.com/test-organization/test-repo"
+	testRepoCloneUrl                 = fmt.Sprintf("%s%s", testRepoUrl, ".git")
+	testdataPath                     = "../cloudbuild/testdata/repo"
+)
+
+func Test_environment_GetConfiguration(t *testing.T) {
+	tests := []struct {
+		name         string
+		envsFilePath string
+		want         *models.Configuration
+		wantErr      bool
+	}{
+		{
+			name:         "Cloud Build main configuration",
+			envsFilePath: cloudbuildMainEnvsFilePath,
+			want: &models.Configuration{
+				Url:       "https://console.cloud.google.com/cloud-build",
+				SCMApiUrl: "https://api.github.com",
+				LocalPath: testRepoPath,
+				CommitSha: "kcy8v2oazy4acuo1475rgtzg0nh403p23vw812lv",
+				Branch:    "main",
+				ProjectId: "test-project",
+				Repository: models.Repository{
+					Name:     "test-repo",
+					FullName: "test-organization/test-repo",
+					Url:      testRepoUrl,
+					CloneUrl: testRepoCloneUrl,
+					Source:   enums.Github,
+				},
+				Organization: models.Entity{
+					Name: "test-organization",
+				},
+				Pipeline: models.Pipeline{
+					Entity: models.Entity{
+						Id:   "test-trigger",
+						Name: "test-trigger",
+					},
+					Path: "cloudbuild.yaml",
+				},
+				Run: models.BuildRun{
+					BuildId: "5b2d8f1e-3c4a-4e6b-9d7f-0a1b2c3d4e5f",
+				},
+				Runner: models.Runner{
+					OS:           runtime.GOOS,
+					Architecture: runtime.GOARCH,
+				},
+				Builder:       "Google Cloud Build",
+				PipelinePaths: []string{"/tmp/cloudbuild/repo/cloudbuild.yaml", "/tmp/cloudbuild/repo/cloudbuild.json"},
+				Environment:   enums.CloudBuild,
+				ScmId:         "8891c0db39f3064732cc1b4ac02c9b9f",
+			},
+		},
+		{
+			name:         "Cloud Build pull request configuration",
+			envsFilePath: cloudbuildPrEnvsFilePath,
+			want: &models.Configuration{
+				Url:       "https://console.cloud.google.com/cloud-build",
+				SCMApiUrl: "https://api.github.com",
+				LocalPath: testRepoPath,
+				CommitSha: "y2nc0ns6qk8qs4dfbsvnjrf2o8dvmrfrthmx9nbl",
+				Branch:    "feature/cloudbuild",
+				ProjectId: "test-project",
+				Repository: models.Repository{
+					Name:     "test-repo",
+					FullName: "test-organization/test-repo",
+					Url:      testRepoUrl,
+					CloneUrl: testRepoCloneUrl,
+					Source:   enums.Github,
+				},
+				Organization: models.Entity{
+					Name: "test-organization",
+				},
+				Pipeline: models.Pipeline{
+					Entity: models.Entity{
+						Id:   "test-pr-trigger",
+						Name: "test-pr-trigger",
+					},
+					Path: "cloudbuild.yaml",
+				},
+				Run: models.BuildRun{
+					BuildId: "9e8d7c6b-5a4f-4e3d-8c2b-1a0f9e8d7c6b",
+				},
+				Runner: models.Runner{
+					OS:           runtime.GOOS,
+					Architecture: runtime.GOARCH,
+				},
+				PullRequest: models.PullRequest{
+					Id: "7",
+					SourceRef: models.Ref{
+						Branch: "feature/cloudbuild",
+						Sha:    "y2nc0ns6qk8qs4dfbsvnjrf2o8dvmrfrthmx9nbl",
+					},
+					TargetRef: models.Ref{
+						Branch: "main",
+					},
+				},
+				Builder:       "Google Cloud Build",
+				PipelinePaths: []string{"/tmp/cloudbuild/repo/cloudbuild.yaml", "/tmp/cloudbuild/repo/cloudbuild.json"},
+				Environment:   enums.CloudBuild,
+				ScmId:         "8891c0db39f3064732cc1b4ac02c9b9f",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := prepareTest(t, tt.envsFilePath)
+			got, err := e.GetConfiguration()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("environment.GetConfiguration() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_environment_GetConfigurationWithoutGitRepository(t *testing.T) {
+	e := prepareTest(t, cloudbuildPrEnvsFilePath)
+	workspacePath = "/tmp/cloudbuild/missing"
+
+	got, err := e.GetConfiguration()
+	assert.NoError(t, err)
+	assert.Equal(t, "https://github.com/test-organization/test-repo", got.Repository.CloneUrl)
+	assert.Equal(t, testRepoUrl, got.Repository.Url)
+	assert.Equal(t, "8891c0db39f3064732cc1b4ac02c9b9f", got.ScmId)
+	if assert.Len(t, got.Warnings, 1) {
+		assert.Equal(t, models.GitRemoteUrlWarning, got.Warnings[0].Code)
+	}
+}
+
+func Test_environment_GetBuildLink(t *testing.T) {
+	tests := []struct {
+		name         string
+		envsFilePath string
+		want         string
+	}{
+		{
+			name:         "Global Cloud Build build",
+			envsFilePath: cloudbuildMainEnvsFilePath,
+			want:         "https://console.cloud.google.com/cloud-build/builds/5b2d8f1e-3c4a-4e6b-9d7f-0a1b2c3d4e5f?project=test-project",
+		},
+		{
+			name:         "Regional Cloud Build build",
+			envsFilePath: cloudbuildPrEnvsFilePath,
+			want:         "https://console.cloud.google.com/cloud-build/builds;region=us-central1/9e8d7c6b-5a4f-4e3d-8c2b-1a0f9e8d7c6b?project=test-project",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := prepareTest(t, tt.envsFilePath)
+			if got := e.GetBuildLink(); got != tt.want {
+				t.Errorf("environment.GetBuildLink() = %v, want %v", got, tt.want)
+			}
+			if got := e.GetStepLink(); got != tt.want {
+				t.Errorf("environment.GetStepLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_environment_IsCurrentEnvironment(t *testing.T) {
+	tests := []struct {
+		name         string
+		envsFilePath string
+		want         bool
+	}{
+		{
+			name:         "Cloud Build environment",
+			envsFilePath: cloudbuildMainEnvsFilePath,
+			want:         true,
+		},
+		{
+			name:         "Jenkins environment with a project id",
+			envsFilePath: jenkinsWithProjectIdEnvsFilePath,
+			want:         false,
+		},
+		{
+			name:         "Not Cloud Build environment",
+			envsFilePath: "",
+			want:         false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := prepareTest(t, tt.envsFilePath)
+			if got := e.IsCurrentEnvironment(); got != tt.want {
+				t.Errorf("environment.IsCurrentEnvironment() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getPipelinePath(t *testing.T) {
+	rootDir := t.TempDir()
+	assert.Equal(t, "", getPipelinePath(envsource.New(nil), rootDir))
+	assert.Equal(t, "ci/cloudbuild.yaml", getPipelinePath(envsource.New(map[string]string{triggerConfigPathEnv: "ci/cloudbuild.yaml"}), rootDir))
+
+	assert.NoError(t, os.WriteFile(filepath.Join(rootDir, "cloudbuild.json"), []byte("{}"), 0644))
+	assert.Equal(t, "cloudbuild.json", getPipelinePath(envsource.New(nil), rootDir))
+}
+
+func prepareTest(t *testing.T, envsFilePath string) *Environment {
+	e := New()
+	testRepoCleanup := testutils.PrepareTestGitRepository(testRepoPath, testRepoCloneUrl, testdataPath)
+	t.Cleanup(testRepoCleanup)
+	envCleanup := testutils.SetEnvsFromFile(envsFilePath)
+	t.Cleanup(envCleanup)
+
+	originalWorkspacePath := workspacePath
+	workspacePath = testRepoPath
+	t.Cleanup(func() { workspacePath = originalWorkspacePath })
+	return e
+}
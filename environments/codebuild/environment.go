@@ -0,0 +1,331 @@
+package codebuild
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/utils"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/models"
+)
+
+const (
+	builder = "AWS CodeBuild"
+
+	buildArnEnv    = "CODEBUILD_BUILD_ARN"
+	buildIdEnv     = "CODEBUILD_BUILD_ID"
+	buildNumberEnv = "CODEBUILD_BUILD_NUMBER"
+	buildImageEnv  = "CODEBUILD_BUILD_IMAGE"
+	batchBuildEnv  = "CODEBUILD_BATCH_BUILD_IDENTIFIER"
+	regionEnv      = "AWS_REGION"
+
+	repositoryCloneUrlEnv = "CODEBUILD_SOURCE_REPO_URL"
+	repositoryPathEnv     = "CODEBUILD_SRC_DIR"
+
+	sourceVersionEnv         = "CODEBUILD_SOURCE_VERSION"
+	resolvedSourceVersionEnv = "CODEBUILD_RESOLVED_SOURCE_VERSION"
+
+	webhookTriggerEnv    = "CODEBUILD_WEBHOOK_TRIGGER"
+	webhookHeadRefEnv    = "CODEBUILD_WEBHOOK_HEAD_REF"
+	webhookBaseRefEnv    = "CODEBUILD_WEBHOOK_BASE_REF"
+	webhookPrevCommitEnv = "CODEBUILD_WEBHOOK_PREV_COMMIT"
+
+	buildResourcePrefix = "build/"
+	pullRequestPrefix   = "pr/"
+	branchRefPrefix     = "refs/heads/"
+
+	consoleUrlFormat = "https://%s.console.aws.amazon.com/codesuite/codebuild"
+)
+
+var (
+	// CodeBuild environment
+	CodeBuild = New()
+
+	pipelineFiles = []string{"buildspec.yml", "buildspec.yaml"}
+
+	commitShaRegexp = regexp.MustCompile(`^[0-9a-f]{40}$`)
+)
+
+// Environment is the AWS CodeBuild environment
+type Environment struct {
+	cache utils.ConfigurationCache
+}
+
+// buildArn is the parsed arn of a build, i.e. arn:aws:codebuild:us-east-1:123456789012:build/project:8745a7a9-c340-456a-9166-edf953571bec
+type buildArn struct {
+	region    string
+	accountId string
+	project   string
+	buildUuid string
+}
+
+// New creates an AWS CodeBuild environment with its own configuration cache
+func New() *Environment {
+	return &Environment{}
+}
+
+func (e *Environment) GetConfiguration() (*models.Configuration, error) {
+	return e.cache.Get(e.load)
+}
+
+// Refresh loads the configuration again and replaces the cached configuration
+func (e *Environment) Refresh() (*models.Configuration, error) {
+	return e.cache.Refresh(e.load)
+}
+
+// Reset clears the cached configuration, it is loaded again on the next GetConfiguration
+func (e *Environment) Reset() {
+	e.cache.Reset()
+}
+
+func (e *Environment) load() (*models.Configuration, error) {
+	return loadConfiguration(envsource.OS)
+}
+
+func (e *Environment) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return loadConfiguration(src)
+}
+
+func loadConfiguration(src envsource.EnvSource) (*models.Configuration, error) {
+	arn, err := parseBuildArn(src.Getenv(buildArnEnv))
+	if err != nil {
+		return nil, err
+	}
+
+	var warnings []models.Warning
+	repoPath := src.Getenv(repositoryPathEnv)
+	cloneUrl := src.Getenv(repositoryCloneUrlEnv)
+	if cloneUrl == "" {
+		// sources that are not connected with a webhook, i.e. S3, do not set the repository url
+		if cloneUrl, err = envsource.GitClient(src).GetGitRemoteURL(envsource.Path(src, repoPath)); err != nil {
+			return nil, err
+		}
+	}
+	cloneUrl = utils.StripCredentialsFromUrl(cloneUrl)
+
+	source, apiUrl := utils.GetRepositorySource(cloneUrl)
+	repoUrl, org, repoName, repoFullName, err := utils.ParseDataFromCloneUrl(cloneUrl, apiUrl, source)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceVersion := src.Getenv(sourceVersionEnv)
+	commit := src.Getenv(resolvedSourceVersionEnv)
+	if commit == "" && commitShaRegexp.MatchString(sourceVersion) {
+		commit = sourceVersion
+	}
+
+	branch := getBranch(src)
+	if branch == "" && commit != "" {
+		var branchWarnings []models.Warning
+		branch, branchWarnings, _ = envsource.GetGitBranch(src, repoPath, commit)
+		warnings = append(warnings, branchWarnings...)
+	}
+
+	pullRequest := models.PullRequest{}
+	if prNumber := getPullRequestNumber(src); prNumber != "" {
+		pullRequest = models.PullRequest{
+			Id: prNumber,
+			SourceRef: models.Ref{
+				Branch: branch,
+				Sha:    commit,
+			},
+			TargetRef: models.Ref{
+				Branch: strings.TrimPrefix(src.Getenv(webhookBaseRefEnv), branchRefPrefix),
+			},
+		}
+	}
+
+	region := getRegion(arn.region, src.Getenv(regionEnv))
+	return &models.Configuration{
+		Url:             fmt.Sprintf("%s/home?region=%s", getConsoleUrl(region), region),
+		SCMApiUrl:       apiUrl,
+		LocalPath:       repoPath,
+		CommitSha:       commit,
+		BeforeCommitSha: src.Getenv(webhookPrevCommitEnv),
+		Branch:          branch,
+		Repository: models.Repository{
+			Name:     repoName,
+			FullName: repoFullName,
+			Url:      repoUrl,
+			CloneUrl: cloneUrl,
+			Source:   source,
+		},
+		Organization: models.Entity{
+			Name: org,
+		},
+		Pipeline: models.Pipeline{
+			Entity: models.Entity{
+				Id:   arn.project,
+				Name: arn.project,
+			},
+			Path: getPipelinePath(src, repoPath),
+		},
+		Job: models.Entity{
+			Id:   src.Getenv(batchBuildEnv),
+			Name: src.Getenv(batchBuildEnv),
+		},
+		Run: models.BuildRun{
+			BuildId:     src.Getenv(buildIdEnv),
+			BuildNumber: src.Getenv(buildNumberEnv),
+		},
+		Runner: models.Runner{
+			Name:         src.Getenv(buildImageEnv),
+			OS:           runtime.GOOS,
+			Architecture: runtime.GOARCH,
+		},
+		PullRequest: pullRequest,
+		Builder:     builder,
+		Pusher: models.Pusher{
+			Username: utils.DetectPusherFrom(src),
+		},
+		PipelinePaths: getPipelinePaths(src, repoPath),
+		Environment:   enums.CodeBuild,
+		ScmId:         utils.GenerateScmId(cloneUrl),
+		Warnings:      warnings,
+	}, nil
+}
+
+func parseBuildArn(arn string) (buildArn, error) {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) != 6 || parts[0] != "arn" || parts[2] != "codebuild" {
+		return buildArn{}, fmt.Errorf("%s is not a build arn", arn)
+	}
+
+	if !strings.HasPrefix(parts[5], buildResourcePrefix) {
+		return buildArn{}, fmt.Errorf("%s is not a build arn", arn)
+	}
+	project, buildUuid, _ := strings.Cut(strings.TrimPrefix(parts[5], buildResourcePrefix), ":")
+	return buildArn{
+		region:    parts[3],
+		accountId: parts[4],
+		project:   project,
+		buildUuid: buildUuid,
+	}, nil
+}
+
+// getBranch returns the branch of the build from the webhook head ref,
+// or from the source version when the build was started with a branch name
+func getBranch(src envsource.EnvSource) string {
+	if headRef := src.Getenv(webhookHeadRefEnv); headRef != "" {
+		return strings.TrimPrefix(headRef, branchRefPrefix)
+	}
+
+	sourceVersion := src.Getenv(sourceVersionEnv)
+	if sourceVersion == "" || commitShaRegexp.MatchString(sourceVersion) || strings.HasPrefix(sourceVersion, pullRequestPrefix) {
+		return ""
+	}
+	return strings.TrimPrefix(sourceVersion, branchRefPrefix)
+}
+
+// getPullRequestNumber returns the number of the pull request from the webhook trigger or the source version, i.e. pr/5
+func getPullRequestNumber(src envsource.EnvSource) string {
+	for _, version := range []string{src.Getenv(webhookTriggerEnv), src.Getenv(sourceVersionEnv)} {
+		if strings.HasPrefix(version, pullRequestPrefix) {
+			return strings.TrimPrefix(version, pullRequestPrefix)
+		}
+	}
+	return ""
+}
+
+func getRegion(arnRegion string, regionEnvValue string) string {
+	if arnRegion != "" {
+		return arnRegion
+	}
+	return regionEnvValue
+}
+
+func getConsoleUrl(region string) string {
+	return fmt.Sprintf(consoleUrlFormat, region)
+}
+
+// getConsoleBuildUrl returns the link of the build in the CodeBuild console of its region, and the region
+func getConsoleBuildUrl() (string, string) {
+	arn, err := parseBuildArn(os.Getenv(buildArnEnv))
+	if err != nil {
+		return "", ""
+	}
+	region := getRegion(arn.region, os.Getenv(regionEnv))
+	// the console escapes the colon between the project and the build uuid
+	return fmt.Sprintf("%s/%s/projects/%s/build/%s%%3A%s", getConsoleUrl(region), arn.accountId, arn.project, arn.project, arn.buildUuid), region
+}
+
+func (e *Environment) GetStepLink() string {
+	buildUrl, region := getConsoleBuildUrl()
+	if buildUrl == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/log?region=%s", buildUrl, region)
+}
+
+func (e *Environment) GetBuildLink() string {
+	buildUrl, region := getConsoleBuildUrl()
+	if buildUrl == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/?region=%s", buildUrl, region)
+}
+
+func (e *Environment) GetFileLink(filename string, branch string, commit string) string {
+	return ""
+}
+
+func (e *Environment) GetFileLineLink(filename string, branch string, commit string, startLine int, endLine int) string {
+	return ""
+}
+
+func (e *Environment) IsCurrentEnvironment() bool {
+	_, isExist := os.LookupEnv(buildArnEnv)
+	return isExist
+}
+
+// DetectionVariables returns the variables used by IsCurrentEnvironment
+func (e *Environment) DetectionVariables() []string {
+	return []string{buildArnEnv}
+}
+
+// ExpectedFields returns the configuration fields the environment is expected to fill and the variables they are filled from
+func (e *Environment) ExpectedFields() []models.ExpectedField {
+	return []models.ExpectedField{
+		{Field: "repository.url", SourceEnv: repositoryCloneUrlEnv, Severity: models.SeverityCritical},
+		{Field: "repository.cloneUrl", SourceEnv: repositoryCloneUrlEnv, Severity: models.SeverityCritical},
+		{Field: "commitSha", SourceEnv: resolvedSourceVersionEnv, Severity: models.SeverityCritical},
+		{Field: "branch", SourceEnv: webhookHeadRefEnv, Severity: models.SeverityWarning},
+		{Field: "localPath", SourceEnv: repositoryPathEnv, Severity: models.SeverityWarning},
+		{Field: "pipeline.name", SourceEnv: buildArnEnv, Severity: models.SeverityWarning},
+		{Field: "run.buildId", SourceEnv: buildIdEnv, Severity: models.SeverityWarning},
+		{Field: "run.buildNumber", SourceEnv: buildNumberEnv, Severity: models.SeverityWarning},
+	}
+}
+
+func (e *Environment) Name() string {
+	return "codebuild"
+}
+
+func getPipelinePaths(src envsource.EnvSource, rootDir string) []string {
+	paths := make([]string, 0)
+
+	for _, file := range pipelineFiles {
+		path := filepath.Join(rootDir, file)
+		if _, err := envsource.Stat(src, path); err == nil {
+			paths = append(paths, path)
+		}
+	}
+
+	return paths
+}
+
+// getPipelinePath returns the path of the buildspec relative to the repository, or an empty string when the repository has none
+func getPipelinePath(src envsource.EnvSource, rootDir string) string {
+	for _, file := range pipelineFiles {
+		if _, err := envsource.Stat(src, filepath.Join(rootDir, file)); err == nil {
+			return file
+		}
+	}
+	return ""
+}
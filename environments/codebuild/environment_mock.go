@@ -0,0 +1,112 @@
+package codebuild
+
+import (
+	"fmt"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/models"
+)
+
+var (
+	MockOrgName     = "test-org"
+	MockRepoName    = "test-repo"
+	MockProjectName = "test-project"
+	MockAccountId   = "123456789012"
+	MockRegion      = "us-east-1"
+	MockBuildUuid   = "8745a7a9-c340-456a-9166-edf953571bec"
+)
+
+var mockConfiguration *models.Configuration
+
+type EnvironmentMock struct{}
+
+func (em *EnvironmentMock) GetConfiguration() (*models.Configuration, error) {
+	if mockConfiguration == nil {
+		if err := loadMockConfiguration(); err != nil {
+			return nil, err
+		}
+	}
+	return mockConfiguration, nil
+}
+
+func (em *EnvironmentMock) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return em.GetConfiguration()
+}
+
+func (em *EnvironmentMock) Refresh() (*models.Configuration, error) {
+	em.Reset()
+	return em.GetConfiguration()
+}
+
+func (em *EnvironmentMock) Reset() {
+	mockConfiguration = nil
+}
+
+func loadMockConfiguration() error {
+	mockConfiguration = &models.Configuration{
+		Url:       fmt.Sprintf("https://%s.console.aws.amazon.com/codesuite/codebuild/home?region=%s", MockRegion, MockRegion),
+		SCMApiUrl: "https://api.github.com",
+		LocalPath: "/codebuild/output/src123456789/src/github.com/test-org/test-repo",
+		CommitSha: "3s32e4s818c6d1s5a0f585sf73112673a9bfcfc7",
+		Branch:    "main",
+		Run: models.BuildRun{
+			BuildId:     fmt.Sprintf("%s:%s", MockProjectName, MockBuildUuid),
+			BuildNumber: "17",
+		},
+		Pipeline: models.Pipeline{
+			Entity: models.Entity{
+				Id:   MockProjectName,
+				Name: MockProjectName,
+			},
+			Path: "buildspec.yml",
+		},
+		Runner: models.Runner{
+			Name: "aws/codebuild/standard:7.0",
+			OS:   "linux",
+		},
+		Repository: models.Repository{
+			Name:     MockRepoName,
+			FullName: fmt.Sprintf("%s/%s", MockOrgName, MockRepoName),
+			Url:      fmt.Sprintf("https://github.com/%s/%s", MockOrgName, MockRepoName),
+			CloneUrl: fmt.Sprintf("https://github.com/%s/%s.git", MockOrgName, MockRepoName),
+			Source:   enums.Github,
+		},
+		Builder: "AWS CodeBuild",
+		Organization: models.Entity{
+			Name: MockOrgName,
+		},
+		PipelinePaths: []string{"buildspec.yml"},
+		Environment:   enums.CodeBuild,
+	}
+
+	return nil
+}
+
+func getMockBuildUrl() string {
+	return fmt.Sprintf("https://%s.console.aws.amazon.com/codesuite/codebuild/%s/projects/%s/build/%s%%3A%s", MockRegion, MockAccountId, MockProjectName, MockProjectName, MockBuildUuid)
+}
+
+func (em *EnvironmentMock) GetBuildLink() string {
+	return fmt.Sprintf("%s/?region=%s", getMockBuildUrl(), MockRegion)
+}
+
+func (em *EnvironmentMock) GetStepLink() string {
+	return fmt.Sprintf("%s/log?region=%s", getMockBuildUrl(), MockRegion)
+}
+
+func (em *EnvironmentMock) GetFileLink(filename string, branch string, commit string) string {
+	return ""
+}
+
+func (em *EnvironmentMock) GetFileLineLink(filename string, branch string, commit string, startLine int, endLine int) string {
+	return ""
+}
+
+func (em *EnvironmentMock) IsCurrentEnvironment() bool {
+	return true
+}
+
+func (em *EnvironmentMock) Name() string {
+	return "codebuild"
+}
@@ -0,0 +1,359 @@
+package codebuild
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/testutils"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/models"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	codebuildPushEnvsFilePath       = "testdata/codebuild-github-push-env.json"
+	codebuildPrEnvsFilePath         = "testdata/codebuild-github-pr-env.json"
+	codebuildManualEnvsFilePath     = "testdata/codebuild-manual-env.json"
+	codebuildCodeCommitEnvsFilePath = "testdata/codebuild-codecommit-env.json"
+	testRepoPath                    = "/tmp/codebuild/repo"
+	testRepoUrl                     = "https://github.com/test-organization/test-repo"
+	testRepoCloneUrl                = fmt.Sprintf("%s%s", testRepoUrl, ".git")
+	testdataPath                    = "../codebuild/testdata/repo"
+)
+
+func Test_environment_GetConfiguration(t *testing.T) {
+	tests := []struct {
+		name         string
+		envsFilePath string
+		want         *models.Configuration
+		wantErr      bool
+	}{
+		{
+			name:         "CodeBuild push webhook configuration",
+			envsFilePath: codebuildPushEnvsFilePath,
+			want: &models.Configuration{
+				Url:             "https://us-east-1.console.aws.amazon.com/codesuite/codebuild/home?region=us-east-1",
+				SCMApiUrl:       "https://api.github.com",
+				LocalPath:       testRepoPath,
+				CommitSha:       "kcy8v2oazy4acuo1475rgtzg0nh403p23vw812lv",
+				BeforeCommitSha: "2c6e3880fd94ddb7ef72d34e683cdc0c47bec6e6",
+				Branch:          "main",
+				Repository: models.Repository{
+					Name:     "test-repo",
+					FullName: "test-organization/test-repo",
+					Url:      testRepoUrl,
+					CloneUrl: testRepoCloneUrl,
+					Source:   enums.Github,
+				},
+				Organization: models.Entity{
+					Name: "test-organization",
+				},
+				Pipeline: models.Pipeline{
+					Entity: models.Entity{
+						Id:   "test-project",
+						Name: "test-project",
+					},
+					Path: "buildspec.yml",
+				},
+				Run: models.BuildRun{
+					BuildId:     "test-project:8745a7a9-c340-456a-9166-edf953571bec",
+					BuildNumber: "17",
+				},
+				Runner: models.Runner{
+					Name:         "aws/codebuild/standard:7.0",
+					OS:           runtime.GOOS,
+					Architecture: runtime.GOARCH,
+				},
+				Builder:       "AWS CodeBuild",
+				PipelinePaths: []string{"/tmp/codebuild/repo/buildspec.yml"},
+				Environment:   enums.CodeBuild,
+				ScmId:         "8891c0db39f3064732cc1b4ac02c9b9f",
+			},
+		},
+		{
+			name:         "CodeBuild pull request webhook configuration",
+			envsFilePath: codebuildPrEnvsFilePath,
+			want: &models.Configuration{
+				Url:             "https://eu-west-1.console.aws.amazon.com/codesuite/codebuild/home?region=eu-west-1",
+				SCMApiUrl:       "https://api.github.com",
+				LocalPath:       testRepoPath,
+				CommitSha:       "y2nc0ns6qk8qs4dfbsvnjrf2o8dvmrfrthmx9nbl",
+				BeforeCommitSha: "kcy8v2oazy4acuo1475rgtzg0nh403p23vw812lv",
+				Branch:          "feature/codebuild",
+				Repository: models.Repository{
+					Name:     "test-repo",
+					FullName: "test-organization/test-repo",
+					Url:      testRepoUrl,
+					CloneUrl: testRepoCloneUrl,
+					Source:   enums.Github,
+				},
+				Organization: models.Entity{
+					Name: "test-organization",
+				},
+				Pipeline: models.Pipeline{
+					Entity: models.Entity{
+						Id:   "test-project",
+						Name: "test-project",
+					},
+					Path: "buildspec.yml",
+				},
+				Run: models.BuildRun{
+					BuildId:     "test-project:0b1c2d3e-4f5a-4b6c-8d7e-9f0a1b2c3d4e",
+					BuildNumber: "18",
+				},
+				Runner: models.Runner{
+					Name:         "aws/codebuild/amazonlinux2-aarch64-standard:3.0",
+					OS:           runtime.GOOS,
+					Architecture: runtime.GOARCH,
+				},
+				PullRequest: models.PullRequest{
+					Id: "7",
+					SourceRef: models.Ref{
+						Branch: "feature/codebuild",
+						Sha:    "y2nc0ns6qk8qs4dfbsvnjrf2o8dvmrfrthmx9nbl",
+					},
+					TargetRef: models.Ref{
+						Branch: "main",
+					},
+				},
+				Builder:       "AWS CodeBuild",
+				PipelinePaths: []string{"/tmp/codebuild/repo/buildspec.yml"},
+				Environment:   enums.CodeBuild,
+				ScmId:         "8891c0db39f3064732cc1b4ac02c9b9f",
+			},
+		},
+		{
+			name:         "CodeBuild manual build configuration",
+			envsFilePath: codebuildManualEnvsFilePath,
+			want: &models.Configuration{
+				Url:       "https://us-east-1.console.aws.amazon.com/codesuite/codebuild/home?region=us-east-1",
+				SCMApiUrl: "https://api.github.com",
+				LocalPath: testRepoPath,
+				CommitSha: "kcy8v2oazy4acuo1475rgtzg0nh403p23vw812lv",
+				Branch:    "release",
+				Repository: models.Repository{
+					Name:     "test-repo",
+					FullName: "test-organization/test-repo",
+					Url:      testRepoUrl,
+					CloneUrl: testRepoCloneUrl,
+					Source:   enums.Github,
+				},
+				Organization: models.Entity{
+					Name: "test-organization",
+				},
+				Pipeline: models.Pipeline{
+					Entity: models.Entity{
+						Id:   "test-project",
+						Name: "test-project",
+					},
+					Path: "buildspec.yml",
+				},
+				Run: models.BuildRun{
+					BuildId:     "test-project:5d6e7f8a-9b0c-4d1e-8f2a-3b4c5d6e7f8a",
+					BuildNumber: "19",
+				},
+				Runner: models.Runner{
+					Name:         "aws/codebuild/standard:7.0",
+					OS:           runtime.GOOS,
+					Architecture: runtime.GOARCH,
+				},
+				Builder:       "AWS CodeBuild",
+				PipelinePaths: []string{"/tmp/codebuild/repo/buildspec.yml"},
+				Environment:   enums.CodeBuild,
+				ScmId:         "8891c0db39f3064732cc1b4ac02c9b9f",
+			},
+		},
+		{
+			name:         "CodeBuild CodeCommit source configuration",
+			envsFilePath: codebuildCodeCommitEnvsFilePath,
+			want: &models.Configuration{
+				Url:       "https://us-east-1.console.aws.amazon.com/codesuite/codebuild/home?region=us-east-1",
+				SCMApiUrl: "https://codecommit.us-east-1.amazonaws.com",
+				LocalPath: testRepoPath,
+				CommitSha: "kcy8v2oazy4acuo1475rgtzg0nh403p23vw812lv",
+				Branch:    "main",
+				Repository: models.Repository{
+					Name:     "test-repo",
+					FullName: "test-repo",
+					Url:      "https://us-east-1.console.aws.amazon.com/codesuite/codecommit/repositories/test-repo",
+					CloneUrl: "https://git-codecommit.us-east-1.amazonaws.com/v1/repos/test-repo",
+					Source:   enums.CodeCommit,
+				},
+				Pipeline: models.Pipeline{
+					Entity: models.Entity{
+						Id:   "test-project",
+						Name: "test-project",
+					},
+					Path: "buildspec.yml",
+				},
+				Run: models.BuildRun{
+					BuildId:     "test-project:0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d",
+					BuildNumber: "20",
+				},
+				Runner: models.Runner{
+					Name:         "aws/codebuild/standard:7.0",
+					OS:           runtime.GOOS,
+					Architecture: runtime.GOARCH,
+				},
+				Builder:       "AWS CodeBuild",
+				PipelinePaths: []string{"/tmp/codebuild/repo/buildspec.yml"},
+				Environment:   enums.CodeBuild,
+				ScmId:         "211cca65e79907ab45708380666bdbeb",
+			},
+		},
+		{
+			name:         "Missing build arn",
+			envsFilePath: "",
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := prepareTest(t, tt.envsFilePath)
+			got, err := e.GetConfiguration()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("environment.GetConfiguration() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_environment_GetStepLink(t *testing.T) {
+	tests := []struct {
+		name         string
+		envsFilePath string
+		want         string
+	}{
+		{
+			name:         "CodeBuild environment",
+			envsFilePath: codebuildPushEnvsFilePath,
+			want:         "https://us-east-1.console.aws.amazon.com/codesuite/codebuild/123456789012/projects/test-project/build/test-project%3A8745a7a9-c340-456a-9166-edf953571bec/log?region=us-east-1",
+		},
+		{
+			name:         "Not CodeBuild environment",
+			envsFilePath: "",
+			want:         "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := prepareTest(t, tt.envsFilePath)
+			if got := e.GetStepLink(); got != tt.want {
+				t.Errorf("environment.GetStepLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_environment_GetBuildLink(t *testing.T) {
+	tests := []struct {
+		name         string
+		envsFilePath string
+		want         string
+	}{
+		{
+			name:         "CodeBuild environment",
+			envsFilePath: codebuildPrEnvsFilePath,
+			want:         "https://eu-west-1.console.aws.amazon.com/codesuite/codebuild/123456789012/projects/test-project/build/test-project%3A0b1c2d3e-4f5a-4b6c-8d7e-9f0a1b2c3d4e/?region=eu-west-1",
+		},
+		{
+			name:         "Not CodeBuild environment",
+			envsFilePath: "",
+			want:         "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := prepareTest(t, tt.envsFilePath)
+			if got := e.GetBuildLink(); got != tt.want {
+				t.Errorf("environment.GetBuildLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_environment_IsCurrentEnvironment(t *testing.T) {
+	tests := []struct {
+		name         string
+		envsFilePath string
+		want         bool
+	}{
+		{
+			name:         "CodeBuild environment",
+			envsFilePath: codebuildPushEnvsFilePath,
+			want:         true,
+		},
+		{
+			name:         "Not CodeBuild environment",
+			envsFilePath: "",
+			want:         false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := prepareTest(t, tt.envsFilePath)
+			if got := e.IsCurrentEnvironment(); got != tt.want {
+				t.Errorf("environment.IsCurrentEnvironment() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseBuildArn(t *testing.T) {
+	tests := []struct {
+		arn     string
+		want    buildArn
+		wantErr bool
+	}{
+		{
+			arn: "arn:aws:codebuild:us-east-1:123456789012:build/test-project:8745a7a9-c340-456a-9166-edf953571bec",
+			want: buildArn{
+				region:    "us-east-1",
+				accountId: "123456789012",
+				project:   "test-project",
+				buildUuid: "8745a7a9-c340-456a-9166-edf953571bec",
+			},
+		},
+		{
+			arn:     "arn:aws:codebuild:us-east-1:123456789012:project/test-project",
+			wantErr: true,
+		},
+		{
+			arn:     "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.arn, func(t *testing.T) {
+			got, err := parseBuildArn(tt.arn)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseBuildArn() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_getPipelinePath(t *testing.T) {
+	rootDir := t.TempDir()
+	assert.Equal(t, "", getPipelinePath(envsource.OS, rootDir))
+
+	assert.NoError(t, os.WriteFile(filepath.Join(rootDir, "buildspec.yaml"), []byte("version: 0.2\n"), 0644))
+	assert.Equal(t, "buildspec.yaml", getPipelinePath(envsource.OS, rootDir))
+}
+
+func prepareTest(t *testing.T, envsFilePath string) *Environment {
+	e := New()
+	testRepoCleanup := testutils.PrepareTestGitRepository(testRepoPath, testRepoCloneUrl, testdataPath)
+	t.Cleanup(testRepoCleanup)
+	envCleanup := testutils.SetEnvsFromFile(envsFilePath)
+	t.Cleanup(envCleanup)
+	return e
+}
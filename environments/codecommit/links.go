@@ -0,0 +1,90 @@
+package codecommit
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const consoleHostSuffix = ".console.aws.amazon.com"
+
+// GetFileLink returns the link to a file in the AWS console, the console browses files under browse/<ref>/--/<path>
+// and needs the region of the repository in the query
+func GetFileLink(repositoryURL string, filename string, branch string, commit string) string {
+	return getFileLink(repositoryURL, filename, branch, commit, url.Values{})
+}
+
+func GetFileLineLink(repositoryURL string, filename string, branch string, commit string, startLine int, endLine int) string {
+	query := url.Values{}
+	if startLine != 0 {
+		if endLine == 0 {
+			endLine = startLine
+		}
+		query.Set("lines", fmt.Sprintf("%d-%d", startLine, endLine))
+	}
+	return getFileLink(repositoryURL, filename, branch, commit, query)
+}
+
+func getFileLink(repositoryURL string, filename string, branch string, commit string, query url.Values) string {
+	ref := commit
+	if ref == "" {
+		if branch == "" {
+			return ""
+		}
+		ref = fmt.Sprintf("refs/heads/%s", branch)
+	}
+
+	if region := getRegion(repositoryURL); region != "" {
+		query.Set("region", region)
+	}
+	link := fmt.Sprintf("%s/browse/%s/--/%s", strings.TrimSuffix(repositoryURL, "/"), ref, filename)
+	if len(query) != 0 {
+		link = fmt.Sprintf("%s?%s", link, query.Encode())
+	}
+	return link
+}
+
+// getRegion returns the region of a console url, i.e. us-east-1 for https://us-east-1.console.aws.amazon.com
+func getRegion(repositoryURL string) string {
+	urlObject, err := url.Parse(repositoryURL)
+	if err != nil || !strings.HasSuffix(urlObject.Hostname(), consoleHostSuffix) {
+		return ""
+	}
+	return strings.TrimSuffix(urlObject.Hostname(), consoleHostSuffix)
+}
+
+var (
+	// https://us-east-1.console.aws.amazon.com/codesuite/codecommit/repositories/repo/browse/<ref>/--/path/to/file
+	fileLinkRegexp = regexp.MustCompile(`^(.+?)/browse/(.+?)/--/(.+)$`)
+	// lines=10-20
+	linesRegexp = regexp.MustCompile(`^(\d+)-(\d+)$`)
+)
+
+// IsConsoleUrl checks whether the url is a link to the AWS console
+func IsConsoleUrl(link string) bool {
+	return getRegion(link) != ""
+}
+
+// ParseFileLineLink returns the repository url, the file, the ref and the lines of a link built by GetFileLineLink,
+// the ref is refs/heads/<branch> for a branch link and the commit for a commit link.
+// The region parameter is not returned, GetFileLineLink takes it from the repository url
+func ParseFileLineLink(link string) (string, string, string, int, int, error) {
+	link, rawQuery, _ := strings.Cut(link, "?")
+	result := fileLinkRegexp.FindStringSubmatch(link)
+	if result == nil {
+		return "", "", "", 0, 0, fmt.Errorf("could not parse file link: %s", link)
+	}
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return "", "", "", 0, 0, fmt.Errorf("could not parse file link: %s", link)
+	}
+
+	startLine, endLine := 0, 0
+	if lines := linesRegexp.FindStringSubmatch(query.Get("lines")); lines != nil {
+		startLine, _ = strconv.Atoi(lines[1])
+		endLine, _ = strconv.Atoi(lines[2])
+	}
+	return result[1], result[3], result[2], startLine, endLine, nil
+}
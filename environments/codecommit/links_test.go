@@ -0,0 +1,185 @@
+package codecommit
+
+import (
+	"testing"
+)
+
+const (
+	testRepoURL  = "https://us-east-1.console.aws.amazon.com/codesuite/codecommit/repositories/test-repo"
+	testFilename = "path/to/file"
+	testBranch   = "branch"
+	testCommit   = "commit"
+)
+
+func TestGetFileLink(t *testing.T) {
+	type args struct {
+		repositoryURL string
+		filename      string
+		branch        string
+		commit        string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "With branch",
+			args: args{
+				repositoryURL: testRepoURL,
+				filename:      testFilename,
+				branch:        testBranch,
+			},
+			want: "https://us-east-1.console.aws.amazon.com/codesuite/codecommit/repositories/test-repo/browse/refs/heads/branch/--/path/to/file?region=us-east-1",
+		},
+		{
+			name: "With commit",
+			args: args{
+				repositoryURL: testRepoURL,
+				filename:      testFilename,
+				commit:        testCommit,
+			},
+			want: "https://us-east-1.console.aws.amazon.com/codesuite/codecommit/repositories/test-repo/browse/commit/--/path/to/file?region=us-east-1",
+		},
+		{
+			name: "With branch and commit",
+			args: args{
+				repositoryURL: testRepoURL,
+				filename:      testFilename,
+				branch:        testBranch,
+				commit:        testCommit,
+			},
+			want: "https://us-east-1.console.aws.amazon.com/codesuite/codecommit/repositories/test-repo/browse/commit/--/path/to/file?region=us-east-1",
+		},
+		{
+			name: "Repository url without region",
+			args: args{
+				repositoryURL: "https://console.aws.amazon.com/codesuite/codecommit/repositories/test-repo",
+				filename:      testFilename,
+				branch:        testBranch,
+			},
+			want: "https://console.aws.amazon.com/codesuite/codecommit/repositories/test-repo/browse/refs/heads/branch/--/path/to/file",
+		},
+		{
+			name: "Without branch and commit",
+			args: args{
+				repositoryURL: testRepoURL,
+				filename:      testFilename,
+			},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetFileLink(tt.args.repositoryURL, tt.args.filename, tt.args.branch, tt.args.commit); got != tt.want {
+				t.Errorf("GetFileLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetFileLineLink(t *testing.T) {
+	type args struct {
+		repositoryURL string
+		filename      string
+		branch        string
+		commit        string
+		startLine     int
+		endLine       int
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "No lines",
+			args: args{
+				repositoryURL: testRepoURL,
+				filename:      testFilename,
+				branch:        testBranch,
+			},
+			want: "https://us-east-1.console.aws.amazon.com/codesuite/codecommit/repositories/test-repo/browse/refs/heads/branch/--/path/to/file?region=us-east-1",
+		},
+		{
+			name: "Only start line",
+			args: args{
+				repositoryURL: testRepoURL,
+				filename:      testFilename,
+				commit:        testCommit,
+				startLine:     1,
+			},
+			want: "https://us-east-1.console.aws.amazon.com/codesuite/codecommit/repositories/test-repo/browse/commit/--/path/to/file?lines=1-1&region=us-east-1",
+		},
+		{
+			name: "With start line and end line",
+			args: args{
+				repositoryURL: testRepoURL,
+				filename:      testFilename,
+				branch:        testBranch,
+				commit:        testCommit,
+				startLine:     1,
+				endLine:       2,
+			},
+			want: "https://us-east-1.console.aws.amazon.com/codesuite/codecommit/repositories/test-repo/browse/commit/--/path/to/file?lines=1-2&region=us-east-1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetFileLineLink(tt.args.repositoryURL, tt.args.filename, tt.args.branch, tt.args.commit, tt.args.startLine, tt.args.endLine); got != tt.want {
+				t.Errorf("GetFileLineLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFileLineLink(t *testing.T) {
+	tests := []struct {
+		name              string
+		link              string
+		wantRepositoryURL string
+		wantFilename      string
+		wantRef           string
+		wantStartLine     int
+		wantEndLine       int
+		wantErr           bool
+	}{
+		{
+			name:              "Branch lines",
+			link:              "https://us-east-1.console.aws.amazon.com/codesuite/codecommit/repositories/test-repo/browse/refs/heads/feature/x/--/path/to/file?lines=10-20&region=us-east-1",
+			wantRepositoryURL: "https://us-east-1.console.aws.amazon.com/codesuite/codecommit/repositories/test-repo",
+			wantFilename:      "path/to/file",
+			wantRef:           "refs/heads/feature/x",
+			wantStartLine:     10,
+			wantEndLine:       20,
+		},
+		{
+			name:              "Commit",
+			link:              "https://us-east-1.console.aws.amazon.com/codesuite/codecommit/repositories/test-repo/browse/commit/--/path/to/file?region=us-east-1",
+			wantRepositoryURL: "https://us-east-1.console.aws.amazon.com/codesuite/codecommit/repositories/test-repo",
+			wantFilename:      "path/to/file",
+			wantRef:           "commit",
+			wantStartLine:     0,
+			wantEndLine:       0,
+		},
+		{
+			name:    "Not a file link",
+			link:    "https://us-east-1.console.aws.amazon.com/codesuite/codecommit/repositories/test-repo/browse",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repositoryURL, filename, ref, startLine, endLine, err := ParseFileLineLink(tt.link)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFileLineLink() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if repositoryURL != tt.wantRepositoryURL || filename != tt.wantFilename || ref != tt.wantRef {
+				t.Errorf("ParseFileLineLink() = %v, %v, %v, want %v, %v, %v", repositoryURL, filename, ref, tt.wantRepositoryURL, tt.wantFilename, tt.wantRef)
+			}
+			if startLine != tt.wantStartLine || endLine != tt.wantEndLine {
+				t.Errorf("ParseFileLineLink() lines = %v-%v, want %v-%v", startLine, endLine, tt.wantStartLine, tt.wantEndLine)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package codecommit
+
+import (
+	"fmt"
+	"regexp"
+)
+
+var (
+	// https://git-codecommit.us-east-1.amazonaws.com/v1/repos/test-repo, the SSH clone url has the SSH key id as the user
+	cloneUrlRegexp = regexp.MustCompile(`^(?:https|ssh)://(?:[^@/]+@)?git-codecommit(?:-fips)?\.([a-z0-9-]+)\.amazonaws\.com(?::\d+)?/v1/repos/([^/]+?)(?:\.git)?/?$`)
+	// codecommit::us-east-1://profile@test-repo, the syntax of the git-remote-codecommit helper,
+	// the region and the profile are optional
+	remoteHelperUrlRegexp = regexp.MustCompile(`^codecommit(?:::([a-z0-9-]+))?://(?:[^@/]+@)?([^/]+?)(?:\.git)?/?$`)
+)
+
+// IsCodeCommitCloneUrl checks whether the clone url is a CodeCommit HTTPS, SSH or git-remote-codecommit url
+func IsCodeCommitCloneUrl(cloneUrl string) bool {
+	return cloneUrlRegexp.MatchString(cloneUrl) || remoteHelperUrlRegexp.MatchString(cloneUrl)
+}
+
+// ParseCloneUrl returns the region and the repository name of a CodeCommit clone url,
+// the region is empty for git-remote-codecommit urls that use the region of the AWS profile
+func ParseCloneUrl(cloneUrl string) (string, string, error) {
+	for _, urlRegexp := range []*regexp.Regexp{cloneUrlRegexp, remoteHelperUrlRegexp} {
+		if result := urlRegexp.FindStringSubmatch(cloneUrl); result != nil {
+			return result[1], result[2], nil
+		}
+	}
+	return "", "", fmt.Errorf("could not parse clone url: %s", cloneUrl)
+}
+
+// GetApiUrl returns the CodeCommit endpoint of the region
+func GetApiUrl(region string) string {
+	if region == "" {
+		return ""
+	}
+	return fmt.Sprintf("https://codecommit.%s.amazonaws.com", region)
+}
+
+// BuildScmLink returns the link to the repository in the AWS console
+func BuildScmLink(region, repo string) string {
+	consoleUrl := "https://console.aws.amazon.com"
+	if region != "" {
+		consoleUrl = fmt.Sprintf("https://%s.console.aws.amazon.com", region)
+	}
+	return fmt.Sprintf("%s/codesuite/codecommit/repositories/%s", consoleUrl, repo)
+}
@@ -0,0 +1,75 @@
+package codecommit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCloneUrl(t *testing.T) {
+	tests := []struct {
+		name       string
+		cloneUrl   string
+		wantRegion string
+		wantRepo   string
+		wantErr    bool
+	}{
+		{
+			name:       "HTTPS clone url",
+			cloneUrl:   "https://git-codecommit.us-east-1.amazonaws.com/v1/repos/test-repo",
+			wantRegion: "us-east-1",
+			wantRepo:   "test-repo",
+		},
+		{
+			name:       "HTTPS FIPS clone url with .git",
+			cloneUrl:   "https://git-codecommit-fips.us-gov-west-1.amazonaws.com/v1/repos/test-repo.git",
+			wantRegion: "us-gov-west-1",
+			wantRepo:   "test-repo",
+		},
+		{
+			name:       "SSH clone url with key id",
+			cloneUrl:   "ssh://APKAEIBAERJR2EXAMPLE@git-codecommit.eu-west-1.amazonaws.com/v1/repos/test-repo",
+			wantRegion: "eu-west-1",
+			wantRepo:   "test-repo",
+		},
+		{
+			name:       "Remote helper url with region and profile",
+			cloneUrl:   "codecommit::eu-central-1://test-profile@test-repo",
+			wantRegion: "eu-central-1",
+			wantRepo:   "test-repo",
+		},
+		{
+			name:       "Remote helper url without region",
+			cloneUrl:   "codecommit://test-repo.git",
+			wantRegion: "",
+			wantRepo:   "test-repo",
+		},
+		{
+			name:     "GitHub clone url",
+			cloneUrl: "https://github.com/test-organization/test-repo.git",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotRegion, gotRepo, err := ParseCloneUrl(tt.cloneUrl)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseCloneUrl() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.wantRegion, gotRegion)
+			assert.Equal(t, tt.wantRepo, gotRepo)
+			assert.Equal(t, !tt.wantErr, IsCodeCommitCloneUrl(tt.cloneUrl))
+		})
+	}
+}
+
+func TestBuildScmLink(t *testing.T) {
+	assert.Equal(t, "https://us-east-1.console.aws.amazon.com/codesuite/codecommit/repositories/test-repo", BuildScmLink("us-east-1", "test-repo"))
+	assert.Equal(t, "https://console.aws.amazon.com/codesuite/codecommit/repositories/test-repo", BuildScmLink("", "test-repo"))
+}
+
+func TestGetApiUrl(t *testing.T) {
+	assert.Equal(t, "https://codecommit.us-east-1.amazonaws.com", GetApiUrl("us-east-1"))
+	assert.Equal(t, "", GetApiUrl(""))
+}
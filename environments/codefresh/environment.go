@@ -0,0 +1,270 @@
+package codefresh
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/utils"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/models"
+)
+
+const (
+	builder = "Codefresh"
+
+	codefreshUrlEnv = "CF_URL"
+	buildIdEnv      = "CF_BUILD_ID"
+	buildUrlEnv     = "CF_BUILD_URL"
+	pipelineNameEnv = "CF_PIPELINE_NAME"
+	stepNameEnv     = "CF_STEP_NAME"
+	volumePathEnv   = "CF_VOLUME_PATH"
+
+	repoOwnerEnv  = "CF_REPO_OWNER"
+	repoNameEnv   = "CF_REPO_NAME"
+	commitShaEnv  = "CF_REVISION"
+	commitUrlEnv  = "CF_COMMIT_URL"
+	branchEnv     = "CF_BRANCH"
+	initiatorEnv  = "CF_BUILD_INITIATOR"
+	commitUserEnv = "CF_COMMIT_AUTHOR"
+
+	pullRequestNumberEnv = "CF_PULL_REQUEST_NUMBER"
+	pullRequestIdEnv     = "CF_PULL_REQUEST_ID"
+	pullRequestTargetEnv = "CF_PULL_REQUEST_TARGET"
+
+	codefreshPipelineFile = "codefresh.yml"
+)
+
+var (
+	// Codefresh environment
+	Codefresh = New()
+
+	// the commit URL paths of the git providers, i.e. https://github.com/owner/repo/commit/sha
+	commitUrlSeparators = []string{"/-/commit/", "/commits/", "/commit/"}
+)
+
+// Environment is the Codefresh environment
+type Environment struct {
+	cache utils.ConfigurationCache
+}
+
+// New creates a Codefresh environment with its own configuration cache
+func New() *Environment {
+	return &Environment{}
+}
+
+func (e *Environment) GetConfiguration() (*models.Configuration, error) {
+	return e.cache.Get(e.load)
+}
+
+// Refresh loads the configuration again and replaces the cached configuration
+func (e *Environment) Refresh() (*models.Configuration, error) {
+	return e.cache.Refresh(e.load)
+}
+
+// Reset clears the cached configuration, it is loaded again on the next GetConfiguration
+func (e *Environment) Reset() {
+	e.cache.Reset()
+}
+
+func (e *Environment) load() (*models.Configuration, error) {
+	return loadConfiguration(envsource.OS)
+}
+
+func (e *Environment) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return loadConfiguration(src)
+}
+
+func loadConfiguration(src envsource.EnvSource) (*models.Configuration, error) {
+	repoPath := getRepositoryPath(src)
+
+	// Codefresh has no variable with the clone URL, the git-clone step clones the repository into the volume
+	var warnings []models.Warning
+	cloneUrl, err := envsource.GitClient(src).GetGitRemoteURL(envsource.Path(src, repoPath))
+	if err != nil || cloneUrl == "" {
+		fallbackUrl := getCloneUrlFromCommitUrl(src.Getenv(commitUrlEnv), src.Getenv(repoOwnerEnv), src.Getenv(repoNameEnv))
+		if err != nil {
+			warnings = append(warnings, models.NewWarning(models.GitRemoteUrlWarning, err, "failed to get the git remote url of %s, using %s", repoPath, fallbackUrl))
+		}
+		cloneUrl = fallbackUrl
+	}
+	cloneUrl = utils.StripCredentialsFromUrl(cloneUrl)
+
+	source, apiUrl := utils.GetRepositorySource(cloneUrl)
+	repoUrl, org, repoName, repoFullName, err := utils.ParseDataFromCloneUrl(cloneUrl, apiUrl, source)
+	if err != nil {
+		return nil, err
+	}
+
+	branch := src.Getenv(branchEnv)
+	commit := src.Getenv(commitShaEnv)
+
+	pullRequest := models.PullRequest{}
+	if prId := getPullRequestId(src); prId != "" {
+		pullRequest = models.PullRequest{
+			Id: prId,
+			SourceRef: models.Ref{
+				Branch: branch,
+				Sha:    commit,
+			},
+			TargetRef: models.Ref{
+				Branch: src.Getenv(pullRequestTargetEnv),
+			},
+		}
+	}
+
+	pipelinePaths := getPipelinePaths(src, repoPath)
+	pipelinePath := ""
+	if len(pipelinePaths) > 0 {
+		pipelinePath = codefreshPipelineFile
+	}
+
+	return &models.Configuration{
+		Url:       src.Getenv(codefreshUrlEnv),
+		SCMApiUrl: apiUrl,
+		LocalPath: repoPath,
+		CommitSha: commit,
+		Branch:    branch,
+		Repository: models.Repository{
+			Name:     repoName,
+			FullName: repoFullName,
+			Url:      repoUrl,
+			CloneUrl: cloneUrl,
+			Source:   source,
+		},
+		Organization: models.Entity{
+			Name: org,
+		},
+		Pipeline: models.Pipeline{
+			Entity: models.Entity{
+				Id:   src.Getenv(pipelineNameEnv),
+				Name: src.Getenv(pipelineNameEnv),
+			},
+			Path: pipelinePath,
+		},
+		Job: models.Entity{
+			Name: src.Getenv(stepNameEnv),
+		},
+		Run: models.BuildRun{
+			BuildId: src.Getenv(buildIdEnv),
+		},
+		Runner: models.Runner{
+			OS:           runtime.GOOS,
+			Architecture: runtime.GOARCH,
+		},
+		PullRequest: pullRequest,
+		Builder:     builder,
+		Pusher: models.Pusher{
+			Username: getPusher(src),
+		},
+		PipelinePaths: pipelinePaths,
+		Environment:   enums.Codefresh,
+		ScmId:         utils.GenerateScmId(cloneUrl),
+		Warnings:      warnings,
+	}, nil
+}
+
+// getRepositoryPath returns the directory that the git-clone step clones the repository into by default
+func getRepositoryPath(src envsource.EnvSource) string {
+	volumePath, repoName := src.Getenv(volumePathEnv), src.Getenv(repoNameEnv)
+	if volumePath == "" || repoName == "" {
+		return volumePath
+	}
+	return filepath.Join(volumePath, repoName)
+}
+
+// getCloneUrlFromCommitUrl builds the clone URL from the commit URL of the git provider,
+// i.e. https://github.com/owner/repo/commit/sha and https://bitbucket.org/owner/repo/commits/sha
+func getCloneUrlFromCommitUrl(commitUrl, owner, repoName string) string {
+	if owner != "" && repoName != "" {
+		repoPath := fmt.Sprintf("/%s/%s/", owner, repoName)
+		if index := strings.Index(commitUrl, repoPath); index != -1 {
+			return fmt.Sprintf("%s.git", commitUrl[:index+len(repoPath)-1])
+		}
+	}
+	for _, separator := range commitUrlSeparators {
+		if index := strings.LastIndex(commitUrl, separator); index != -1 {
+			return fmt.Sprintf("%s.git", commitUrl[:index])
+		}
+	}
+	return ""
+}
+
+// getPullRequestId returns the pull request number, or the id when the git provider has no numbers
+func getPullRequestId(src envsource.EnvSource) string {
+	if prNumber := src.Getenv(pullRequestNumberEnv); prNumber != "" {
+		return prNumber
+	}
+	return src.Getenv(pullRequestIdEnv)
+}
+
+func getPusher(src envsource.EnvSource) string {
+	for _, env := range []string{initiatorEnv, commitUserEnv} {
+		if username := src.Getenv(env); username != "" {
+			return username
+		}
+	}
+	return utils.DetectPusherFrom(src)
+}
+
+func (e *Environment) GetStepLink() string {
+	return fmt.Sprintf("%s?step=%s", os.Getenv(buildUrlEnv), url.QueryEscape(os.Getenv(stepNameEnv)))
+}
+
+func (e *Environment) GetBuildLink() string {
+	return os.Getenv(buildUrlEnv)
+}
+
+func (e *Environment) GetFileLink(filename string, branch string, commit string) string {
+	return ""
+}
+
+func (e *Environment) GetFileLineLink(filename string, branch string, commit string, startLine int, endLine int) string {
+	return ""
+}
+
+func (e *Environment) IsCurrentEnvironment() bool {
+	_, isExist := os.LookupEnv(buildIdEnv)
+	return isExist
+}
+
+// DetectionVariables returns the variables used by IsCurrentEnvironment
+func (e *Environment) DetectionVariables() []string {
+	return []string{buildIdEnv}
+}
+
+// ExpectedFields returns the configuration fields the environment is expected to fill and the variables they are filled from
+func (e *Environment) ExpectedFields() []models.ExpectedField {
+	return []models.ExpectedField{
+		{Field: "repository.url", SourceEnv: commitUrlEnv, Severity: models.SeverityCritical},
+		{Field: "repository.cloneUrl", SourceEnv: commitUrlEnv, Severity: models.SeverityCritical},
+		{Field: "commitSha", SourceEnv: commitShaEnv, Severity: models.SeverityCritical},
+		{Field: "branch", SourceEnv: branchEnv, Severity: models.SeverityWarning},
+		{Field: "localPath", SourceEnv: volumePathEnv, Severity: models.SeverityWarning},
+		{Field: "pipeline.name", SourceEnv: pipelineNameEnv, Severity: models.SeverityWarning},
+		{Field: "job.name", SourceEnv: stepNameEnv, Severity: models.SeverityWarning},
+		{Field: "run.buildId", SourceEnv: buildIdEnv, Severity: models.SeverityWarning},
+	}
+}
+
+func (e *Environment) Name() string {
+	return "codefresh"
+}
+
+func getPipelinePaths(src envsource.EnvSource, rootDir string) []string {
+	paths := make([]string, 0)
+	if rootDir == "" {
+		return paths
+	}
+
+	path := filepath.Join(rootDir, codefreshPipelineFile)
+	if _, err := envsource.Stat(src, path); err == nil {
+		paths = append(paths, path)
+	}
+
+	return paths
+}
@@ -0,0 +1,104 @@
+package codefresh
+
+import (
+	"fmt"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/models"
+)
+
+var (
+	MockOrgName  = "test-org"
+	MockRepoName = "test-repo"
+	MockBuildId  = "65e5c5d0e1a2b3c4d5e6f708"
+	MockBuildUrl = "https://g.codefresh.io/build/65e5c5d0e1a2b3c4d5e6f708"
+)
+
+var mockConfiguration *models.Configuration
+
+type EnvironmentMock struct{}
+
+func (em *EnvironmentMock) GetConfiguration() (*models.Configuration, error) {
+	if mockConfiguration == nil {
+		if err := loadMockConfiguration(); err != nil {
+			return nil, err
+		}
+	}
+	return mockConfiguration, nil
+}
+
+func (em *EnvironmentMock) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return em.GetConfiguration()
+}
+
+func (em *EnvironmentMock) Refresh() (*models.Configuration, error) {
+	em.Reset()
+	return em.GetConfiguration()
+}
+
+func (em *EnvironmentMock) Reset() {
+	mockConfiguration = nil
+}
+
+func loadMockConfiguration() error {
+	mockConfiguration = &models.Configuration{
+		Url:       "https://g.codefresh.io",
+		SCMApiUrl: "https://api.github.com",
+		LocalPath: fmt.Sprintf("/codefresh/volume/%s", MockRepoName),
+		CommitSha: "2c6e3880fd94ddb7ef72d34e683cdc0c47bec6e6",
+		Branch:    "main",
+		Run: models.BuildRun{
+			BuildId: MockBuildId,
+		},
+		Job: models.Entity{
+			Name: "test",
+		},
+		Pipeline: models.Pipeline{
+			Entity: models.Entity{
+				Id:   "test-project/build",
+				Name: "test-project/build",
+			},
+			Path: "codefresh.yml",
+		},
+		Repository: models.Repository{
+			Name:     MockRepoName,
+			FullName: fmt.Sprintf("%s/%s", MockOrgName, MockRepoName),
+			Url:      fmt.Sprintf("https://github.com/%s/%s", MockOrgName, MockRepoName),
+			CloneUrl: fmt.Sprintf("https://github.com/%s/%s.git", MockOrgName, MockRepoName),
+			Source:   enums.Github,
+		},
+		Builder: "Codefresh",
+		Organization: models.Entity{
+			Name: MockOrgName,
+		},
+		PipelinePaths: []string{fmt.Sprintf("/codefresh/volume/%s/codefresh.yml", MockRepoName)},
+		Environment:   enums.Codefresh,
+	}
+
+	return nil
+}
+
+func (em *EnvironmentMock) GetBuildLink() string {
+	return MockBuildUrl
+}
+
+func (em *EnvironmentMock) GetStepLink() string {
+	return fmt.Sprintf("%s?step=test", MockBuildUrl)
+}
+
+func (em *EnvironmentMock) GetFileLink(filename string, branch string, commit string) string {
+	return ""
+}
+
+func (em *EnvironmentMock) GetFileLineLink(filename string, branch string, commit string, startLine int, endLine int) string {
+	return ""
+}
+
+func (em *EnvironmentMock) IsCurrentEnvironment() bool {
+	return true
+}
+
+func (em *EnvironmentMock) Name() string {
+	return "codefresh"
+}
@@ -0,0 +1,238 @@
+package codefresh
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/testutils"
+	"github.com/argonsecurity/go-environments/environments/testutils/mocks"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/models"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	codefreshMainEnvsFilePath      = "testdata/codefresh-github-main-env.json"
+	codefreshPrEnvsFilePath        = "testdata/codefresh-github-pr-env.json"
+	codefreshBitbucketEnvsFilePath = "testdata/codefresh-bitbucket-main-env.json"
+	testRepoPath                   = "/tmp/codefresh/volume/test-repo"
+	testRepoUrl                    = "https://github.com/test-org/test-repo"
+	testRepoCloneUrl               = "https://github.com/test-org/test-repo.git"
+	testdataPath                   = "../codefresh/testdata/repo"
+)
+
+func Test_environment_GetConfiguration(t *testing.T) {
+	tests := []struct {
+		name         string
+		envsFilePath string
+		want         *models.Configuration
+		wantErr      bool
+	}{
+		{
+			name:         "Codefresh GitHub main configuration",
+			envsFilePath: codefreshMainEnvsFilePath,
+			want: &models.Configuration{
+				Url:       "https://g.codefresh.io",
+				SCMApiUrl: "https://api.github.com",
+				LocalPath: testRepoPath,
+				CommitSha: "2c6e3880fd94ddb7ef72d34e683cdc0c47bec6e6",
+				Branch:    "main",
+				Repository: models.Repository{
+					Name:     "test-repo",
+					FullName: "test-org/test-repo",
+					Url:      testRepoUrl,
+					CloneUrl: testRepoCloneUrl,
+					Source:   enums.Github,
+				},
+				Organization: models.Entity{
+					Name: "test-org",
+				},
+				Pipeline: models.Pipeline{
+					Entity: models.Entity{
+						Id:   "test-project/build",
+						Name: "test-project/build",
+					},
+					Path: "codefresh.yml",
+				},
+				Job: models.Entity{
+					Name: "test",
+				},
+				Run: models.BuildRun{
+					BuildId: "65e5c5d0e1a2b3c4d5e6f708",
+				},
+				Runner: models.Runner{
+					OS:           runtime.GOOS,
+					Architecture: runtime.GOARCH,
+				},
+				Pusher: models.Pusher{
+					Username: "test-user",
+				},
+				Builder:       "Codefresh",
+				PipelinePaths: []string{"/tmp/codefresh/volume/test-repo/codefresh.yml"},
+				Environment:   enums.Codefresh,
+				ScmId:         "b30f418cdcc9970849d3d031de5df54f",
+			},
+		},
+		{
+			name:         "Codefresh GitHub pull request configuration",
+			envsFilePath: codefreshPrEnvsFilePath,
+			want: &models.Configuration{
+				Url:       "https://g.codefresh.io",
+				SCMApiUrl: "https://api.github.com",
+				LocalPath: testRepoPath,
+				CommitSha: "fe8c38a965d13d9794eb36918cb24cebe49a45c2",
+				Branch:    "feature/test",
+				Repository: models.Repository{
+					Name:     "test-repo",
+					FullName: "test-org/test-repo",
+					Url:      testRepoUrl,
+					CloneUrl: testRepoCloneUrl,
+					Source:   enums.Github,
+				},
+				Organization: models.Entity{
+					Name: "test-org",
+				},
+				Pipeline: models.Pipeline{
+					Entity: models.Entity{
+						Id:   "test-project/build",
+						Name: "test-project/build",
+					},
+					Path: "codefresh.yml",
+				},
+				Job: models.Entity{
+					Name: "Running tests",
+				},
+				Run: models.BuildRun{
+					BuildId: "65e5c6a1e1a2b3c4d5e6f709",
+				},
+				Runner: models.Runner{
+					OS:           runtime.GOOS,
+					Architecture: runtime.GOARCH,
+				},
+				PullRequest: models.PullRequest{
+					Id: "7",
+					SourceRef: models.Ref{
+						Branch: "feature/test",
+						Sha:    "fe8c38a965d13d9794eb36918cb24cebe49a45c2",
+					},
+					TargetRef: models.Ref{
+						Branch: "main",
+					},
+				},
+				Pusher: models.Pusher{
+					Username: "test-author",
+				},
+				Builder:       "Codefresh",
+				PipelinePaths: []string{"/tmp/codefresh/volume/test-repo/codefresh.yml"},
+				Environment:   enums.Codefresh,
+				ScmId:         "b30f418cdcc9970849d3d031de5df54f",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := prepareTest(t, tt.envsFilePath)
+			got, err := e.GetConfiguration()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("environment.GetConfiguration() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_environment_GetConfigurationWarnings(t *testing.T) {
+	gitClient := (&mocks.MockGitClient{}).SetError(errors.New("not a git repository"))
+	src := envsource.New(testutils.LoadEnvsFromFile(codefreshBitbucketEnvsFilePath)).WithGitClient(gitClient)
+
+	got, err := New().GetConfigurationFrom(src)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://bitbucket.org/test-org/test-repo.git", got.Repository.CloneUrl)
+	assert.Equal(t, enums.Bitbucket, got.Repository.Source)
+	assert.Equal(t, "/codefresh/volume/test-repo", got.LocalPath)
+	assert.Empty(t, got.PipelinePaths)
+	assert.Len(t, got.Warnings, 1)
+	assert.Equal(t, models.GitRemoteUrlWarning, got.Warnings[0].Code)
+}
+
+func Test_getCloneUrlFromCommitUrl(t *testing.T) {
+	tests := []struct {
+		name      string
+		commitUrl string
+		owner     string
+		repoName  string
+		want      string
+	}{
+		{
+			name:      "GitHub commit url",
+			commitUrl: "https://github.com/test-org/test-repo/commit/2c6e3880fd94ddb7ef72d34e683cdc0c47bec6e6",
+			owner:     "test-org",
+			repoName:  "test-repo",
+			want:      "https://github.com/test-org/test-repo.git",
+		},
+		{
+			name:      "GitLab subgroup commit url",
+			commitUrl: "https://gitlab.com/test-group/sub-group/test-repo/-/commit/2c6e3880fd94ddb7ef72d34e683cdc0c47bec6e6",
+			want:      "https://gitlab.com/test-group/sub-group/test-repo.git",
+		},
+		{
+			name:      "Bitbucket commit url",
+			commitUrl: "https://bitbucket.org/test-org/test-repo/commits/2c6e3880fd94ddb7ef72d34e683cdc0c47bec6e6",
+			want:      "https://bitbucket.org/test-org/test-repo.git",
+		},
+		{
+			name: "Missing commit url",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, getCloneUrlFromCommitUrl(tt.commitUrl, tt.owner, tt.repoName))
+		})
+	}
+}
+
+func Test_environment_GetBuildLink(t *testing.T) {
+	e := prepareTest(t, codefreshPrEnvsFilePath)
+	assert.Equal(t, "https://g.codefresh.io/build/65e5c6a1e1a2b3c4d5e6f709", e.GetBuildLink())
+	assert.Equal(t, "https://g.codefresh.io/build/65e5c6a1e1a2b3c4d5e6f709?step=Running+tests", e.GetStepLink())
+}
+
+func Test_environment_IsCurrentEnvironment(t *testing.T) {
+	tests := []struct {
+		name         string
+		envsFilePath string
+		want         bool
+	}{
+		{
+			name:         "Codefresh environment",
+			envsFilePath: codefreshMainEnvsFilePath,
+			want:         true,
+		},
+		{
+			name:         "Not Codefresh environment",
+			envsFilePath: "",
+			want:         false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := prepareTest(t, tt.envsFilePath)
+			if got := e.IsCurrentEnvironment(); got != tt.want {
+				t.Errorf("environment.IsCurrentEnvironment() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func prepareTest(t *testing.T, envsFilePath string) *Environment {
+	e := New()
+	testRepoCleanup := testutils.PrepareTestGitRepository(testRepoPath, testRepoCloneUrl, testdataPath)
+	t.Cleanup(testRepoCleanup)
+	envCleanup := testutils.SetEnvsFromFile(envsFilePath)
+	t.Cleanup(envCleanup)
+	return e
+}
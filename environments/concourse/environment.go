@@ -0,0 +1,247 @@
+package concourse
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/utils"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/models"
+)
+
+const (
+	builder = "Concourse"
+
+	// the BUILD_* variables are shared with Jenkins, ATC_EXTERNAL_URL is set by Concourse only
+	atcExternalUrlEnv = "ATC_EXTERNAL_URL"
+
+	teamNameEnv             = "BUILD_TEAM_NAME"
+	pipelineNameEnv         = "BUILD_PIPELINE_NAME"
+	pipelineInstanceVarsEnv = "BUILD_PIPELINE_INSTANCE_VARS"
+	jobNameEnv              = "BUILD_JOB_NAME"
+	buildIdEnv              = "BUILD_ID"
+	buildNameEnv            = "BUILD_NAME"
+	buildCreatedByEnv       = "BUILD_CREATED_BY"
+	hostnameEnv             = "HOSTNAME"
+)
+
+var (
+	// Concourse environment
+	Concourse = New()
+
+	// the conventional locations of the pipeline that is set with fly set-pipeline
+	concoursePipelineFiles = []string{"ci/pipeline.yml", "ci/pipeline.yaml"}
+)
+
+// Environment is the Concourse environment
+type Environment struct {
+	cache utils.ConfigurationCache
+}
+
+// New creates a Concourse environment with its own configuration cache
+func New() *Environment {
+	return &Environment{}
+}
+
+func (e *Environment) GetConfiguration() (*models.Configuration, error) {
+	return e.cache.Get(e.load)
+}
+
+// Refresh loads the configuration again and replaces the cached configuration
+func (e *Environment) Refresh() (*models.Configuration, error) {
+	return e.cache.Refresh(e.load)
+}
+
+// Reset clears the cached configuration, it is loaded again on the next GetConfiguration
+func (e *Environment) Reset() {
+	e.cache.Reset()
+}
+
+func (e *Environment) load() (*models.Configuration, error) {
+	return loadConfiguration(envsource.OS)
+}
+
+func (e *Environment) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return loadConfiguration(src)
+}
+
+func loadConfiguration(src envsource.EnvSource) (*models.Configuration, error) {
+	// tasks run in a build directory that has the inputs, i.e. the git resource, as subdirectories
+	workingDir := envsource.Getwd(src)
+	repoPath := envsource.FindRepository(src, workingDir)
+	if repoPath == "" {
+		repoPath = workingDir
+	}
+
+	gitClient := envsource.GitClient(src)
+	cloneUrl, err := gitClient.GetGitRemoteURL(envsource.Path(src, repoPath))
+	if err != nil {
+		return nil, err
+	}
+	cloneUrl = utils.StripCredentialsFromUrl(cloneUrl)
+
+	source, apiUrl := utils.GetRepositorySource(cloneUrl)
+	repoUrl, org, repoName, repoFullName, err := utils.ParseDataFromCloneUrl(cloneUrl, apiUrl, source)
+	if err != nil {
+		return nil, err
+	}
+
+	commit, err := gitClient.GetGitCommit(envsource.Path(src, repoPath))
+	if err != nil {
+		return nil, err
+	}
+	branch, warnings, _ := envsource.GetGitBranch(src, repoPath, commit)
+
+	return &models.Configuration{
+		Url:       src.Getenv(atcExternalUrlEnv),
+		SCMApiUrl: apiUrl,
+		LocalPath: repoPath,
+		CommitSha: commit,
+		Branch:    branch,
+		Repository: models.Repository{
+			Name:     repoName,
+			FullName: repoFullName,
+			Url:      repoUrl,
+			CloneUrl: cloneUrl,
+			Source:   source,
+		},
+		Organization: models.Entity{
+			Id:   src.Getenv(teamNameEnv),
+			Name: org,
+		},
+		Pipeline: models.Pipeline{
+			Entity: models.Entity{
+				Id:   src.Getenv(pipelineNameEnv),
+				Name: src.Getenv(pipelineNameEnv),
+			},
+		},
+		Job: models.Entity{
+			Id:   src.Getenv(jobNameEnv),
+			Name: src.Getenv(jobNameEnv),
+		},
+		Run: models.BuildRun{
+			BuildId:     src.Getenv(buildIdEnv),
+			BuildNumber: src.Getenv(buildNameEnv),
+		},
+		Runner: models.Runner{
+			Name:         src.Getenv(hostnameEnv),
+			OS:           runtime.GOOS,
+			Architecture: runtime.GOARCH,
+		},
+		Builder: builder,
+		Pusher: models.Pusher{
+			Username: getPusher(src),
+		},
+		PipelinePaths: getPipelinePaths(src, repoPath),
+		Environment:   enums.Concourse,
+		ScmId:         utils.GenerateScmId(cloneUrl),
+		Warnings:      warnings,
+	}, nil
+}
+
+// getPusher returns the user that triggered a manual build, builds that were triggered by a resource have no user
+func getPusher(src envsource.EnvSource) string {
+	if createdBy := src.Getenv(buildCreatedByEnv); createdBy != "" {
+		return createdBy
+	}
+	return utils.DetectPusherFrom(src)
+}
+
+// getBuildUrl returns the link to the build of the job, or to the one-off build that was started with fly execute
+func getBuildUrl(src envsource.EnvSource) string {
+	atcUrl, jobName := src.Getenv(atcExternalUrlEnv), src.Getenv(jobNameEnv)
+	if jobName == "" {
+		return fmt.Sprintf("%s/builds/%s", atcUrl, src.Getenv(buildIdEnv))
+	}
+
+	buildUrl := fmt.Sprintf("%s/teams/%s/pipelines/%s/jobs/%s/builds/%s", atcUrl,
+		url.PathEscape(src.Getenv(teamNameEnv)), url.PathEscape(src.Getenv(pipelineNameEnv)), url.PathEscape(jobName), url.PathEscape(src.Getenv(buildNameEnv)))
+	if query := getInstanceVarsQuery(src.Getenv(pipelineInstanceVarsEnv)); query != "" {
+		buildUrl = fmt.Sprintf("%s?%s", buildUrl, query)
+	}
+	return buildUrl
+}
+
+// getInstanceVarsQuery returns the query that selects an instanced pipeline, every instance var is a vars.<name> parameter with a JSON value
+func getInstanceVarsQuery(instanceVars string) string {
+	vars := map[string]json.RawMessage{}
+	if instanceVars == "" || json.Unmarshal([]byte(instanceVars), &vars) != nil {
+		return ""
+	}
+
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	query := url.Values{}
+	for _, name := range names {
+		query.Add(fmt.Sprintf("vars.%s", name), string(vars[name]))
+	}
+	return query.Encode()
+}
+
+func (e *Environment) GetStepLink() string {
+	return getBuildUrl(envsource.OS)
+}
+
+func (e *Environment) GetBuildLink() string {
+	return getBuildUrl(envsource.OS)
+}
+
+func (e *Environment) GetFileLink(filename string, branch string, commit string) string {
+	return ""
+}
+
+func (e *Environment) GetFileLineLink(filename string, branch string, commit string, startLine int, endLine int) string {
+	return ""
+}
+
+func (e *Environment) IsCurrentEnvironment() bool {
+	_, isExist := os.LookupEnv(atcExternalUrlEnv)
+	return isExist
+}
+
+// DetectionVariables returns the variables used by IsCurrentEnvironment
+func (e *Environment) DetectionVariables() []string {
+	return []string{atcExternalUrlEnv}
+}
+
+// ExpectedFields returns the configuration fields the environment is expected to fill and the variables they are filled from,
+// the repository is read from the git checkout in the working directory
+func (e *Environment) ExpectedFields() []models.ExpectedField {
+	return []models.ExpectedField{
+		{Field: "repository.url", Severity: models.SeverityCritical},
+		{Field: "repository.cloneUrl", Severity: models.SeverityCritical},
+		{Field: "commitSha", Severity: models.SeverityCritical},
+		{Field: "organization.id", SourceEnv: teamNameEnv, Severity: models.SeverityWarning},
+		{Field: "pipeline.name", SourceEnv: pipelineNameEnv, Severity: models.SeverityWarning},
+		{Field: "job.name", SourceEnv: jobNameEnv, Severity: models.SeverityWarning},
+		{Field: "run.buildId", SourceEnv: buildIdEnv, Severity: models.SeverityWarning},
+		{Field: "run.buildNumber", SourceEnv: buildNameEnv, Severity: models.SeverityWarning},
+	}
+}
+
+func (e *Environment) Name() string {
+	return "concourse"
+}
+
+func getPipelinePaths(src envsource.EnvSource, rootDir string) []string {
+	paths := make([]string, 0)
+
+	for _, file := range concoursePipelineFiles {
+		path := filepath.Join(rootDir, file)
+		if _, err := envsource.Stat(src, path); err == nil {
+			paths = append(paths, path)
+		}
+	}
+
+	return paths
+}
@@ -0,0 +1,109 @@
+package concourse
+
+import (
+	"fmt"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/models"
+)
+
+var (
+	MockOrgName      = "test-org"
+	MockRepoName     = "test-repo"
+	MockAtcUrl       = "https://ci.example.com"
+	MockTeamName     = "main"
+	MockPipelineName = "test-repo"
+	MockJobName      = "unit"
+	MockBuildName    = "42"
+)
+
+var mockConfiguration *models.Configuration
+
+type EnvironmentMock struct{}
+
+func (em *EnvironmentMock) GetConfiguration() (*models.Configuration, error) {
+	if mockConfiguration == nil {
+		if err := loadMockConfiguration(); err != nil {
+			return nil, err
+		}
+	}
+	return mockConfiguration, nil
+}
+
+func (em *EnvironmentMock) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return em.GetConfiguration()
+}
+
+func (em *EnvironmentMock) Refresh() (*models.Configuration, error) {
+	em.Reset()
+	return em.GetConfiguration()
+}
+
+func (em *EnvironmentMock) Reset() {
+	mockConfiguration = nil
+}
+
+func loadMockConfiguration() error {
+	mockConfiguration = &models.Configuration{
+		Url:       MockAtcUrl,
+		SCMApiUrl: "https://api.github.com",
+		LocalPath: "/tmp/build/e55deab7/repo",
+		CommitSha: "2c6e3880fd94ddb7ef72d34e683cdc0c47bec6e6",
+		Branch:    "main",
+		Run: models.BuildRun{
+			BuildId:     "1234",
+			BuildNumber: MockBuildName,
+		},
+		Job: models.Entity{
+			Id:   MockJobName,
+			Name: MockJobName,
+		},
+		Pipeline: models.Pipeline{
+			Entity: models.Entity{
+				Id:   MockPipelineName,
+				Name: MockPipelineName,
+			},
+		},
+		Repository: models.Repository{
+			Name:     MockRepoName,
+			FullName: fmt.Sprintf("%s/%s", MockOrgName, MockRepoName),
+			Url:      fmt.Sprintf("https://github.com/%s/%s", MockOrgName, MockRepoName),
+			CloneUrl: fmt.Sprintf("https://github.com/%s/%s.git", MockOrgName, MockRepoName),
+			Source:   enums.Github,
+		},
+		Builder: "Concourse",
+		Organization: models.Entity{
+			Id:   MockTeamName,
+			Name: MockOrgName,
+		},
+		PipelinePaths: []string{"/tmp/build/e55deab7/repo/ci/pipeline.yml"},
+		Environment:   enums.Concourse,
+	}
+
+	return nil
+}
+
+func (em *EnvironmentMock) GetBuildLink() string {
+	return fmt.Sprintf("%s/teams/%s/pipelines/%s/jobs/%s/builds/%s", MockAtcUrl, MockTeamName, MockPipelineName, MockJobName, MockBuildName)
+}
+
+func (em *EnvironmentMock) GetStepLink() string {
+	return em.GetBuildLink()
+}
+
+func (em *EnvironmentMock) GetFileLink(filename string, branch string, commit string) string {
+	return ""
+}
+
+func (em *EnvironmentMock) GetFileLineLink(filename string, branch string, commit string, startLine int, endLine int) string {
+	return ""
+}
+
+func (em *EnvironmentMock) IsCurrentEnvironment() bool {
+	return true
+}
+
+func (em *EnvironmentMock) Name() string {
+	return "concourse"
+}
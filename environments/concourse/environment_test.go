@@ -0,0 +1,227 @@
+package concourse
+
+import (
+	"errors"
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/testutils"
+	"github.com/argonsecurity/go-environments/environments/testutils/mocks"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/models"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	concourseJobEnvsFilePath       = "testdata/concourse-job-env.json"
+	concourseInstancedEnvsFilePath = "testdata/concourse-instanced-env.json"
+	concourseOneOffEnvsFilePath    = "testdata/concourse-one-off-env.json"
+	testBuildPath                  = "/tmp/concourse/build"
+	testRepoPath                   = "/tmp/concourse/build/repo"
+	testRepoCloneUrl               = "https://github.com/test-org/test-repo.git"
+	testdataPath                   = "../concourse/testdata/repo"
+)
+
+func Test_environment_GetConfigurationFrom(t *testing.T) {
+	tests := []struct {
+		name         string
+		envsFilePath string
+		gitClient    *mocks.MockGitClient
+		want         *models.Configuration
+		wantErr      bool
+	}{
+		{
+			name:         "Concourse job configuration",
+			envsFilePath: concourseJobEnvsFilePath,
+			gitClient: (&mocks.MockGitClient{}).
+				SetRemoteUrl(testRepoCloneUrl).
+				SetCommit("2c6e3880fd94ddb7ef72d34e683cdc0c47bec6e6").
+				SetBranch("main"),
+			want: &models.Configuration{
+				Url:       "https://ci.example.com",
+				SCMApiUrl: "https://api.github.com",
+				LocalPath: testRepoPath,
+				CommitSha: "2c6e3880fd94ddb7ef72d34e683cdc0c47bec6e6",
+				Branch:    "main",
+				Repository: models.Repository{
+					Name:     "test-repo",
+					FullName: "test-org/test-repo",
+					Url:      "https://github.com/test-org/test-repo",
+					CloneUrl: testRepoCloneUrl,
+					Source:   enums.Github,
+				},
+				Organization: models.Entity{
+					Id:   "main",
+					Name: "test-org",
+				},
+				Pipeline: models.Pipeline{
+					Entity: models.Entity{
+						Id:   "test-repo",
+						Name: "test-repo",
+					},
+				},
+				Job: models.Entity{
+					Id:   "unit",
+					Name: "unit",
+				},
+				Run: models.BuildRun{
+					BuildId:     "1234",
+					BuildNumber: "42",
+				},
+				Runner: models.Runner{
+					Name:         "a1b2c3d4-e5f6-47a8-9b0c-1d2e3f4a5b6c",
+					OS:           runtime.GOOS,
+					Architecture: runtime.GOARCH,
+				},
+				Builder:       "Concourse",
+				PipelinePaths: []string{"/tmp/concourse/build/repo/ci/pipeline.yml"},
+				Environment:   enums.Concourse,
+				ScmId:         "b30f418cdcc9970849d3d031de5df54f",
+			},
+		},
+		{
+			name:         "Concourse one-off build configuration",
+			envsFilePath: concourseOneOffEnvsFilePath,
+			gitClient: (&mocks.MockGitClient{}).
+				SetRemoteUrl("git@gitlab.com:test-group/test-repo.git").
+				SetCommit("fe8c38a965d13d9794eb36918cb24cebe49a45c2").
+				SetBranch("feature/test"),
+			want: &models.Configuration{
+				Url:       "https://ci.example.com",
+				SCMApiUrl: "https://gitlab.com/api/v4",
+				LocalPath: testRepoPath,
+				CommitSha: "fe8c38a965d13d9794eb36918cb24cebe49a45c2",
+				Branch:    "feature/test",
+				Repository: models.Repository{
+					Name:     "test-repo",
+					FullName: "test-group/test-repo",
+					Url:      "https://gitlab.com/test-group/test-repo",
+					CloneUrl: "git@gitlab.com:test-group/test-repo.git",
+					Source:   enums.Gitlab,
+				},
+				Organization: models.Entity{
+					Id:   "main",
+					Name: "test-group",
+				},
+				Run: models.BuildRun{
+					BuildId:     "1302",
+					BuildNumber: "1302",
+				},
+				Runner: models.Runner{
+					OS:           runtime.GOOS,
+					Architecture: runtime.GOARCH,
+				},
+				Pusher: models.Pusher{
+					Username: "test-user",
+				},
+				Builder:       "Concourse",
+				PipelinePaths: []string{"/tmp/concourse/build/repo/ci/pipeline.yml"},
+				Environment:   enums.Concourse,
+				ScmId:         "5992c2ba95a922426aae55f34afec4ed",
+			},
+		},
+		{
+			name:         "Missing git checkout",
+			envsFilePath: concourseJobEnvsFilePath,
+			gitClient:    (&mocks.MockGitClient{}).SetError(errors.New("not a git repository")),
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			envs := testutils.LoadEnvsFromFile(tt.envsFilePath)
+			e := prepareTest(t, "")
+			src := envsource.New(envs).WithWorkingDirectory(testBuildPath).WithGitClient(tt.gitClient)
+			got, err := e.GetConfigurationFrom(src)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("environment.GetConfigurationFrom() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_environment_GetBuildLink(t *testing.T) {
+	tests := []struct {
+		name         string
+		envsFilePath string
+		want         string
+	}{
+		{
+			name:         "Job build",
+			envsFilePath: concourseJobEnvsFilePath,
+			want:         "https://ci.example.com/teams/main/pipelines/test-repo/jobs/unit/builds/42",
+		},
+		{
+			name:         "Instanced pipeline build",
+			envsFilePath: concourseInstancedEnvsFilePath,
+			want:         "https://ci.example.com/teams/main/pipelines/test-repo/jobs/unit/builds/7?vars.branch=%22feature%2Ftest%22&vars.env=%7B%22name%22%3A%22staging%22%7D",
+		},
+		{
+			name:         "One-off build",
+			envsFilePath: concourseOneOffEnvsFilePath,
+			want:         "https://ci.example.com/builds/1302",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := prepareTest(t, tt.envsFilePath)
+			assert.Equal(t, tt.want, e.GetBuildLink())
+			assert.Equal(t, tt.want, e.GetStepLink())
+		})
+	}
+}
+
+func Test_environment_IsCurrentEnvironment(t *testing.T) {
+	tests := []struct {
+		name         string
+		envsFilePath string
+		want         bool
+	}{
+		{
+			name:         "Concourse environment",
+			envsFilePath: concourseJobEnvsFilePath,
+			want:         true,
+		},
+		{
+			name:         "Jenkins environment",
+			envsFilePath: "../jenkins/testdata/jenkins-github-main-full-env.json",
+			want:         false,
+		},
+		{
+			name:         "Not Concourse environment",
+			envsFilePath: "",
+			want:         false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := prepareTest(t, tt.envsFilePath)
+			if got := e.IsCurrentEnvironment(); got != tt.want {
+				t.Errorf("environment.IsCurrentEnvironment() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// prepareTest clones the test repository as an input of the build directory and runs the test in the build directory
+func prepareTest(t *testing.T, envsFilePath string) *Environment {
+	e := New()
+	testRepoCleanup := testutils.PrepareTestGitRepository(testRepoPath, testRepoCloneUrl, testdataPath)
+	t.Cleanup(testRepoCleanup)
+	envCleanup := testutils.SetEnvsFromFile(envsFilePath)
+	t.Cleanup(envCleanup)
+
+	workingDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(testBuildPath); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(workingDir) })
+	return e
+}
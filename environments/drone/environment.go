@@ -0,0 +1,280 @@
+package drone
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/utils"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/models"
+)
+
+const (
+	builder  = "Drone"
+	droneEnv = "DRONE"
+
+	// woodpeckerCIEnv is set to woodpecker by Woodpecker, which also sets the DRONE variables for compatibility
+	woodpeckerCIEnv = "CI"
+	woodpecker      = "woodpecker"
+
+	// harnessBuildIdEnv is set by Harness CI, which also sets the DRONE variables
+	harnessBuildIdEnv = "HARNESS_BUILD_ID"
+
+	systemProtoEnv = "DRONE_SYSTEM_PROTO"
+	systemHostEnv  = "DRONE_SYSTEM_HOST"
+
+	repositoryLinkEnv     = "DRONE_REPO_LINK"
+	repositoryCloneUrlEnv = "DRONE_GIT_HTTP_URL"
+	repositoryPathEnv     = "DRONE_WORKSPACE"
+
+	commitShaEnv        = "DRONE_COMMIT_SHA"
+	commitBeforeEnv     = "DRONE_COMMIT_BEFORE"
+	commitAuthorEnv     = "DRONE_COMMIT_AUTHOR"
+	commitAuthorMailEnv = "DRONE_COMMIT_AUTHOR_EMAIL"
+	branchEnv           = "DRONE_BRANCH"
+
+	pullRequestEnv  = "DRONE_PULL_REQUEST"
+	sourceBranchEnv = "DRONE_SOURCE_BRANCH"
+	targetBranchEnv = "DRONE_TARGET_BRANCH"
+
+	buildNumberEnv = "DRONE_BUILD_NUMBER"
+	buildLinkEnv   = "DRONE_BUILD_LINK"
+
+	runnerHostnameEnv = "DRONE_RUNNER_HOSTNAME"
+
+	stageNameEnv    = "DRONE_STAGE_NAME"
+	stageNumberEnv  = "DRONE_STAGE_NUMBER"
+	stageMachineEnv = "DRONE_STAGE_MACHINE"
+	stageOSEnv      = "DRONE_STAGE_OS"
+	stageArchEnv    = "DRONE_STAGE_ARCH"
+	stepNameEnv     = "DRONE_STEP_NAME"
+	stepNumberEnv   = "DRONE_STEP_NUMBER"
+
+	// pipelineFileEnv is the configuration file of the repository when it is not .drone.yml
+	pipelineFileEnv   = "DRONE_YAML"
+	dronePipelineFile = ".drone.yml"
+)
+
+var (
+	// Drone environment
+	Drone = New()
+)
+
+// Environment is the Drone environment
+type Environment struct {
+	cache utils.ConfigurationCache
+}
+
+// New creates a Drone environment with its own configuration cache
+func New() *Environment {
+	return &Environment{}
+}
+
+func (e *Environment) GetConfiguration() (*models.Configuration, error) {
+	return e.cache.Get(e.load)
+}
+
+// Refresh loads the configuration again and replaces the cached configuration
+func (e *Environment) Refresh() (*models.Configuration, error) {
+	return e.cache.Refresh(e.load)
+}
+
+// Reset clears the cached configuration, it is loaded again on the next GetConfiguration
+func (e *Environment) Reset() {
+	e.cache.Reset()
+}
+
+func (e *Environment) load() (*models.Configuration, error) {
+	return loadConfiguration(envsource.OS)
+}
+
+func (e *Environment) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return loadConfiguration(src)
+}
+
+func loadConfiguration(src envsource.EnvSource) (*models.Configuration, error) {
+	cloneUrl := utils.StripCredentialsFromUrl(src.Getenv(repositoryCloneUrlEnv))
+	source, apiUrl := utils.GetRepositorySource(cloneUrl)
+	repoUrl, org, repoName, repoFullName, err := utils.ParseDataFromCloneUrl(cloneUrl, apiUrl, source)
+	if err != nil {
+		return nil, err
+	}
+	if link := src.Getenv(repositoryLinkEnv); link != "" {
+		repoUrl = link
+	}
+
+	repoPath := src.Getenv(repositoryPathEnv)
+	commit := src.Getenv(commitShaEnv)
+	branch := src.Getenv(branchEnv)
+
+	pullRequest := models.PullRequest{}
+	if prNumber := src.Getenv(pullRequestEnv); prNumber != "" {
+		// on pull request builds DRONE_BRANCH is the target branch of the pull request
+		branch = src.Getenv(sourceBranchEnv)
+		pullRequest = models.PullRequest{
+			Id: prNumber,
+			SourceRef: models.Ref{
+				Branch: branch,
+				Sha:    commit,
+			},
+			TargetRef: models.Ref{
+				Branch: src.Getenv(targetBranchEnv),
+			},
+		}
+	}
+
+	return &models.Configuration{
+		Url:             getDroneUrl(src.Getenv(systemProtoEnv), src.Getenv(systemHostEnv)),
+		SCMApiUrl:       apiUrl,
+		LocalPath:       repoPath,
+		CommitSha:       commit,
+		BeforeCommitSha: src.Getenv(commitBeforeEnv),
+		Branch:          branch,
+		Repository: models.Repository{
+			Name:     repoName,
+			FullName: repoFullName,
+			Url:      repoUrl,
+			CloneUrl: cloneUrl,
+			Source:   source,
+		},
+		Organization: models.Entity{
+			Name: org,
+		},
+		Pipeline: models.Pipeline{
+			Entity: models.Entity{
+				Id:   src.Getenv(stageNumberEnv),
+				Name: src.Getenv(stageNameEnv),
+			},
+			Path: getPipelinePath(src, repoPath),
+		},
+		Job: models.Entity{
+			Id:   src.Getenv(stepNumberEnv),
+			Name: src.Getenv(stepNameEnv),
+		},
+		Run: models.BuildRun{
+			BuildId:     src.Getenv(buildNumberEnv),
+			BuildNumber: src.Getenv(buildNumberEnv),
+		},
+		Runner: models.Runner{
+			Name:         getRunnerName(src),
+			OS:           src.Getenv(stageOSEnv),
+			Architecture: src.Getenv(stageArchEnv),
+		},
+		PullRequest:   pullRequest,
+		Builder:       builder,
+		Pusher:        getPusher(src),
+		PipelinePaths: getPipelinePaths(src, repoPath),
+		Environment:   enums.Drone,
+		ScmId:         utils.GenerateScmId(cloneUrl),
+	}, nil
+}
+
+func getDroneUrl(proto string, host string) string {
+	if host == "" {
+		return ""
+	}
+	if proto == "" {
+		proto = "https"
+	}
+	return fmt.Sprintf("%s://%s", proto, host)
+}
+
+// getRunnerName returns the host name of the runner, or the machine of the stage on runners that don't set it
+func getRunnerName(src envsource.EnvSource) string {
+	if hostname := src.Getenv(runnerHostnameEnv); hostname != "" {
+		return hostname
+	}
+	return src.Getenv(stageMachineEnv)
+}
+
+func getPusher(src envsource.EnvSource) models.Pusher {
+	if author := src.Getenv(commitAuthorEnv); author != "" {
+		return models.Pusher{
+			Username: author,
+			Email:    src.Getenv(commitAuthorMailEnv),
+		}
+	}
+	return models.Pusher{
+		Username: utils.DetectPusherFrom(src),
+	}
+}
+
+func (e *Environment) GetStepLink() string {
+	if os.Getenv(buildLinkEnv) == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s/%s", os.Getenv(buildLinkEnv), os.Getenv(stageNumberEnv), os.Getenv(stepNumberEnv))
+}
+
+func (e *Environment) GetBuildLink() string {
+	return os.Getenv(buildLinkEnv)
+}
+
+func (e *Environment) GetFileLink(filename string, branch string, commit string) string {
+	return ""
+}
+
+func (e *Environment) GetFileLineLink(filename string, branch string, commit string, startLine int, endLine int) string {
+	return ""
+}
+
+// IsCurrentEnvironment checks for the DRONE variable, unless the build runs on Woodpecker or Harness
+func (e *Environment) IsCurrentEnvironment() bool {
+	if os.Getenv(woodpeckerCIEnv) == woodpecker {
+		return false
+	}
+	if _, isHarness := os.LookupEnv(harnessBuildIdEnv); isHarness {
+		return false
+	}
+	_, isExist := os.LookupEnv(droneEnv)
+	return isExist
+}
+
+// DetectionVariables returns the variables used by IsCurrentEnvironment
+func (e *Environment) DetectionVariables() []string {
+	return []string{droneEnv}
+}
+
+// ExpectedFields returns the configuration fields the environment is expected to fill and the variables they are filled from
+func (e *Environment) ExpectedFields() []models.ExpectedField {
+	return []models.ExpectedField{
+		{Field: "repository.url", SourceEnv: repositoryLinkEnv, Severity: models.SeverityCritical},
+		{Field: "repository.cloneUrl", SourceEnv: repositoryCloneUrlEnv, Severity: models.SeverityCritical},
+		{Field: "commitSha", SourceEnv: commitShaEnv, Severity: models.SeverityCritical},
+		{Field: "branch", SourceEnv: branchEnv, Severity: models.SeverityWarning},
+		{Field: "url", SourceEnv: systemHostEnv, Severity: models.SeverityWarning},
+		{Field: "localPath", SourceEnv: repositoryPathEnv, Severity: models.SeverityWarning},
+		{Field: "pipeline.name", SourceEnv: stageNameEnv, Severity: models.SeverityWarning},
+		{Field: "job.name", SourceEnv: stepNameEnv, Severity: models.SeverityWarning},
+		{Field: "run.buildNumber", SourceEnv: buildNumberEnv, Severity: models.SeverityWarning},
+		{Field: "runner.name", SourceEnv: runnerHostnameEnv, Severity: models.SeverityWarning},
+	}
+}
+
+func (e *Environment) Name() string {
+	return "drone"
+}
+
+func getPipelinePaths(src envsource.EnvSource, rootDir string) []string {
+	paths := make([]string, 0)
+
+	if pipelinePath := getPipelinePath(src, rootDir); pipelinePath != "" {
+		paths = append(paths, filepath.Join(rootDir, pipelinePath))
+	}
+
+	return paths
+}
+
+// getPipelinePath returns the path of the configuration file relative to the repository, or an empty string when the repository has none
+func getPipelinePath(src envsource.EnvSource, rootDir string) string {
+	pipelineFile := src.Getenv(pipelineFileEnv)
+	if pipelineFile == "" {
+		pipelineFile = dronePipelineFile
+	}
+	if _, err := envsource.Stat(src, filepath.Join(rootDir, pipelineFile)); err != nil {
+		return ""
+	}
+	return pipelineFile
+}
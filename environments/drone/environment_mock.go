@@ -0,0 +1,111 @@
+package drone
+
+import (
+	"fmt"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/models"
+)
+
+var (
+	MockOrgName     = "test-org"
+	MockRepoName    = "test-repo"
+	MockServerUrl   = "https://drone.example.com"
+	MockBuildNumber = "42"
+)
+
+var mockConfiguration *models.Configuration
+
+type EnvironmentMock struct{}
+
+func (em *EnvironmentMock) GetConfiguration() (*models.Configuration, error) {
+	if mockConfiguration == nil {
+		if err := loadMockConfiguration(); err != nil {
+			return nil, err
+		}
+	}
+	return mockConfiguration, nil
+}
+
+func (em *EnvironmentMock) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return em.GetConfiguration()
+}
+
+func (em *EnvironmentMock) Refresh() (*models.Configuration, error) {
+	em.Reset()
+	return em.GetConfiguration()
+}
+
+func (em *EnvironmentMock) Reset() {
+	mockConfiguration = nil
+}
+
+func loadMockConfiguration() error {
+	mockConfiguration = &models.Configuration{
+		Url:       MockServerUrl,
+		SCMApiUrl: "https://api.github.com",
+		LocalPath: "/drone/src",
+		CommitSha: "3s32e4s818c6d1s5a0f585sf73112673a9bfcfc7",
+		Branch:    "main",
+		Run: models.BuildRun{
+			BuildId:     MockBuildNumber,
+			BuildNumber: MockBuildNumber,
+		},
+		Job: models.Entity{
+			Id:   "2",
+			Name: "test",
+		},
+		Pipeline: models.Pipeline{
+			Entity: models.Entity{
+				Id:   "1",
+				Name: "default",
+			},
+			Path: ".drone.yml",
+		},
+		Runner: models.Runner{
+			Name:         "runner-1.example.com",
+			OS:           "linux",
+			Architecture: "amd64",
+		},
+		Repository: models.Repository{
+			Name:     MockRepoName,
+			FullName: fmt.Sprintf("%s/%s", MockOrgName, MockRepoName),
+			Url:      fmt.Sprintf("https://github.com/%s/%s", MockOrgName, MockRepoName),
+			CloneUrl: fmt.Sprintf("https://github.com/%s/%s.git", MockOrgName, MockRepoName),
+			Source:   enums.Github,
+		},
+		Builder: "Drone",
+		Organization: models.Entity{
+			Name: MockOrgName,
+		},
+		PipelinePaths: []string{"/drone/src/.drone.yml"},
+		Environment:   enums.Drone,
+	}
+
+	return nil
+}
+
+func (em *EnvironmentMock) GetBuildLink() string {
+	return fmt.Sprintf("%s/%s/%s/%s", MockServerUrl, MockOrgName, MockRepoName, MockBuildNumber)
+}
+
+func (em *EnvironmentMock) GetStepLink() string {
+	return fmt.Sprintf("%s/1/2", em.GetBuildLink())
+}
+
+func (em *EnvironmentMock) GetFileLink(filename string, branch string, commit string) string {
+	return ""
+}
+
+func (em *EnvironmentMock) GetFileLineLink(filename string, branch string, commit string, startLine int, endLine int) string {
+	return ""
+}
+
+func (em *EnvironmentMock) IsCurrentEnvironment() bool {
+	return true
+}
+
+func (em *EnvironmentMock) Name() string {
+	return "drone"
+}
@@ -0,0 +1,248 @@
+package drone
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/testutils"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/models"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	dronePushEnvsFilePath = "testdata/drone-github-push-env.json"
+	dronePrEnvsFilePath   = "testdata/drone-github-pr-env.json"
+	testRepoPath          = "/tmp/drone/repo"
+	testRepoUrl           = "https://github.com/test-organization/test-repo"
+	testRepoCloneUrl      = fmt.Sprintf("%s%s", testRepoUrl, ".git")
+	testdataPath          = "../drone/testdata/repo"
+)
+
+func Test_environment_GetConfiguration(t *testing.T) {
+	tests := []struct {
+		name         string
+		envsFilePath string
+		want         *models.Configuration
+		wantErr      bool
+	}{
+		{
+			name:         "Drone push configuration",
+			envsFilePath: dronePushEnvsFilePath,
+			want: &models.Configuration{
+				Url:             "https://drone.example.com",
+				SCMApiUrl:       "https://api.github.com",
+				LocalPath:       testRepoPath,
+				CommitSha:       "kcy8v2oazy4acuo1475rgtzg0nh403p23vw812lv",
+				BeforeCommitSha: "2c6e3880fd94ddb7ef72d34e683cdc0c47bec6e6",
+				Branch:          "main",
+				Repository: models.Repository{
+					Name:     "test-repo",
+					FullName: "test-organization/test-repo",
+					Url:      testRepoUrl,
+					CloneUrl: testRepoCloneUrl,
+					Source:   enums.Github,
+				},
+				Organization: models.Entity{
+					Name: "test-organization",
+				},
+				Pipeline: models.Pipeline{
+					Entity: models.Entity{
+						Id:   "1",
+						Name: "default",
+					},
+					Path: ".drone.yml",
+				},
+				Job: models.Entity{
+					Id:   "2",
+					Name: "test",
+				},
+				Run: models.BuildRun{
+					BuildId:     "42",
+					BuildNumber: "42",
+				},
+				Runner: models.Runner{
+					Name:         "drone-runner-1",
+					OS:           "linux",
+					Architecture: "amd64",
+				},
+				Pusher: models.Pusher{
+					Username: "test-user",
+					Email:    "test-user@example.com",
+				},
+				Builder:       "Drone",
+				PipelinePaths: []string{"/tmp/drone/repo/.drone.yml"},
+				Environment:   enums.Drone,
+				ScmId:         "8891c0db39f3064732cc1b4ac02c9b9f",
+			},
+		},
+		{
+			name:         "Drone pull request configuration",
+			envsFilePath: dronePrEnvsFilePath,
+			want: &models.Configuration{
+				Url:             "https://drone.example.com",
+				SCMApiUrl:       "https://api.github.com",
+				LocalPath:       testRepoPath,
+				CommitSha:       "y2nc0ns6qk8qs4dfbsvnjrf2o8dvmrfrthmx9nbl",
+				BeforeCommitSha: "kcy8v2oazy4acuo1475rgtzg0nh403p23vw812lv",
+				Branch:          "feature/drone",
+				Repository: models.Repository{
+					Name:     "test-repo",
+					FullName: "test-organization/test-repo",
+					Url:      testRepoUrl,
+					CloneUrl: testRepoCloneUrl,
+					Source:   enums.Github,
+				},
+				Organization: models.Entity{
+					Name: "test-organization",
+				},
+				Pipeline: models.Pipeline{
+					Entity: models.Entity{
+						Id:   "1",
+						Name: "default",
+					},
+					Path: ".drone.yml",
+				},
+				Job: models.Entity{
+					Id:   "2",
+					Name: "test",
+				},
+				Run: models.BuildRun{
+					BuildId:     "43",
+					BuildNumber: "43",
+				},
+				Runner: models.Runner{
+					Name:         "runner-2.example.com",
+					OS:           "linux",
+					Architecture: "arm64",
+				},
+				PullRequest: models.PullRequest{
+					Id: "7",
+					SourceRef: models.Ref{
+						Branch: "feature/drone",
+						Sha:    "y2nc0ns6qk8qs4dfbsvnjrf2o8dvmrfrthmx9nbl",
+					},
+					TargetRef: models.Ref{
+						Branch: "main",
+					},
+				},
+				Builder:       "Drone",
+				PipelinePaths: []string{"/tmp/drone/repo/.drone.yml"},
+				Environment:   enums.Drone,
+				ScmId:         "8891c0db39f3064732cc1b4ac02c9b9f",
+			},
+		},
+		{
+			name:         "Missing clone url",
+			envsFilePath: "",
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := prepareTest(t, tt.envsFilePath)
+			got, err := e.GetConfiguration()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("environment.GetConfiguration() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_environment_GetStepLink(t *testing.T) {
+	tests := []struct {
+		name         string
+		envsFilePath string
+		want         string
+	}{
+		{
+			name:         "Drone environment",
+			envsFilePath: dronePushEnvsFilePath,
+			want:         "https://drone.example.com/test-organization/test-repo/42/1/2",
+		},
+		{
+			name:         "Not Drone environment",
+			envsFilePath: "",
+			want:         "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := prepareTest(t, tt.envsFilePath)
+			if got := e.GetStepLink(); got != tt.want {
+				t.Errorf("environment.GetStepLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_environment_GetBuildLink(t *testing.T) {
+	e := prepareTest(t, dronePushEnvsFilePath)
+	assert.Equal(t, "https://drone.example.com/test-organization/test-repo/42", e.GetBuildLink())
+}
+
+func Test_environment_IsCurrentEnvironment(t *testing.T) {
+	tests := []struct {
+		name         string
+		envsFilePath string
+		want         bool
+	}{
+		{
+			name:         "Drone environment",
+			envsFilePath: dronePushEnvsFilePath,
+			want:         true,
+		},
+		{
+			name:         "Woodpecker environment with Drone compatibility variables",
+			envsFilePath: "../woodpecker/testdata/woodpecker-github-pr-env.json",
+			want:         false,
+		},
+		{
+			name:         "Harness environment with Drone compatibility variables",
+			envsFilePath: "../harness/testdata/harness-github-pr-env.json",
+			want:         false,
+		},
+		{
+			name:         "GitLab environment",
+			envsFilePath: "../gitlab/testdata/gitlab-ci-main-env.json",
+			want:         false,
+		},
+		{
+			name:         "Not Drone environment",
+			envsFilePath: "",
+			want:         false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := prepareTest(t, tt.envsFilePath)
+			if got := e.IsCurrentEnvironment(); got != tt.want {
+				t.Errorf("environment.IsCurrentEnvironment() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getPipelinePath(t *testing.T) {
+	rootDir := t.TempDir()
+	assert.Equal(t, "", getPipelinePath(envsource.New(nil), rootDir))
+
+	assert.NoError(t, os.WriteFile(filepath.Join(rootDir, "ci.yml"), []byte("kind: pipeline\n"), 0644))
+	src := envsource.New(map[string]string{pipelineFileEnv: "ci.yml"})
+	assert.Equal(t, "ci.yml", getPipelinePath(src, rootDir))
+	assert.Equal(t, []string{filepath.Join(rootDir, "ci.yml")}, getPipelinePaths(src, rootDir))
+}
+
+func prepareTest(t *testing.T, envsFilePath string) *Environment {
+	e := New()
+	testRepoCleanup := testutils.PrepareTestGitRepository(testRepoPath, testRepoCloneUrl, testdataPath)
+	t.Cleanup(testRepoCleanup)
+	envCleanup := testutils.SetEnvsFromFile(envsFilePath)
+	t.Cleanup(envCleanup)
+	return e
+}
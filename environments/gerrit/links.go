@@ -0,0 +1,88 @@
+package gerrit
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/argonsecurity/go-environments/environments/utils"
+)
+
+// gitilesPath is the path of the Gitiles plugin, that browses the repositories of a Gerrit server
+const gitilesPath = "/plugins/gitiles/"
+
+// GetFileLink returns the Gitiles link to a file, Gitiles serves files under +/<ref>/<path>
+func GetFileLink(repositoryURL string, filename string, branch string, commit string) string {
+	ref := commit
+	if ref == "" {
+		if branch == "" {
+			return ""
+		}
+		ref = fmt.Sprintf("refs/heads/%s", branch)
+	}
+
+	return fmt.Sprintf("%s/+/%s/%s",
+		strings.TrimSuffix(repositoryURL, "/"),
+		ref,
+		filename)
+}
+
+// GetFileLineLink returns the Gitiles link to a line of a file, Gitiles anchors a single line so the end line is ignored
+func GetFileLineLink(repositoryURL string, filename string, branch string, commit string, startLine int, endLine int) string {
+	url := GetFileLink(repositoryURL, filename, branch, commit)
+	if url != "" && startLine != 0 {
+		url += fmt.Sprintf("#%d", startLine)
+	}
+
+	return url
+}
+
+// GetChangeLink returns the link to the change that a commit belongs to, Gerrit redirects a query by commit to its change
+func GetChangeLink(repositoryURL string, commit string) string {
+	serverUrl := GetServerUrl(repositoryURL)
+	if serverUrl == "" || commit == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("%s/q/%s", serverUrl, commit)
+}
+
+// GetServerUrl returns the Gerrit server url of a Gitiles repository url,
+// i.e. https://gerrit.company.com/r for https://gerrit.company.com/r/plugins/gitiles/platform/build
+func GetServerUrl(repositoryURL string) string {
+	if index := strings.Index(repositoryURL, gitilesPath); index != -1 {
+		return repositoryURL[:index]
+	}
+
+	urlObject, err := url.Parse(repositoryURL)
+	if err != nil || urlObject.Host == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s://%s", urlObject.Scheme, urlObject.Host)
+}
+
+var (
+	// https://gerrit.company.com/plugins/gitiles/project/+/<commit|refs/heads/branch>/path/to/file
+	fileLinkRegexp = regexp.MustCompile(`^(.+?)/\+/(refs/heads/[^/]+|[^/]+)/(.+)$`)
+	// #10, Gitiles anchors a single line
+	linesRegexp = regexp.MustCompile(`^(\d+)$`)
+)
+
+// ParseFileLineLink returns the repository url, the file, the ref and the line of a link built by GetFileLineLink,
+// the ref is refs/heads/<branch> for a branch link and the commit for a 40 character commit sha. The end line is the start line
+// Branches with slashes can't be told from the file, the ref is read up to the first slash
+func ParseFileLineLink(link string) (string, string, string, int, int, error) {
+	link, fragment, _ := strings.Cut(link, "#")
+	result := fileLinkRegexp.FindStringSubmatch(link)
+	if result == nil {
+		return "", "", "", 0, 0, fmt.Errorf("could not parse file link: %s", link)
+	}
+
+	startLine := 0
+	if lines := linesRegexp.FindStringSubmatch(fragment); lines != nil {
+		startLine, _ = strconv.Atoi(lines[1])
+	}
+	return result[1], result[3], utils.GetLinkRef(result[2]), startLine, startLine, nil
+}
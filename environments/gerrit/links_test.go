@@ -0,0 +1,243 @@
+package gerrit
+
+import (
+	"testing"
+)
+
+const (
+	testRepoURL  = "https://gerrit.company.com/plugins/gitiles/platform/build"
+	testFilename = "path/to/file"
+	testBranch   = "branch"
+	testCommit   = "commit"
+)
+
+func TestGetFileLink(t *testing.T) {
+	type args struct {
+		repositoryURL string
+		filename      string
+		branch        string
+		commit        string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "With branch",
+			args: args{
+				repositoryURL: testRepoURL,
+				filename:      testFilename,
+				branch:        testBranch,
+			},
+			want: "https://gerrit.company.com/plugins/gitiles/platform/build/+/refs/heads/branch/path/to/file",
+		},
+		{
+			name: "With commit",
+			args: args{
+				repositoryURL: testRepoURL,
+				filename:      testFilename,
+				commit:        testCommit,
+			},
+			want: "https://gerrit.company.com/plugins/gitiles/platform/build/+/commit/path/to/file",
+		},
+		{
+			name: "With branch and commit",
+			args: args{
+				repositoryURL: testRepoURL,
+				filename:      testFilename,
+				branch:        testBranch,
+				commit:        testCommit,
+			},
+			want: "https://gerrit.company.com/plugins/gitiles/platform/build/+/commit/path/to/file",
+		},
+		{
+			name: "Without branch and commit",
+			args: args{
+				repositoryURL: testRepoURL,
+				filename:      testFilename,
+			},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetFileLink(tt.args.repositoryURL, tt.args.filename, tt.args.branch, tt.args.commit); got != tt.want {
+				t.Errorf("GetFileLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetFileLineLink(t *testing.T) {
+	type args struct {
+		repositoryURL string
+		filename      string
+		branch        string
+		commit        string
+		startLine     int
+		endLine       int
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "No lines",
+			args: args{
+				repositoryURL: testRepoURL,
+				filename:      testFilename,
+				branch:        testBranch,
+			},
+			want: "https://gerrit.company.com/plugins/gitiles/platform/build/+/refs/heads/branch/path/to/file",
+		},
+		{
+			name: "Only start line",
+			args: args{
+				repositoryURL: testRepoURL,
+				filename:      testFilename,
+				commit:        testCommit,
+				startLine:     1,
+			},
+			want: "https://gerrit.company.com/plugins/gitiles/platform/build/+/commit/path/to/file#1",
+		},
+		{
+			name: "With start line and end line",
+			args: args{
+				repositoryURL: testRepoURL,
+				filename:      testFilename,
+				branch:        testBranch,
+				commit:        testCommit,
+				startLine:     1,
+				endLine:       2,
+			},
+			want: "https://gerrit.company.com/plugins/gitiles/platform/build/+/commit/path/to/file#1",
+		},
+		{
+			name: "Without branch and commit",
+			args: args{
+				repositoryURL: testRepoURL,
+				filename:      testFilename,
+				startLine:     1,
+			},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetFileLineLink(tt.args.repositoryURL, tt.args.filename, tt.args.branch, tt.args.commit, tt.args.startLine, tt.args.endLine); got != tt.want {
+				t.Errorf("GetFileLineLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetChangeLink(t *testing.T) {
+	type args struct {
+		repositoryURL string
+		commit        string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "Gitiles plugin repository url",
+			args: args{
+				repositoryURL: testRepoURL,
+				commit:        testCommit,
+			},
+			want: "https://gerrit.company.com/q/commit",
+		},
+		{
+			name: "Gitiles plugin repository url with a context path",
+			args: args{
+				repositoryURL: "https://company.com/r/plugins/gitiles/platform/build",
+				commit:        testCommit,
+			},
+			want: "https://company.com/r/q/commit",
+		},
+		{
+			name: "Standalone Gitiles repository url",
+			args: args{
+				repositoryURL: "https://gerrit.company.com/platform/build",
+				commit:        testCommit,
+			},
+			want: "https://gerrit.company.com/q/commit",
+		},
+		{
+			name: "Without commit",
+			args: args{
+				repositoryURL: testRepoURL,
+			},
+			want: "",
+		},
+		{
+			name: "Empty repo url",
+			args: args{
+				commit: testCommit,
+			},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetChangeLink(tt.args.repositoryURL, tt.args.commit); got != tt.want {
+				t.Errorf("GetChangeLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFileLineLink(t *testing.T) {
+	tests := []struct {
+		name              string
+		link              string
+		wantRepositoryURL string
+		wantFilename      string
+		wantRef           string
+		wantStartLine     int
+		wantEndLine       int
+		wantErr           bool
+	}{
+		{
+			name:              "Branch line",
+			link:              "https://gerrit.company.com/plugins/gitiles/platform/build/+/refs/heads/branch/path/to/file#10",
+			wantRepositoryURL: "https://gerrit.company.com/plugins/gitiles/platform/build",
+			wantFilename:      "path/to/file",
+			wantRef:           "refs/heads/branch",
+			wantStartLine:     10,
+			wantEndLine:       10,
+		},
+		{
+			name:              "Commit",
+			link:              "https://gerrit.company.com/plugins/gitiles/platform/build/+/0a1b2c3d4e5f60718293a4b5c6d7e8f901234567/path/to/file",
+			wantRepositoryURL: "https://gerrit.company.com/plugins/gitiles/platform/build",
+			wantFilename:      "path/to/file",
+			wantRef:           "0a1b2c3d4e5f60718293a4b5c6d7e8f901234567",
+			wantStartLine:     0,
+			wantEndLine:       0,
+		},
+		{
+			name:    "Not a file link",
+			link:    "https://gerrit.company.com/plugins/gitiles/platform/build",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repositoryURL, filename, ref, startLine, endLine, err := ParseFileLineLink(tt.link)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFileLineLink() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if repositoryURL != tt.wantRepositoryURL || filename != tt.wantFilename || ref != tt.wantRef {
+				t.Errorf("ParseFileLineLink() = %v, %v, %v, want %v, %v, %v", repositoryURL, filename, ref, tt.wantRepositoryURL, tt.wantFilename, tt.wantRef)
+			}
+			if startLine != tt.wantStartLine || endLine != tt.wantEndLine {
+				t.Errorf("ParseFileLineLink() lines = %v-%v, want %v-%v", startLine, endLine, tt.wantStartLine, tt.wantEndLine)
+			}
+		})
+	}
+}
@@ -0,0 +1,389 @@
+package gitea
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/github"
+	"github.com/argonsecurity/go-environments/environments/utils"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/http"
+	"github.com/argonsecurity/go-environments/models"
+)
+
+const (
+	builder = "Gitea Actions"
+
+	// Gitea and Forgejo runners set their own marker variables,
+	// the other variables use the GitHub Actions names
+	giteaActionsEnv   = "GITEA_ACTIONS"
+	forgejoActionsEnv = "FORGEJO_ACTIONS"
+
+	repositoryEnv     = "GITHUB_REPOSITORY"
+	serverUrlEnv      = "GITHUB_SERVER_URL"
+	apiUrlEnv         = "GITHUB_API_URL"
+	workflowEnv       = "GITHUB_WORKFLOW"
+	runIdEnv          = "GITHUB_RUN_ID"
+	runNumberEnv      = "GITHUB_RUN_NUMBER"
+	repositoryPathEnv = "GITHUB_WORKSPACE"
+	jobEnv            = "GITHUB_JOB"
+	branchEnv         = "GITHUB_REF"
+	commitShaEnv      = "GITHUB_SHA"
+	eventPathEnv      = "GITHUB_EVENT_PATH"
+	eventNameEnv      = "GITHUB_EVENT_NAME"
+
+	baseBranchNameEnv = "GITHUB_BASE_REF"
+	headBranchNameEnv = "GITHUB_HEAD_REF"
+
+	runnerNameEnv = "RUNNER_NAME"
+	runnerOSEnv   = "RUNNER_OS"
+
+	githubServerUrl = "https://github.com"
+	giteaApiPath    = "/api/v1"
+	versionApiPath  = "/api/v1/version"
+
+	pullRequestEventName = "pull_request"
+)
+
+var (
+	// Gitea environment
+	Gitea = New()
+
+	// workflowsDirs are searched for workflow files, Forgejo reads .forgejo/workflows,
+	// and both fall back to .gitea/workflows and .github/workflows
+	workflowsDirs = []string{".forgejo/workflows", ".gitea/workflows", ".github/workflows"}
+)
+
+// Environment is the Gitea environment
+type Environment struct {
+	cache utils.ConfigurationCache
+}
+
+// New creates a Gitea environment with its own configuration cache
+func New() *Environment {
+	return &Environment{}
+}
+
+func (e *Environment) GetConfiguration() (*models.Configuration, error) {
+	return e.cache.Get(e.load)
+}
+
+// Refresh loads the configuration again and replaces the cached configuration
+func (e *Environment) Refresh() (*models.Configuration, error) {
+	return e.cache.Refresh(e.load)
+}
+
+// Reset clears the cached configuration, it is loaded again on the next GetConfiguration
+func (e *Environment) Reset() {
+	e.cache.Reset()
+}
+
+func (e *Environment) load() (*models.Configuration, error) {
+	return loadConfiguration(envsource.OS)
+}
+
+func (e *Environment) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return loadConfiguration(src)
+}
+
+func loadConfiguration(src envsource.EnvSource) (*models.Configuration, error) {
+	payload, err := initPayload(src)
+	if err != nil {
+		return nil, err
+	}
+
+	repoPath := src.Getenv(repositoryPathEnv)
+	repoUrl := fmt.Sprintf("%s/%s", src.Getenv(serverUrlEnv), src.Getenv(repositoryEnv))
+	cloneUrl, err := envsource.GitClient(src).GetGitRemoteURL(envsource.Path(src, repoPath))
+
+	var warnings []models.Warning
+	if err != nil {
+		warnings = append(warnings, models.NewWarning(models.GitRemoteUrlWarning, err, "failed to get the git remote url of %s, using %s", repoPath, repoUrl))
+	}
+	if err != nil || cloneUrl == "" || !strings.HasSuffix(cloneUrl, ".git") {
+		cloneUrl = fmt.Sprintf("%s.git", repoUrl)
+	}
+	strippedCloneUrl := utils.StripCredentialsFromUrl(cloneUrl)
+
+	username := payload.Sender.Login
+	if username == "" {
+		username = utils.DetectPusherFrom(src)
+	}
+
+	fullName := src.Getenv(repositoryEnv)
+	org, name := "", fullName
+	if index := strings.LastIndex(fullName, "/"); index != -1 {
+		org, name = fullName[:index], fullName[index+1:]
+	}
+	if payload.Repository.Owner.Login != "" {
+		org = payload.Repository.Owner.Login
+	}
+
+	var repoId string
+	if payload.Repository.Id != 0 {
+		repoId = strconv.Itoa(payload.Repository.Id)
+	}
+	var senderId string
+	if payload.Sender.Id != 0 {
+		senderId = strconv.Itoa(payload.Sender.Id)
+	}
+
+	pipelines, pipelinesWarnings := GetPipelinePaths(src, repoPath)
+	warnings = append(warnings, pipelinesWarnings...)
+	return &models.Configuration{
+		Url:       src.Getenv(serverUrlEnv),
+		SCMApiUrl: src.Getenv(apiUrlEnv),
+		LocalPath: repoPath,
+		CommitSha: src.Getenv(commitShaEnv),
+		Branch:    getBranch(src),
+		Run: models.BuildRun{
+			BuildId:     src.Getenv(runIdEnv),
+			BuildNumber: src.Getenv(runNumberEnv),
+		},
+		Job: models.Entity{
+			Id:   src.Getenv(jobEnv),
+			Name: src.Getenv(jobEnv),
+		},
+		Pipeline: models.Pipeline{
+			Entity: models.Entity{
+				Id:   src.Getenv(workflowEnv),
+				Name: src.Getenv(workflowEnv),
+			},
+			Path: getPipelinePath(src.Getenv(workflowEnv), repoPath, pipelines),
+		},
+		Runner: models.Runner{
+			Id:           src.Getenv(runIdEnv),
+			Name:         src.Getenv(runnerNameEnv),
+			OS:           src.Getenv(runnerOSEnv),
+			Architecture: runtime.GOARCH,
+		},
+		Repository: models.Repository{
+			Id:       repoId,
+			Name:     name,
+			FullName: fullName,
+			Url:      repoUrl,
+			CloneUrl: strippedCloneUrl,
+			Source:   enums.Gitea,
+		},
+		PullRequest: models.PullRequest{
+			SourceRef: models.Ref{
+				Branch: src.Getenv(headBranchNameEnv),
+			},
+			TargetRef: models.Ref{
+				Branch: src.Getenv(baseBranchNameEnv),
+			},
+		},
+		Commits: github.GetCommits(payload),
+		Builder: builder,
+		Organization: models.Entity{
+			Name: org,
+		},
+		Pusher: models.Pusher{
+			Entity: models.Entity{
+				Id:   senderId,
+				Name: payload.Sender.Login,
+			},
+			Username: username,
+		},
+		PipelinePaths: pipelines,
+		Environment:   enums.Gitea,
+		ScmId:         utils.GenerateScmId(strippedCloneUrl),
+		Warnings:      warnings,
+	}, nil
+}
+
+// initPayload reads the event payload, Gitea sends the GitHub webhook format
+func initPayload(src envsource.EnvSource) (*github.GithubPayload, error) {
+	var payload *github.GithubPayload
+
+	payloadFile, err := envsource.ReadFile(src, src.Getenv(eventPathEnv))
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(payloadFile, &payload); err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
+
+func getBranch(src envsource.EnvSource) string {
+	if src.Getenv(eventNameEnv) == pullRequestEventName {
+		return src.Getenv(headBranchNameEnv)
+	}
+	return src.Getenv(branchEnv)
+}
+
+// getPipelinePath returns the path of the workflow file relative to the repository,
+// GITHUB_WORKFLOW is the workflow file name on Gitea, i.e. build.yml
+func getPipelinePath(workflow string, rootDir string, pipelinePaths []string) string {
+	if workflow == "" {
+		return ""
+	}
+	for _, path := range pipelinePaths {
+		if filepath.Base(path) == workflow {
+			return strings.TrimPrefix(path, rootDir+string(filepath.Separator))
+		}
+	}
+	return ""
+}
+
+func getRepositoryUrl() string {
+	return fmt.Sprintf("%s/%s", os.Getenv(serverUrlEnv), os.Getenv(repositoryEnv))
+}
+
+// GetStepLink returns the link of the run, Gitea does not expose the index of the job in the variables
+func (e *Environment) GetStepLink() string {
+	return e.GetBuildLink()
+}
+
+// GetBuildLink returns the link of the run, which Gitea addresses by the run number
+func (e *Environment) GetBuildLink() string {
+	return fmt.Sprintf("%s/actions/runs/%s", getRepositoryUrl(), os.Getenv(runNumberEnv))
+}
+
+func (e *Environment) GetFileLink(filename string, branch string, commit string) string {
+	return GetFileLink(getRepositoryUrl(), filename, branch, commit)
+}
+
+func (e *Environment) GetFileLineLink(filename string, branch string, commit string, startLine int, endLine int) string {
+	return GetFileLineLink(getRepositoryUrl(), filename, branch, commit, startLine, endLine)
+}
+
+func (e *Environment) GetCommitLink(commit string) string {
+	return GetCommitLink(getRepositoryUrl(), commit)
+}
+
+func (e *Environment) GetCompareLink(baseCommit string, headCommit string) string {
+	return GetCompareLink(getRepositoryUrl(), baseCommit, headCommit)
+}
+
+func (e *Environment) GetPullRequestLink(pullRequestId string) string {
+	return GetPullRequestLink(getRepositoryUrl(), pullRequestId)
+}
+
+func (e *Environment) GetRefLink(ref string) string {
+	return GetRefLink(getRepositoryUrl(), ref)
+}
+
+// GetFileLink returns the link to a file at a commit, or at a branch or tag when there is no commit.
+// The branch can be a branch name or a refs/heads/ or refs/tags/ ref, i.e. GITHUB_REF
+func GetFileLink(repositoryURL string, filename string, branch string, commit string) string {
+	if commit != "" {
+		return fmt.Sprintf("%s/src/commit/%s/%s", repositoryURL, commit, filename)
+	}
+	name, isTag := utils.ParseRef(branch)
+	if isTag {
+		return fmt.Sprintf("%s/src/tag/%s/%s", repositoryURL, name, filename)
+	}
+	return fmt.Sprintf("%s/src/branch/%s/%s", repositoryURL, name, filename)
+}
+
+func GetFileLineLink(repositoryURL string, filename string, branch string, commit string, startLine, endLine int) string {
+	url := GetFileLink(repositoryURL, filename, branch, commit)
+	if startLine != 0 {
+		if endLine == 0 {
+			endLine = startLine
+		}
+
+		url = fmt.Sprintf("%s#L%d-L%d", url, startLine, endLine)
+	}
+
+	return url
+}
+
+// IsCurrentEnvironment checks for the GITEA_ACTIONS and FORGEJO_ACTIONS variables.
+// Older runners do not set them, so a GitHub Actions run on a server other than github.com is checked by its api url,
+// which is /api/v1 on Gitea. Detection does not request the server, runs without a Gitea api url are left to GitHub
+func (e *Environment) IsCurrentEnvironment() bool {
+	if os.Getenv(giteaActionsEnv) == "true" || os.Getenv(forgejoActionsEnv) == "true" {
+		return true
+	}
+	serverUrl := strings.TrimSuffix(os.Getenv(serverUrlEnv), "/")
+	if _, isExist := os.LookupEnv(workflowEnv); !isExist || serverUrl == "" || serverUrl == githubServerUrl {
+		return false
+	}
+	return strings.HasSuffix(strings.TrimSuffix(os.Getenv(apiUrlEnv), "/"), giteaApiPath)
+}
+
+// CheckGiteaByHTTPRequest checks that the server answers the version endpoint of the Gitea api,
+// which is not served by GitHub Enterprise
+func CheckGiteaByHTTPRequest(url string, httpClient http.HTTPService) bool {
+	body, err := httpClient.Get(fmt.Sprintf("%s%s", url, versionApiPath), nil, nil)
+	if err != nil {
+		return false
+	}
+	var version struct {
+		Version string `json:"version"`
+	}
+	return json.Unmarshal(body, &version) == nil && version.Version != ""
+}
+
+// DetectionVariables returns the variables used by IsCurrentEnvironment,
+// the GitHub Actions variables identify runners that do not set the marker variables
+func (e *Environment) DetectionVariables() []string {
+	return []string{giteaActionsEnv, forgejoActionsEnv, workflowEnv, serverUrlEnv, apiUrlEnv}
+}
+
+// ExpectedFields returns the configuration fields the environment is expected to fill and the variables they are filled from
+func (e *Environment) ExpectedFields() []models.ExpectedField {
+	return []models.ExpectedField{
+		{Field: "repository.url", SourceEnv: repositoryEnv, Severity: models.SeverityCritical},
+		{Field: "repository.cloneUrl", SourceEnv: repositoryEnv, Severity: models.SeverityCritical},
+		{Field: "commitSha", SourceEnv: commitShaEnv, Severity: models.SeverityCritical},
+		{Field: "branch", SourceEnv: branchEnv, Severity: models.SeverityWarning},
+		{Field: "url", SourceEnv: serverUrlEnv, Severity: models.SeverityWarning},
+		{Field: "scmApiUrl", SourceEnv: apiUrlEnv, Severity: models.SeverityWarning},
+		{Field: "localPath", SourceEnv: repositoryPathEnv, Severity: models.SeverityWarning},
+		{Field: "pipeline.name", SourceEnv: workflowEnv, Severity: models.SeverityWarning},
+		{Field: "job.name", SourceEnv: jobEnv, Severity: models.SeverityWarning},
+		{Field: "run.buildNumber", SourceEnv: runNumberEnv, Severity: models.SeverityWarning},
+	}
+}
+
+func (e *Environment) Name() string {
+	return "gitea"
+}
+
+// GetPipelinePaths finds the workflow files of the repository in the .forgejo, .gitea and .github workflows directories,
+// directories that cannot be read are skipped and reported as warnings
+func GetPipelinePaths(src envsource.EnvSource, rootDir string) ([]string, []models.Warning) {
+	paths := make([]string, 0)
+	var warnings []models.Warning
+
+	for _, workflowsDir := range workflowsDirs {
+		dir := filepath.Join(rootDir, workflowsDir)
+		if _, err := envsource.Stat(src, dir); err != nil {
+			continue
+		}
+		err := envsource.Walk(src, dir, func(path string, info fs.FileInfo, err error) error {
+			if err != nil {
+				warnings = append(warnings, models.NewWarning(models.PipelinePathsWarning, err, "failed to search %s for pipeline files", path))
+				return nil
+			}
+			if info.IsDir() {
+				if path != dir {
+					return fs.SkipDir
+				}
+				return nil
+			}
+			if filepath.Ext(path) == ".yml" || filepath.Ext(path) == ".yaml" {
+				paths = append(paths, path)
+			}
+			return nil
+		})
+		if err != nil {
+			warnings = append(warnings, models.NewWarning(models.PipelinePathsWarning, err, "failed to search %s for pipeline files", dir))
+		}
+	}
+
+	return paths, warnings
+}
@@ -0,0 +1,127 @@
+package gitea
+
+import (
+	"fmt"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/models"
+)
+
+var (
+	MockOrgName   = "test-org"
+	MockRepoName  = "test-repo"
+	MockServerUrl = "https://gitea.example.com"
+	MockRunNumber = "12"
+)
+
+var mockConfiguration *models.Configuration
+
+type EnvironmentMock struct{}
+
+func (em *EnvironmentMock) GetConfiguration() (*models.Configuration, error) {
+	if mockConfiguration == nil {
+		if err := loadMockConfiguration(); err != nil {
+			return nil, err
+		}
+	}
+	return mockConfiguration, nil
+}
+
+func (em *EnvironmentMock) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return em.GetConfiguration()
+}
+
+func (em *EnvironmentMock) Refresh() (*models.Configuration, error) {
+	em.Reset()
+	return em.GetConfiguration()
+}
+
+func (em *EnvironmentMock) Reset() {
+	mockConfiguration = nil
+}
+
+func loadMockConfiguration() error {
+	mockConfiguration = &models.Configuration{
+		Url:       MockServerUrl,
+		SCMApiUrl: fmt.Sprintf("%s/api/v1", MockServerUrl),
+		LocalPath: "/workspace/test-org/test-repo",
+		CommitSha: "2c6e3880fd94ddb7ef72d34e683cdc0c47bec6e6",
+		Branch:    "refs/heads/main",
+		Run: models.BuildRun{
+			BuildId:     "318",
+			BuildNumber: MockRunNumber,
+		},
+		Job: models.Entity{
+			Id:   "test",
+			Name: "test",
+		},
+		Pipeline: models.Pipeline{
+			Entity: models.Entity{
+				Id:   "build.yml",
+				Name: "build.yml",
+			},
+			Path: ".gitea/workflows/build.yml",
+		},
+		Runner: models.Runner{
+			Id:   "318",
+			Name: "gitea-runner-1",
+			OS:   "Linux",
+		},
+		Repository: models.Repository{
+			Id:       "27",
+			Name:     MockRepoName,
+			FullName: fmt.Sprintf("%s/%s", MockOrgName, MockRepoName),
+			Url:      fmt.Sprintf("%s/%s/%s", MockServerUrl, MockOrgName, MockRepoName),
+			CloneUrl: fmt.Sprintf("%s/%s/%s.git", MockServerUrl, MockOrgName, MockRepoName),
+			Source:   enums.Gitea,
+		},
+		Builder: "Gitea Actions",
+		Organization: models.Entity{
+			Name: MockOrgName,
+		},
+		PipelinePaths: []string{"/workspace/test-org/test-repo/.gitea/workflows/build.yml"},
+		Environment:   enums.Gitea,
+	}
+	return nil
+}
+
+func (em *EnvironmentMock) GetBuildLink() string {
+	return fmt.Sprintf("%s/%s/%s/actions/runs/%s", MockServerUrl, MockOrgName, MockRepoName, MockRunNumber)
+}
+
+func (em *EnvironmentMock) GetStepLink() string {
+	return em.GetBuildLink()
+}
+
+func (em *EnvironmentMock) GetFileLink(filename string, branch string, commit string) string {
+	return GetFileLink(fmt.Sprintf("%s/%s/%s", MockServerUrl, MockOrgName, MockRepoName), filename, branch, commit)
+}
+
+func (em *EnvironmentMock) GetFileLineLink(filename string, branch string, commit string, startLine int, endLine int) string {
+	return GetFileLineLink(fmt.Sprintf("%s/%s/%s", MockServerUrl, MockOrgName, MockRepoName), filename, branch, commit, startLine, endLine)
+}
+
+func (em *EnvironmentMock) GetCommitLink(commit string) string {
+	return ""
+}
+
+func (em *EnvironmentMock) GetCompareLink(baseCommit string, headCommit string) string {
+	return ""
+}
+
+func (em *EnvironmentMock) GetPullRequestLink(pullRequestId string) string {
+	return ""
+}
+
+func (em *EnvironmentMock) GetRefLink(ref string) string {
+	return ""
+}
+
+func (em *EnvironmentMock) IsCurrentEnvironment() bool {
+	return true
+}
+
+func (em *EnvironmentMock) Name() string {
+	return "gitea"
+}
@@ -0,0 +1,431 @@
+package gitea
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/testutils"
+	"github.com/argonsecurity/go-environments/http"
+	"github.com/argonsecurity/go-environments/models"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	giteaMainEnvsFilePath  = "testdata/gitea-actions-main-env.json"
+	forgejoPrEnvsFilePath  = "testdata/forgejo-actions-pr-env.json"
+	githubMainEnvsFilePath = "../github/testdata/github-workflows-main-env.json"
+	testRepoPath           = "/tmp/gitea/repo"
+	testRepoUrl            = "https://gitea.example.com/test-org/test-repo"
+	testdataPath           = "../gitea/testdata/repo"
+
+	testBranch   = "branch"
+	testCommit   = "commit"
+	testFilepath = "path/to/file"
+)
+
+var (
+	testRepoCloneUrl  = fmt.Sprintf("%s%s", testRepoUrl, ".git")
+	testPipelinePaths = []string{"/tmp/gitea/repo/.forgejo/workflows/release.yaml", "/tmp/gitea/repo/.gitea/workflows/build.yml"}
+)
+
+type httpServiceMock struct {
+	body []byte
+	err  error
+	urls []string
+}
+
+func (m *httpServiceMock) Get(url string, headers http.Headers, params http.Params) ([]byte, error) {
+	m.urls = append(m.urls, url)
+	return m.body, m.err
+}
+
+func (m *httpServiceMock) Post(url string, headers http.Headers, data interface{}) ([]byte, error) {
+	return nil, nil
+}
+
+func (m *httpServiceMock) Put(url string, headers http.Headers, data interface{}) ([]byte, error) {
+	return nil, nil
+}
+
+func (m *httpServiceMock) Delete(url string, headers http.Headers, data interface{}) ([]byte, error) {
+	return nil, nil
+}
+
+func Test_environment_GetConfiguration(t *testing.T) {
+	tests := []struct {
+		name         string
+		envsFilePath string
+		want         *models.Configuration
+		wantErr      bool
+	}{
+		{
+			name:         "Gitea push configuration",
+			envsFilePath: giteaMainEnvsFilePath,
+			want: &models.Configuration{
+				Url:       "https://gitea.example.com",
+				SCMApiUrl: "https://gitea.example.com/api/v1",
+				LocalPath: testRepoPath,
+				CommitSha: "2c6e3880fd94ddb7ef72d34e683cdc0c47bec6e6",
+				Branch:    "refs/heads/main",
+				Run: models.BuildRun{
+					BuildId:     "318",
+					BuildNumber: "12",
+				},
+				Job: models.Entity{
+					Id:   "test",
+					Name: "test",
+				},
+				Pipeline: models.Pipeline{
+					Entity: models.Entity{
+						Id:   "build.yml",
+						Name: "build.yml",
+					},
+					Path: ".gitea/workflows/build.yml",
+				},
+				Runner: models.Runner{
+					Id:           "318",
+					Name:         "gitea-runner-1",
+					OS:           "Linux",
+					Architecture: runtime.GOARCH,
+				},
+				Repository: models.Repository{
+					Id:       "27",
+					Name:     "test-repo",
+					FullName: "test-org/test-repo",
+					Url:      testRepoUrl,
+					CloneUrl: testRepoCloneUrl,
+					Source:   enums.Gitea,
+				},
+				Commits: []models.Commit{
+					{
+						Id:         "2c6e3880fd94ddb7ef72d34e683cdc0c47bec6e6",
+						Message:    "Commit message\n",
+						CommitDate: "2024-03-12T10:21:45Z",
+						Url:        "https://gitea.example.com/test-org/test-repo/commit/2c6e3880fd94ddb7ef72d34e683cdc0c47bec6e6",
+						Author: models.Author{
+							Email:    "username123@example.com",
+							Name:     "User Name",
+							Username: "username123",
+						},
+					},
+				},
+				Builder: "Gitea Actions",
+				Organization: models.Entity{
+					Name: "test-org",
+				},
+				Pusher: models.Pusher{
+					Entity: models.Entity{
+						Id:   "5",
+						Name: "username123",
+					},
+					Username: "username123",
+				},
+				PipelinePaths: testPipelinePaths,
+				Environment:   enums.Gitea,
+				ScmId:         "3bd6c1aead85eae116905f75031b0b90",
+			},
+		},
+		{
+			name:         "Forgejo pull request configuration",
+			envsFilePath: forgejoPrEnvsFilePath,
+			want: &models.Configuration{
+				Url:       "https://gitea.example.com",
+				SCMApiUrl: "https://gitea.example.com/api/v1",
+				LocalPath: testRepoPath,
+				CommitSha: "mky2jknpc4fuz6qsn0vtouqwfjbno39itu0hifvs",
+				Branch:    "test-branch",
+				Run: models.BuildRun{
+					BuildId:     "321",
+					BuildNumber: "13",
+				},
+				Job: models.Entity{
+					Id:   "release",
+					Name: "release",
+				},
+				Pipeline: models.Pipeline{
+					Entity: models.Entity{
+						Id:   "release.yaml",
+						Name: "release.yaml",
+					},
+					Path: ".forgejo/workflows/release.yaml",
+				},
+				Runner: models.Runner{
+					Id:           "321",
+					Name:         "forgejo-runner-1",
+					OS:           "Linux",
+					Architecture: runtime.GOARCH,
+				},
+				Repository: models.Repository{
+					Id:       "27",
+					Name:     "test-repo",
+					FullName: "test-org/test-repo",
+					Url:      testRepoUrl,
+					CloneUrl: testRepoCloneUrl,
+					Source:   enums.Gitea,
+				},
+				PullRequest: models.PullRequest{
+					SourceRef: models.Ref{
+						Branch: "test-branch",
+					},
+					TargetRef: models.Ref{
+						Branch: "main",
+					},
+				},
+				Commits: []models.Commit{},
+				Builder: "Gitea Actions",
+				Organization: models.Entity{
+					Name: "test-org",
+				},
+				Pusher: models.Pusher{
+					Entity: models.Entity{
+						Id:   "5",
+						Name: "username123",
+					},
+					Username: "username123",
+				},
+				PipelinePaths: testPipelinePaths,
+				Environment:   enums.Gitea,
+				ScmId:         "3bd6c1aead85eae116905f75031b0b90",
+			},
+		},
+		{
+			name:         "Missing event payload",
+			envsFilePath: "",
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := prepareTest(t, tt.envsFilePath)
+			got, err := e.GetConfiguration()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("environment.GetConfiguration() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_environment_GetBuildLink(t *testing.T) {
+	e := prepareTest(t, giteaMainEnvsFilePath)
+	assert.Equal(t, "https://gitea.example.com/test-org/test-repo/actions/runs/12", e.GetBuildLink())
+	assert.Equal(t, "https://gitea.example.com/test-org/test-repo/actions/runs/12", e.GetStepLink())
+}
+
+func Test_environment_GetFileLineLink(t *testing.T) {
+	e := prepareTest(t, giteaMainEnvsFilePath)
+	assert.Equal(t, "https://gitea.example.com/test-org/test-repo/src/commit/commit/path/to/file", e.GetFileLink(testFilepath, testBranch, testCommit))
+	assert.Equal(t, "https://gitea.example.com/test-org/test-repo/src/branch/branch/path/to/file#L3-L5", e.GetFileLineLink(testFilepath, testBranch, "", 3, 5))
+
+	configuration, err := e.GetConfiguration()
+	assert.NoError(t, err)
+	assert.Equal(t, "https://gitea.example.com/test-org/test-repo/src/branch/main/path/to/file", e.GetFileLink(testFilepath, configuration.Branch, ""))
+}
+
+func TestGetFileLink(t *testing.T) {
+	type args struct {
+		repositoryURL string
+		filename      string
+		branch        string
+		commit        string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "With branch",
+			args: args{
+				repositoryURL: testRepoUrl,
+				filename:      testFilepath,
+				branch:        testBranch,
+			},
+			want: "https://gitea.example.com/test-org/test-repo/src/branch/branch/path/to/file",
+		},
+		{
+			name: "With branch ref",
+			args: args{
+				repositoryURL: testRepoUrl,
+				filename:      testFilepath,
+				branch:        "refs/heads/main",
+			},
+			want: "https://gitea.example.com/test-org/test-repo/src/branch/main/path/to/file",
+		},
+		{
+			name: "With tag ref",
+			args: args{
+				repositoryURL: testRepoUrl,
+				filename:      testFilepath,
+				branch:        "refs/tags/v1.0.0",
+			},
+			want: "https://gitea.example.com/test-org/test-repo/src/tag/v1.0.0/path/to/file",
+		},
+		{
+			name: "With commit",
+			args: args{
+				repositoryURL: testRepoUrl,
+				filename:      testFilepath,
+				commit:        testCommit,
+			},
+			want: "https://gitea.example.com/test-org/test-repo/src/commit/commit/path/to/file",
+		},
+		{
+			name: "With commit and branch",
+			args: args{
+				repositoryURL: testRepoUrl,
+				filename:      testFilepath,
+				commit:        testCommit,
+				branch:        testBranch,
+			},
+			want: "https://gitea.example.com/test-org/test-repo/src/commit/commit/path/to/file",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetFileLink(tt.args.repositoryURL, tt.args.filename, tt.args.branch, tt.args.commit); got != tt.want {
+				t.Errorf("GetFileLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetFileLineLink(t *testing.T) {
+	type args struct {
+		repositoryURL string
+		filename      string
+		branch        string
+		commit        string
+		startLine     int
+		endLine       int
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "With start and end lines",
+			args: args{
+				repositoryURL: testRepoUrl,
+				filename:      testFilepath,
+				commit:        testCommit,
+				startLine:     1,
+				endLine:       3,
+			},
+			want: "https://gitea.example.com/test-org/test-repo/src/commit/commit/path/to/file#L1-L3",
+		},
+		{
+			name: "With start line only",
+			args: args{
+				repositoryURL: testRepoUrl,
+				filename:      testFilepath,
+				branch:        testBranch,
+				startLine:     7,
+			},
+			want: "https://gitea.example.com/test-org/test-repo/src/branch/branch/path/to/file#L7-L7",
+		},
+		{
+			name: "Without lines",
+			args: args{
+				repositoryURL: testRepoUrl,
+				filename:      testFilepath,
+				branch:        testBranch,
+			},
+			want: "https://gitea.example.com/test-org/test-repo/src/branch/branch/path/to/file",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetFileLineLink(tt.args.repositoryURL, tt.args.filename, tt.args.branch, tt.args.commit, tt.args.startLine, tt.args.endLine); got != tt.want {
+				t.Errorf("GetFileLineLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_environment_IsCurrentEnvironment(t *testing.T) {
+	tests := []struct {
+		name         string
+		envsFilePath string
+		envs         map[string]string
+		want         bool
+	}{
+		{
+			name:         "Gitea environment",
+			envsFilePath: giteaMainEnvsFilePath,
+			want:         true,
+		},
+		{
+			name:         "Forgejo environment",
+			envsFilePath: forgejoPrEnvsFilePath,
+			want:         true,
+		},
+		{
+			name:         "GitHub environment",
+			envsFilePath: githubMainEnvsFilePath,
+			want:         false,
+		},
+		{
+			name:         "GitHub Enterprise environment",
+			envsFilePath: "../github/testdata/github-server-workflows-main-env.json",
+			want:         false,
+		},
+		{
+			name:         "Gitea environment without marker variables",
+			envsFilePath: githubMainEnvsFilePath,
+			envs:         map[string]string{"GITHUB_SERVER_URL": "https://gitea.example.com", "GITHUB_API_URL": "https://gitea.example.com/api/v1"},
+			want:         true,
+		},
+		{
+			name:         "Server without api url",
+			envsFilePath: githubMainEnvsFilePath,
+			envs:         map[string]string{"GITHUB_SERVER_URL": "https://gitea.example.com", "GITHUB_API_URL": ""},
+			want:         false,
+		},
+		{
+			name:         "GitHub Enterprise Cloud with data residency",
+			envsFilePath: githubMainEnvsFilePath,
+			envs:         map[string]string{"GITHUB_SERVER_URL": "https://org.ghe.com", "GITHUB_API_URL": "https://api.org.ghe.com"},
+			want:         false,
+		},
+		{
+			name:         "Not Gitea environment",
+			envsFilePath: "",
+			want:         false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := prepareTest(t, tt.envsFilePath)
+			for key, value := range tt.envs {
+				t.Setenv(key, value)
+			}
+			if got := e.IsCurrentEnvironment(); got != tt.want {
+				t.Errorf("environment.IsCurrentEnvironment() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckGiteaByHTTPRequest(t *testing.T) {
+	client := &httpServiceMock{body: []byte(`{"version":"1.21.4"}`)}
+	assert.True(t, CheckGiteaByHTTPRequest("https://gitea.example.com", client))
+	assert.Equal(t, []string{"https://gitea.example.com/api/v1/version"}, client.urls)
+
+	assert.False(t, CheckGiteaByHTTPRequest("https://git.example.com", &httpServiceMock{err: errors.New("got a response with status code 404")}))
+	assert.False(t, CheckGiteaByHTTPRequest("https://git.example.com", &httpServiceMock{body: []byte(`{}`)}))
+}
+
+func prepareTest(t *testing.T, envsFilePath string) *Environment {
+	e := New()
+	testRepoCleanup := testutils.PrepareTestGitRepository(testRepoPath, testRepoCloneUrl, testdataPath)
+	t.Cleanup(testRepoCleanup)
+	envCleanup := testutils.SetEnvsFromFile(envsFilePath)
+	t.Cleanup(envCleanup)
+	return e
+}
@@ -0,0 +1,79 @@
+package gitea
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/argonsecurity/go-environments/environments/utils"
+)
+
+func GetCommitLink(repositoryURL string, commit string) string {
+	if commit == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/commit/%s", repositoryURL, commit)
+}
+
+func GetCompareLink(repositoryURL string, baseCommit string, headCommit string) string {
+	if baseCommit == "" || headCommit == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/compare/%s...%s", repositoryURL, baseCommit, headCommit)
+}
+
+func GetPullRequestLink(repositoryURL string, pullRequestId string) string {
+	if pullRequestId == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/pulls/%s", repositoryURL, pullRequestId)
+}
+
+// GetRefLink returns the link to the source of a branch or tag, Gitea serves them under src/branch/<name> and src/tag/<name>
+func GetRefLink(repositoryURL string, ref string) string {
+	name, isTag := utils.ParseRef(ref)
+	if name == "" {
+		return ""
+	}
+	if isTag {
+		return fmt.Sprintf("%s/src/tag/%s", repositoryURL, name)
+	}
+	return fmt.Sprintf("%s/src/branch/%s", repositoryURL, name)
+}
+
+var (
+	// https://gitea.com/org/repo/src/<branch|commit|tag>/<ref>/path/to/file
+	fileLinkRegexp = regexp.MustCompile(`^(.+?)/src/(branch|commit|tag)/([^/]+)/(.+)$`)
+	// #L10-L20, or #L10 for a single line
+	linesRegexp = regexp.MustCompile(`^L(\d+)(?:-L(\d+))?$`)
+)
+
+// ParseFileLineLink returns the repository url, the file, the ref and the lines of a link built by GetFileLineLink,
+// the ref is refs/heads/<branch> for a branch link, refs/tags/<tag> for a tag link and the commit for a commit link
+// Branches with slashes can't be told from the file, the ref is read up to the first slash
+func ParseFileLineLink(link string) (string, string, string, int, int, error) {
+	link, fragment, _ := strings.Cut(link, "#")
+	result := fileLinkRegexp.FindStringSubmatch(link)
+	if result == nil {
+		return "", "", "", 0, 0, fmt.Errorf("could not parse file link: %s", link)
+	}
+
+	ref := result[3]
+	switch result[2] {
+	case "branch":
+		ref = fmt.Sprintf("refs/heads/%s", ref)
+	case "tag":
+		ref = fmt.Sprintf("refs/tags/%s", ref)
+	}
+
+	startLine, endLine := 0, 0
+	if lines := linesRegexp.FindStringSubmatch(fragment); lines != nil {
+		startLine, _ = strconv.Atoi(lines[1])
+		endLine = startLine
+		if lines[2] != "" {
+			endLine, _ = strconv.Atoi(lines[2])
+		}
+	}
+	return result[1], result[4], ref, startLine, endLine, nil
+}
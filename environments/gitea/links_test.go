@@ -0,0 +1,181 @@
+package gitea
+
+import (
+	"testing"
+)
+
+func TestGetCommitLink(t *testing.T) {
+	tests := []struct {
+		name   string
+		commit string
+		want   string
+	}{
+		{
+			name:   "With commit",
+			commit: "0a1b2c",
+			want:   "https://gitea.example.com/org/repo/commit/0a1b2c",
+		},
+		{
+			name:   "Without commit",
+			commit: "",
+			want:   "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetCommitLink("https://gitea.example.com/org/repo", tt.commit); got != tt.want {
+				t.Errorf("GetCommitLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetCompareLink(t *testing.T) {
+	tests := []struct {
+		name       string
+		baseCommit string
+		headCommit string
+		want       string
+	}{
+		{
+			name:       "With base and head commits",
+			baseCommit: "9f8e7d",
+			headCommit: "0a1b2c",
+			want:       "https://gitea.example.com/org/repo/compare/9f8e7d...0a1b2c",
+		},
+		{
+			name:       "Without base commit",
+			headCommit: "0a1b2c",
+			want:       "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetCompareLink("https://gitea.example.com/org/repo", tt.baseCommit, tt.headCommit); got != tt.want {
+				t.Errorf("GetCompareLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetPullRequestLink(t *testing.T) {
+	tests := []struct {
+		name          string
+		pullRequestId string
+		want          string
+	}{
+		{
+			name:          "With pull request id",
+			pullRequestId: "12",
+			want:          "https://gitea.example.com/org/repo/pulls/12",
+		},
+		{
+			name:          "Without pull request id",
+			pullRequestId: "",
+			want:          "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetPullRequestLink("https://gitea.example.com/org/repo", tt.pullRequestId); got != tt.want {
+				t.Errorf("GetPullRequestLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetRefLink(t *testing.T) {
+	tests := []struct {
+		name string
+		ref  string
+		want string
+	}{
+		{
+			name: "Branch name",
+			ref:  "feature/x",
+			want: "https://gitea.example.com/org/repo/src/branch/feature/x",
+		},
+		{
+			name: "Branch ref",
+			ref:  "refs/heads/feature/x",
+			want: "https://gitea.example.com/org/repo/src/branch/feature/x",
+		},
+		{
+			name: "Tag ref",
+			ref:  "refs/tags/v1.0",
+			want: "https://gitea.example.com/org/repo/src/tag/v1.0",
+		},
+		{
+			name: "Empty ref",
+			ref:  "",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetRefLink("https://gitea.example.com/org/repo", tt.ref); got != tt.want {
+				t.Errorf("GetRefLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFileLineLink(t *testing.T) {
+	tests := []struct {
+		name              string
+		link              string
+		wantRepositoryURL string
+		wantFilename      string
+		wantRef           string
+		wantStartLine     int
+		wantEndLine       int
+		wantErr           bool
+	}{
+		{
+			name:              "Branch lines",
+			link:              "https://gitea.com/org/repo/src/branch/main/path/to/file#L10-L20",
+			wantRepositoryURL: "https://gitea.com/org/repo",
+			wantFilename:      "path/to/file",
+			wantRef:           "refs/heads/main",
+			wantStartLine:     10,
+			wantEndLine:       20,
+		},
+		{
+			name:              "Commit single line",
+			link:              "https://gitea.com/org/repo/src/commit/0a1b2c/path/to/file#L10",
+			wantRepositoryURL: "https://gitea.com/org/repo",
+			wantFilename:      "path/to/file",
+			wantRef:           "0a1b2c",
+			wantStartLine:     10,
+			wantEndLine:       10,
+		},
+		{
+			name:              "Tag",
+			link:              "https://gitea.com/org/repo/src/tag/v1.0/path/to/file",
+			wantRepositoryURL: "https://gitea.com/org/repo",
+			wantFilename:      "path/to/file",
+			wantRef:           "refs/tags/v1.0",
+			wantStartLine:     0,
+			wantEndLine:       0,
+		},
+		{
+			name:    "Not a file link",
+			link:    "https://gitea.com/org/repo/commit/0a1b2c",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repositoryURL, filename, ref, startLine, endLine, err := ParseFileLineLink(tt.link)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFileLineLink() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if repositoryURL != tt.wantRepositoryURL || filename != tt.wantFilename || ref != tt.wantRef {
+				t.Errorf("ParseFileLineLink() = %v, %v, %v, want %v, %v, %v", repositoryURL, filename, ref, tt.wantRepositoryURL, tt.wantFilename, tt.wantRef)
+			}
+			if startLine != tt.wantStartLine || endLine != tt.wantEndLine {
+				t.Errorf("ParseFileLineLink() lines = %v-%v, want %v-%v", startLine, endLine, tt.wantStartLine, tt.wantEndLine)
+			}
+		})
+	}
+}
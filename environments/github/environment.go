@@ -12,7 +12,7 @@ import (
 
 	"github.com/argonsecurity/go-environments/enums"
 	"github.com/argonsecurity/go-environments/environments/utils"
-	"github.com/argonsecurity/go-environments/environments/utils/git"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
 	"github.com/argonsecurity/go-environments/models"
 )
 
@@ -44,92 +44,126 @@ const (
 
 	githubApiUrlEnv = "GITHUB_API_URL"
 
+	// giteaActionsEnv and forgejoActionsEnv are set by Gitea and Forgejo runners, which also set the GITHUB variables
+	giteaActionsEnv   = "GITEA_ACTIONS"
+	forgejoActionsEnv = "FORGEJO_ACTIONS"
+
 	pullRequestEventName = "pull_request"
 )
 
 var (
 	// Github environment
-	Github        = environment{}
-	configuration *models.Configuration
+	Github = New()
 )
 
-type environment struct{}
+// Environment is the GitHub environment
+type Environment struct {
+	cache utils.ConfigurationCache
+}
 
-func (e environment) GetConfiguration() (*models.Configuration, error) {
-	if configuration == nil {
-		if err := loadConfiguration(); err != nil {
-			return nil, err
-		}
-	}
-	return configuration, nil
+// New creates a GitHub environment with its own configuration cache
+func New() *Environment {
+	return &Environment{}
+}
+
+func (e *Environment) GetConfiguration() (*models.Configuration, error) {
+	return e.cache.Get(e.load)
+}
+
+// Refresh loads the configuration again and replaces the cached configuration
+func (e *Environment) Refresh() (*models.Configuration, error) {
+	return e.cache.Refresh(e.load)
+}
+
+// Reset clears the cached configuration, it is loaded again on the next GetConfiguration
+func (e *Environment) Reset() {
+	e.cache.Reset()
+}
+
+func (e *Environment) load() (*models.Configuration, error) {
+	return loadConfiguration(envsource.OS)
 }
 
-func loadConfiguration() error {
-	payload, err := initPayload()
+func (e *Environment) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return loadConfiguration(src)
+}
+
+func loadConfiguration(src envsource.EnvSource) (*models.Configuration, error) {
+	_, repoName, found := strings.Cut(src.Getenv(githubRepositoryEnv), "/")
+	if !found {
+		return nil, fmt.Errorf("%s is not a repository full name", src.Getenv(githubRepositoryEnv))
+	}
+
+	payload, err := initPayload(src)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	source := getSource()
+	source := getSource(src)
 
-	repoPath := os.Getenv(repositoryPathEnv)
-	repoUrl := fmt.Sprintf("%s/%s", os.Getenv(githubServerEnv), os.Getenv(githubRepositoryEnv))
-	cloneUrl, err := git.GetGitRemoteURL(repoPath)
+	repoPath := src.Getenv(repositoryPathEnv)
+	repoUrl := fmt.Sprintf("%s/%s", src.Getenv(githubServerEnv), src.Getenv(githubRepositoryEnv))
+	cloneUrl, err := envsource.GitClient(src).GetGitRemoteURL(envsource.Path(src, repoPath))
 
+	var warnings []models.Warning
+	if err != nil {
+		warnings = append(warnings, models.NewWarning(models.GitRemoteUrlWarning, err, "failed to get the git remote url of %s, using %s", repoPath, repoUrl))
+	}
 	if err != nil || cloneUrl == "" || !strings.HasSuffix(cloneUrl, ".git") {
 		cloneUrl = fmt.Sprintf("%s.git", repoUrl)
 	}
 
 	username := payload.Sender.Login
 	if username == "" {
-		username = utils.DetectPusher()
+		username = utils.DetectPusherFrom(src)
 	}
 	strippedCloneUrl := utils.StripCredentialsFromUrl(cloneUrl)
 	scmId := utils.GenerateScmId(strippedCloneUrl)
 
-	pipelines := GetPipelinePaths(repoPath)
+	pipelines, pipelinesWarnings := GetPipelinePathsFrom(src, repoPath)
+	warnings = append(warnings, pipelinesWarnings...)
 	repoId := strconv.Itoa(payload.Repository.Id)
-	configuration = &models.Configuration{
-		Url:       os.Getenv(githubServerEnv),
-		SCMApiUrl: os.Getenv(githubApiUrlEnv),
+	return &models.Configuration{
+		Url:       src.Getenv(githubServerEnv),
+		SCMApiUrl: src.Getenv(githubApiUrlEnv),
 		LocalPath: repoPath,
-		CommitSha: os.Getenv(commitShaEnv),
-		Branch:    getBranch(),
+		CommitSha: src.Getenv(commitShaEnv),
+		Branch:    getBranch(src),
 		Run: models.BuildRun{
-			BuildId:     os.Getenv(githubRunIdEnv),
-			BuildNumber: os.Getenv(githubRunNumberEnv),
+			BuildId:     src.Getenv(githubRunIdEnv),
+			BuildNumber: src.Getenv(githubRunNumberEnv),
 		},
 		Job: models.Entity{
-			Id:   os.Getenv(githubJobEnv),
-			Name: os.Getenv(githubJobEnv),
+			Id:   src.Getenv(githubJobEnv),
+			Name: src.Getenv(githubJobEnv),
 		},
 		Pipeline: models.Pipeline{
 			Entity: models.Entity{
-				Id:   os.Getenv(githubWorkflowEnv),
-				Name: os.Getenv(githubWorkflowEnv),
+				Id:   src.Getenv(githubWorkflowEnv),
+				Name: src.Getenv(githubWorkflowEnv),
 			},
-			Path: getPipelinePath(os.Getenv(githubWorkflowEnv)),
+			Path: getPipelinePath(src.Getenv(githubWorkflowEnv)),
 		},
 		Runner: models.Runner{
-			Id:           os.Getenv(githubRunIdEnv),
-			Name:         os.Getenv(runnerNameEnv),
-			OS:           os.Getenv(runnerOSEnv),
+			Id:           src.Getenv(githubRunIdEnv),
+			Name:         src.Getenv(runnerNameEnv),
+			OS:           src.Getenv(runnerOSEnv),
 			Architecture: runtime.GOARCH,
 		},
 		Repository: models.Repository{
 			Id:       repoId,
-			Name:     strings.Split(os.Getenv(githubRepositoryEnv), "/")[1],
-			FullName: os.Getenv(githubRepositoryEnv),
+			Name:     repoName,
+			FullName: src.Getenv(githubRepositoryEnv),
 			Url:      repoUrl,
 			CloneUrl: strippedCloneUrl,
 			Source:   source,
 		},
 		PullRequest: models.PullRequest{
 			SourceRef: models.Ref{
-				Branch: os.Getenv(headBranchNameEnv),
+				Branch: src.Getenv(headBranchNameEnv),
 			},
 			TargetRef: models.Ref{
-				Branch: os.Getenv(baseBranchNameEnv),
+				Branch: src.Getenv(baseBranchNameEnv),
 			},
 		},
 		Commits: GetCommits(payload),
@@ -147,9 +181,8 @@ func loadConfiguration() error {
 		PipelinePaths: pipelines,
 		Environment:   source,
 		ScmId:         scmId,
-	}
-
-	return nil
+		Warnings:      warnings,
+	}, nil
 }
 
 func getPipelinePath(githubWorkflow string) string {
@@ -159,41 +192,41 @@ func getPipelinePath(githubWorkflow string) string {
 	return ""
 }
 
-func getSource() enums.Source {
-	url := os.Getenv(githubServerEnv)
+func getSource(src envsource.EnvSource) enums.Source {
+	url := src.Getenv(githubServerEnv)
 	if url == "https://github.com" {
 		return enums.Github
 	}
 	return enums.GithubServer
 }
 
-func getBranch() string {
-	if os.Getenv(githubEventNameEnv) == pullRequestEventName {
-		return os.Getenv(headBranchNameEnv)
+func getBranch(src envsource.EnvSource) string {
+	if src.Getenv(githubEventNameEnv) == pullRequestEventName {
+		return src.Getenv(headBranchNameEnv)
 	}
-	return os.Getenv(branchEnv)
+	return src.Getenv(branchEnv)
 }
 
-func (e environment) GetStepLink() string {
+func (e *Environment) GetStepLink() string {
 	return fmt.Sprintf("%s/%s/actions/runs/%s", os.Getenv(githubServerEnv), os.Getenv(githubRepositoryEnv), os.Getenv(githubRunIdEnv))
 }
 
-func (e environment) GetBuildLink() string {
+func (e *Environment) GetBuildLink() string {
 	return fmt.Sprintf("%s/%s/actions/runs/%s", os.Getenv(githubServerEnv), os.Getenv(githubRepositoryEnv), os.Getenv(githubRunIdEnv))
 }
 
-func (e environment) GetFileLink(filename string, branch string, commit string) string {
+func (e *Environment) GetFileLink(filename string, branch string, commit string) string {
 	return GetFileLink(
-		fmt.Sprintf("%s/%s", os.Getenv(githubServerEnv), os.Getenv(githubRepositoryEnv)),
+		getRepositoryUrl(),
 		filename,
 		branch,
 		commit,
 	)
 }
 
-func (e environment) GetFileLineLink(filename string, branch string, commit string, startLine int, endLine int) string {
+func (e *Environment) GetFileLineLink(filename string, branch string, commit string, startLine int, endLine int) string {
 	return GetFileLineLink(
-		fmt.Sprintf("%s/%s", os.Getenv(githubServerEnv), os.Getenv(githubRepositoryEnv)),
+		getRepositoryUrl(),
 		filename,
 		branch,
 		commit,
@@ -202,6 +235,22 @@ func (e environment) GetFileLineLink(filename string, branch string, commit stri
 	)
 }
 
+func (e *Environment) GetCommitLink(commit string) string {
+	return GetCommitLink(getRepositoryUrl(), commit)
+}
+
+func (e *Environment) GetCompareLink(baseCommit string, headCommit string) string {
+	return GetCompareLink(getRepositoryUrl(), baseCommit, headCommit)
+}
+
+func (e *Environment) GetPullRequestLink(pullRequestId string) string {
+	return GetPullRequestLink(getRepositoryUrl(), pullRequestId)
+}
+
+func (e *Environment) GetRefLink(ref string) string {
+	return GetRefLink(getRepositoryUrl(), ref)
+}
+
 func GetFileLink(repositoryURL string, filename string, branch string, commit string) string {
 	refToUse := branch
 	if commit != "" {
@@ -227,19 +276,46 @@ func GetFileLineLink(repositoryURL string, filename string, branch string, commi
 	return url
 }
 
-func (e environment) IsCurrentEnvironment() bool {
+// IsCurrentEnvironment checks for the GITHUB_WORKFLOW variable, unless the run is a Gitea or Forgejo Actions run
+func (e *Environment) IsCurrentEnvironment() bool {
+	if os.Getenv(giteaActionsEnv) == "true" || os.Getenv(forgejoActionsEnv) == "true" {
+		return false
+	}
 	_, isExists := os.LookupEnv(githubWorkflowEnv)
 	return isExists
 }
 
-func (e environment) Name() string {
+// DetectionVariables returns the variables used by IsCurrentEnvironment
+func (e *Environment) DetectionVariables() []string {
+	return []string{githubWorkflowEnv}
+}
+
+// ExpectedFields returns the configuration fields the environment is expected to fill and the variables they are filled from
+func (e *Environment) ExpectedFields() []models.ExpectedField {
+	return []models.ExpectedField{
+		{Field: "repository.url", SourceEnv: githubRepositoryEnv, Severity: models.SeverityCritical},
+		{Field: "repository.cloneUrl", SourceEnv: githubRepositoryEnv, Severity: models.SeverityCritical},
+		{Field: "commitSha", SourceEnv: commitShaEnv, Severity: models.SeverityCritical},
+		{Field: "branch", SourceEnv: branchEnv, Severity: models.SeverityWarning},
+		{Field: "url", SourceEnv: githubServerEnv, Severity: models.SeverityWarning},
+		{Field: "scmApiUrl", SourceEnv: githubApiUrlEnv, Severity: models.SeverityWarning},
+		{Field: "localPath", SourceEnv: repositoryPathEnv, Severity: models.SeverityWarning},
+		{Field: "pipeline.name", SourceEnv: githubWorkflowEnv, Severity: models.SeverityWarning},
+		{Field: "job.name", SourceEnv: githubJobEnv, Severity: models.SeverityWarning},
+		{Field: "run.buildId", SourceEnv: githubRunIdEnv, Severity: models.SeverityWarning},
+		{Field: "runner.name", SourceEnv: runnerNameEnv, Severity: models.SeverityWarning},
+		{Field: "organization.name", SourceEnv: githubEventPath, Severity: models.SeverityWarning},
+	}
+}
+
+func (e *Environment) Name() string {
 	return "github"
 }
 
-func initPayload() (*GithubPayload, error) {
+func initPayload(src envsource.EnvSource) (*GithubPayload, error) {
 	var payload *GithubPayload
 
-	payloadFile, err := os.ReadFile(os.Getenv(githubEventPath))
+	payloadFile, err := envsource.ReadFile(src, src.Getenv(githubEventPath))
 	if err != nil {
 		return nil, err
 	}
@@ -270,12 +346,25 @@ func GetCommits(payload *GithubPayload) []models.Commit {
 	return commits
 }
 
+// GetPipelinePaths finds the workflow files of the repository, directories that cannot be read are skipped
 func GetPipelinePaths(rootDir string) []string {
+	paths, _ := GetPipelinePathsFrom(envsource.OS, rootDir)
+	return paths
+}
+
+// GetPipelinePathsFrom finds the workflow files of the repository in the files of the source,
+// directories that cannot be read are skipped and reported as warnings
+func GetPipelinePathsFrom(src envsource.EnvSource, rootDir string) ([]string, []models.Warning) {
 	paths := make([]string, 0)
+	var warnings []models.Warning
 
 	rootDirDepth := len(strings.Split(rootDir, "/"))
 
-	filepath.Walk(rootDir, func(path string, info fs.FileInfo, err error) error {
+	err := envsource.Walk(src, rootDir, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			warnings = append(warnings, models.NewWarning(models.PipelinePathsWarning, err, "failed to search %s for pipeline files", path))
+			return nil
+		}
 		if info.IsDir() {
 			if info.Name() == githubDir || strings.HasSuffix(path, workflowsDir) {
 				return nil
@@ -292,6 +381,14 @@ func GetPipelinePaths(rootDir string) []string {
 		}
 		return nil
 	})
+	if err != nil {
+		warnings = append(warnings, models.NewWarning(models.PipelinePathsWarning, err, "failed to search %s for pipeline files", rootDir))
+	}
 
-	return paths
+	return paths, warnings
+}
+
+// getRepositoryUrl returns the url of the repository on GitHub or GitHub Enterprise Server
+func getRepositoryUrl() string {
+	return fmt.Sprintf("%s/%s", os.Getenv(githubServerEnv), os.Getenv(githubRepositoryEnv))
 }
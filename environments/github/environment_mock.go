@@ -5,6 +5,7 @@ import (
 	"net/url"
 
 	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
 	"github.com/argonsecurity/go-environments/models"
 )
 
@@ -26,6 +27,19 @@ func (em *EnvironmentMock) GetConfiguration() (*models.Configuration, error) {
 	return mockConfiguration, nil
 }
 
+func (em *EnvironmentMock) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return em.GetConfiguration()
+}
+
+func (em *EnvironmentMock) Refresh() (*models.Configuration, error) {
+	em.Reset()
+	return em.GetConfiguration()
+}
+
+func (em *EnvironmentMock) Reset() {
+	mockConfiguration = nil
+}
+
 func loadMockConfiguration() error {
 	mockConfiguration = &models.Configuration{
 		Url:       "https://github.com",
@@ -106,6 +120,22 @@ func (em *EnvironmentMock) GetFileLineLink(filename string, branch string, commi
 	return fmt.Sprintf("https://github.com/%s/%s/blob/%s/%s", MockOrgName, MockRepoName, branch, url.PathEscape(filename))
 }
 
+func (em *EnvironmentMock) GetCommitLink(commit string) string {
+	return ""
+}
+
+func (em *EnvironmentMock) GetCompareLink(baseCommit string, headCommit string) string {
+	return ""
+}
+
+func (em *EnvironmentMock) GetPullRequestLink(pullRequestId string) string {
+	return ""
+}
+
+func (em *EnvironmentMock) GetRefLink(ref string) string {
+	return ""
+}
+
 func (em *EnvironmentMock) IsCurrentEnvironment() bool {
 	return true
 }
@@ -1,6 +1,7 @@
 package github
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
 	"runtime"
@@ -8,6 +9,8 @@ import (
 
 	"github.com/argonsecurity/go-environments/enums"
 	"github.com/argonsecurity/go-environments/environments/testutils"
+	"github.com/argonsecurity/go-environments/environments/testutils/mocks"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
 	"github.com/argonsecurity/go-environments/models"
 	"github.com/stretchr/testify/assert"
 )
@@ -272,6 +275,109 @@ func Test_environment_GetConfiguration(t *testing.T) {
 	}
 }
 
+func Test_environment_GetConfigurationFrom(t *testing.T) {
+	tests := []struct {
+		name         string
+		envsFilePath string
+	}{
+		{
+			name:         "GitHub main configuration",
+			envsFilePath: githubMainEnvsFilePath,
+		},
+		{
+			name:         "GitHub PR configuration",
+			envsFilePath: githubPrEnvsFilePath,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var want *models.Configuration
+			t.Run("from process environment", func(t *testing.T) {
+				var err error
+				want, err = prepareTest(t, tt.envsFilePath).GetConfiguration()
+				assert.NoError(t, err)
+			})
+
+			e := prepareTest(t, "")
+			got, err := e.GetConfigurationFrom(envsource.New(testutils.LoadEnvsFromFile(tt.envsFilePath)))
+			assert.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func Test_environment_GetConfigurationFromInvalidRepository(t *testing.T) {
+	for _, repository := range []string{"", "test-repo"} {
+		t.Run(repository, func(t *testing.T) {
+			envs := testutils.LoadEnvsFromFile(githubMainEnvsFilePath)
+			envs["GITHUB_REPOSITORY"] = repository
+
+			got, err := prepareTest(t, "").GetConfigurationFrom(envsource.New(envs))
+			assert.Error(t, err)
+			assert.Nil(t, got)
+		})
+	}
+}
+
+func Test_environment_GetConfigurationWarnings(t *testing.T) {
+	envs := testutils.LoadEnvsFromFile(githubMainEnvsFilePath)
+	envs[repositoryPathEnv] = filepath.Join(t.TempDir(), "missing")
+	gitClient := (&mocks.MockGitClient{}).SetError(errors.New("not a git repository"))
+
+	got, err := New().GetConfigurationFrom(envsource.New(envs).WithGitClient(gitClient))
+	assert.NoError(t, err)
+	assert.Equal(t, "https://github.com/test-org/test-repo.git", got.Repository.CloneUrl)
+	assert.Empty(t, got.PipelinePaths)
+
+	codes := []models.WarningCode{}
+	for _, warning := range got.Warnings {
+		codes = append(codes, warning.Code)
+	}
+	assert.Equal(t, []models.WarningCode{models.GitRemoteUrlWarning, models.PipelinePathsWarning}, codes)
+	assert.EqualError(t, got.Warnings[0].Err, "not a git repository")
+}
+
+func TestGetPipelinePaths(t *testing.T) {
+	prepareTest(t, githubMainEnvsFilePath)
+	want := []string{
+		filepath.Join(testRepoPath, ".github/workflows/first.yml"),
+		filepath.Join(testRepoPath, ".github/workflows/second.yaml"),
+	}
+	assert.Equal(t, want, GetPipelinePaths(testRepoPath))
+
+	paths, warnings := GetPipelinePathsFrom(envsource.OS, filepath.Join(t.TempDir(), "missing"))
+	assert.Empty(t, paths)
+	assert.Len(t, warnings, 1)
+}
+
+func Test_environment_Refresh(t *testing.T) {
+	e := prepareTest(t, githubMainEnvsFilePath)
+	other := New()
+
+	cached, err := e.GetConfiguration()
+	assert.NoError(t, err)
+	assert.Equal(t, "3", cached.Run.BuildNumber)
+
+	t.Setenv(githubRunNumberEnv, "4")
+	got, err := e.GetConfiguration()
+	assert.NoError(t, err)
+	assert.Same(t, cached, got)
+
+	got, err = other.GetConfiguration()
+	assert.NoError(t, err)
+	assert.Equal(t, "4", got.Run.BuildNumber)
+
+	got, err = e.Refresh()
+	assert.NoError(t, err)
+	assert.Equal(t, "4", got.Run.BuildNumber)
+
+	t.Setenv(githubRunNumberEnv, "5")
+	e.Reset()
+	got, err = e.GetConfiguration()
+	assert.NoError(t, err)
+	assert.Equal(t, "5", got.Run.BuildNumber)
+}
+
 func Test_environment_GetStepLink(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -445,6 +551,34 @@ func Test_environment_GetFileLineLink(t *testing.T) {
 	}
 }
 
+func Test_environment_GetCommitAndRefLinks(t *testing.T) {
+	tests := []struct {
+		name         string
+		envsFilePath string
+		repoUrl      string
+	}{
+		{
+			name:         "GitHub",
+			envsFilePath: githubMainEnvsFilePath,
+			repoUrl:      "https://github.com/test-org/test-repo",
+		},
+		{
+			name:         "GitHub Server",
+			envsFilePath: githubServerEnvsFilePath,
+			repoUrl:      "https://github.test.com/test-org/test-repo",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := prepareTest(t, tt.envsFilePath)
+			assert.Equal(t, tt.repoUrl+"/commit/0a1b2c", e.GetCommitLink("0a1b2c"))
+			assert.Equal(t, tt.repoUrl+"/compare/9f8e7d...0a1b2c", e.GetCompareLink("9f8e7d", "0a1b2c"))
+			assert.Equal(t, tt.repoUrl+"/pull/12", e.GetPullRequestLink("12"))
+			assert.Equal(t, tt.repoUrl+"/tree/v1.0", e.GetRefLink("refs/tags/v1.0"))
+		})
+	}
+}
+
 func TestGetFileLink(t *testing.T) {
 	type args struct {
 		repositoryURL string
@@ -589,6 +723,11 @@ func Test_environment_IsCurrentEnvironment(t *testing.T) {
 			envsFilePath: githubPrEnvsFilePath,
 			want:         true,
 		},
+		{
+			name:         "Gitea environment",
+			envsFilePath: "../gitea/testdata/gitea-actions-main-env.json",
+			want:         false,
+		},
 		{
 			name:         "Not GitHub environment",
 			envsFilePath: "",
@@ -605,9 +744,8 @@ func Test_environment_IsCurrentEnvironment(t *testing.T) {
 	}
 }
 
-func prepareTest(t *testing.T, envsFilePath string) environment {
-	e := environment{}
-	configuration = nil
+func prepareTest(t *testing.T, envsFilePath string) *Environment {
+	e := New()
 	testRepoCleanup := testutils.PrepareTestGitRepository(testRepoPath, testRepoCloneUrl, testdataPath)
 	t.Cleanup(testRepoCleanup)
 	envCleanup := testutils.SetEnvsFromFile(envsFilePath)
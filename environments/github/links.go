@@ -0,0 +1,69 @@
+package github
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/argonsecurity/go-environments/environments/utils"
+)
+
+func GetCommitLink(repositoryURL string, commit string) string {
+	if commit == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/commit/%s", repositoryURL, commit)
+}
+
+// GetCompareLink returns the link to the diff between two commits, GitHub compares with the three-dot syntax
+func GetCompareLink(repositoryURL string, baseCommit string, headCommit string) string {
+	if baseCommit == "" || headCommit == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/compare/%s...%s", repositoryURL, baseCommit, headCommit)
+}
+
+func GetPullRequestLink(repositoryURL string, pullRequestId string) string {
+	if pullRequestId == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/pull/%s", repositoryURL, pullRequestId)
+}
+
+// GetRefLink returns the link to the tree of a branch or tag, GitHub serves both under tree/<name>
+func GetRefLink(repositoryURL string, ref string) string {
+	name, _ := utils.ParseRef(ref)
+	if name == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/tree/%s", repositoryURL, name)
+}
+
+var (
+	// https://github.com/org/repo/blob/<ref>/path/to/file
+	fileLinkRegexp = regexp.MustCompile(`^(.+?)/blob/([^/]+)/(.+)$`)
+	// #L10-L20, or #L10 for a single line
+	linesRegexp = regexp.MustCompile(`^L(\d+)(?:-L(\d+))?$`)
+)
+
+// ParseFileLineLink returns the repository url, the file, the ref and the lines of a link built by GetFileLineLink,
+// the ref is refs/heads/<branch> for a branch and the commit for a 40 character commit sha, and the end line is the start line for a single line
+// Branches with slashes can't be told from the file, the ref is read up to the first slash
+func ParseFileLineLink(link string) (string, string, string, int, int, error) {
+	link, fragment, _ := strings.Cut(link, "#")
+	result := fileLinkRegexp.FindStringSubmatch(link)
+	if result == nil {
+		return "", "", "", 0, 0, fmt.Errorf("could not parse file link: %s", link)
+	}
+
+	startLine, endLine := 0, 0
+	if lines := linesRegexp.FindStringSubmatch(fragment); lines != nil {
+		startLine, _ = strconv.Atoi(lines[1])
+		endLine = startLine
+		if lines[2] != "" {
+			endLine, _ = strconv.Atoi(lines[2])
+		}
+	}
+	return result[1], result[3], utils.GetLinkRef(result[2]), startLine, endLine, nil
+}
@@ -0,0 +1,181 @@
+package github
+
+import (
+	"testing"
+)
+
+func TestGetCommitLink(t *testing.T) {
+	tests := []struct {
+		name   string
+		commit string
+		want   string
+	}{
+		{
+			name:   "With commit",
+			commit: "0a1b2c",
+			want:   "https://github.com/org/repo/commit/0a1b2c",
+		},
+		{
+			name:   "Without commit",
+			commit: "",
+			want:   "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetCommitLink("https://github.com/org/repo", tt.commit); got != tt.want {
+				t.Errorf("GetCommitLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetCompareLink(t *testing.T) {
+	tests := []struct {
+		name       string
+		baseCommit string
+		headCommit string
+		want       string
+	}{
+		{
+			name:       "With base and head commits",
+			baseCommit: "9f8e7d",
+			headCommit: "0a1b2c",
+			want:       "https://github.com/org/repo/compare/9f8e7d...0a1b2c",
+		},
+		{
+			name:       "Without base commit",
+			headCommit: "0a1b2c",
+			want:       "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetCompareLink("https://github.com/org/repo", tt.baseCommit, tt.headCommit); got != tt.want {
+				t.Errorf("GetCompareLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetPullRequestLink(t *testing.T) {
+	tests := []struct {
+		name          string
+		pullRequestId string
+		want          string
+	}{
+		{
+			name:          "With pull request id",
+			pullRequestId: "12",
+			want:          "https://github.com/org/repo/pull/12",
+		},
+		{
+			name:          "Without pull request id",
+			pullRequestId: "",
+			want:          "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetPullRequestLink("https://github.com/org/repo", tt.pullRequestId); got != tt.want {
+				t.Errorf("GetPullRequestLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetRefLink(t *testing.T) {
+	tests := []struct {
+		name string
+		ref  string
+		want string
+	}{
+		{
+			name: "Branch name",
+			ref:  "feature/x",
+			want: "https://github.com/org/repo/tree/feature/x",
+		},
+		{
+			name: "Branch ref",
+			ref:  "refs/heads/feature/x",
+			want: "https://github.com/org/repo/tree/feature/x",
+		},
+		{
+			name: "Tag ref",
+			ref:  "refs/tags/v1.0",
+			want: "https://github.com/org/repo/tree/v1.0",
+		},
+		{
+			name: "Empty ref",
+			ref:  "",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetRefLink("https://github.com/org/repo", tt.ref); got != tt.want {
+				t.Errorf("GetRefLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFileLineLink(t *testing.T) {
+	tests := []struct {
+		name              string
+		link              string
+		wantRepositoryURL string
+		wantFilename      string
+		wantRef           string
+		wantStartLine     int
+		wantEndLine       int
+		wantErr           bool
+	}{
+		{
+			name:              "Lines",
+			link:              "https://github.com/org/repo/blob/main/path/to/file#L10-L20",
+			wantRepositoryURL: "https://github.com/org/repo",
+			wantFilename:      "path/to/file",
+			wantRef:           "refs/heads/main",
+			wantStartLine:     10,
+			wantEndLine:       20,
+		},
+		{
+			name:              "Single line",
+			link:              "https://github.com/org/repo/blob/0a1b2c3d4e5f60718293a4b5c6d7e8f901234567/path/to/file#L10",
+			wantRepositoryURL: "https://github.com/org/repo",
+			wantFilename:      "path/to/file",
+			wantRef:           "0a1b2c3d4e5f60718293a4b5c6d7e8f901234567",
+			wantStartLine:     10,
+			wantEndLine:       10,
+		},
+		{
+			name:              "Other anchor",
+			link:              "https://github.com/org/repo/blob/main/README.md#usage",
+			wantRepositoryURL: "https://github.com/org/repo",
+			wantFilename:      "README.md",
+			wantRef:           "refs/heads/main",
+			wantStartLine:     0,
+			wantEndLine:       0,
+		},
+		{
+			name:    "Not a file link",
+			link:    "https://github.com/org/repo/tree/main",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repositoryURL, filename, ref, startLine, endLine, err := ParseFileLineLink(tt.link)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFileLineLink() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if repositoryURL != tt.wantRepositoryURL || filename != tt.wantFilename || ref != tt.wantRef {
+				t.Errorf("ParseFileLineLink() = %v, %v, %v, want %v, %v, %v", repositoryURL, filename, ref, tt.wantRepositoryURL, tt.wantFilename, tt.wantRef)
+			}
+			if startLine != tt.wantStartLine || endLine != tt.wantEndLine {
+				t.Errorf("ParseFileLineLink() lines = %v-%v, want %v-%v", startLine, endLine, tt.wantStartLine, tt.wantEndLine)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/argonsecurity/go-environments/enums"
 	"github.com/argonsecurity/go-environments/environments/utils"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
 	"github.com/argonsecurity/go-environments/models"
 )
 
@@ -44,101 +45,123 @@ const (
 
 	pipelineIdEnv = "CI_PIPELINE_ID"
 	gitlabUrlEnv  = "CI_SERVER_URL"
+	gitlabCIEnv   = "GITLAB_CI"
 )
 
 var (
-	Gitlab        = environment{}
-	configuration *models.Configuration
+	Gitlab = New()
 
 	gitlabPipelines = []string{".gitlab-ci.yml", ".gitlab-ci.yaml"}
 )
 
-type environment struct{}
+// Environment is the GitLab environment
+type Environment struct {
+	cache utils.ConfigurationCache
+}
 
-func (e environment) GetConfiguration() (*models.Configuration, error) {
-	if configuration == nil {
-		loadConfiguration()
-	}
-	return configuration, nil
+// New creates a GitLab environment with its own configuration cache
+func New() *Environment {
+	return &Environment{}
+}
+
+func (e *Environment) GetConfiguration() (*models.Configuration, error) {
+	return e.cache.Get(e.load)
 }
 
-func loadConfiguration() *models.Configuration {
-	source := getSource()
-	repoPath := os.Getenv(repositoryPathEnv)
-	cloneUrl := utils.StripCredentialsFromUrl(os.Getenv(repositoryCloneURLEnv))
+// Refresh loads the configuration again and replaces the cached configuration
+func (e *Environment) Refresh() (*models.Configuration, error) {
+	return e.cache.Refresh(e.load)
+}
+
+// Reset clears the cached configuration, it is loaded again on the next GetConfiguration
+func (e *Environment) Reset() {
+	e.cache.Reset()
+}
+
+func (e *Environment) load() (*models.Configuration, error) {
+	return loadConfiguration(envsource.OS), nil
+}
+
+func (e *Environment) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return loadConfiguration(src), nil
+}
+
+func loadConfiguration(src envsource.EnvSource) *models.Configuration {
+	source := getSource(src)
+	repoPath := src.Getenv(repositoryPathEnv)
+	cloneUrl := utils.StripCredentialsFromUrl(src.Getenv(repositoryCloneURLEnv))
 	if !strings.HasSuffix(cloneUrl, ".git") {
 		cloneUrl += ".git"
 	}
 	scmId := utils.GenerateScmId(cloneUrl)
 
-	configuration = &models.Configuration{
-		Url:             os.Getenv(gitlabUrlEnv),
-		SCMApiUrl:       os.Getenv(gitlabUrlEnv),
+	return &models.Configuration{
+		Url:             src.Getenv(gitlabUrlEnv),
+		SCMApiUrl:       src.Getenv(gitlabUrlEnv),
 		LocalPath:       repoPath,
-		Branch:          os.Getenv(branchEnv),
-		CommitSha:       os.Getenv(commitShaEnv),
-		BeforeCommitSha: os.Getenv(beforeCommitShaEnv),
+		Branch:          src.Getenv(branchEnv),
+		CommitSha:       src.Getenv(commitShaEnv),
+		BeforeCommitSha: src.Getenv(beforeCommitShaEnv),
 		Organization: models.Entity{
-			Name: os.Getenv(rootNamespaceEnv),
+			Name: src.Getenv(rootNamespaceEnv),
 		},
 		Repository: models.Repository{
-			Id:       os.Getenv(projectIdEnv),
-			Name:     os.Getenv(projectNameEnv),
-			FullName: os.Getenv(projectPathEnv),
-			Url:      os.Getenv(projectUrlEnv),
+			Id:       src.Getenv(projectIdEnv),
+			Name:     src.Getenv(projectNameEnv),
+			FullName: src.Getenv(projectPathEnv),
+			Url:      src.Getenv(projectUrlEnv),
 			CloneUrl: cloneUrl,
 			Source:   source,
 		},
 		Pipeline: models.Pipeline{
 			Entity: models.Entity{
-				Id:   os.Getenv(pipelineIdEnv), // Each GitLab project has only one pipeline
-				Name: os.Getenv(projectNameEnv),
+				Id:   src.Getenv(pipelineIdEnv), // Each GitLab project has only one pipeline
+				Name: src.Getenv(projectNameEnv),
 			},
-			Path: os.Getenv(pipelineFilePathEnv),
+			Path: src.Getenv(pipelineFilePathEnv),
 		},
 		Job: models.Entity{
-			Id:   os.Getenv(jobNameEnv),
-			Name: os.Getenv(jobNameEnv),
+			Id:   src.Getenv(jobNameEnv),
+			Name: src.Getenv(jobNameEnv),
 		},
 		Run: models.BuildRun{
-			BuildId: os.Getenv(jobIdEnv),
+			BuildId: src.Getenv(jobIdEnv),
 		},
 		Runner: models.Runner{
-			Id:           os.Getenv(runnerIdEnv),
-			Name:         os.Getenv(runnerDescriptionEnv),
-			OS:           os.Getenv(runnerOSEnv),
+			Id:           src.Getenv(runnerIdEnv),
+			Name:         src.Getenv(runnerDescriptionEnv),
+			OS:           src.Getenv(runnerOSEnv),
 			Architecture: runtime.GOARCH,
 		},
 		PullRequest: models.PullRequest{
-			Id: os.Getenv(mergeRequestIdEnv),
+			Id: src.Getenv(mergeRequestIdEnv),
 			SourceRef: models.Ref{
-				Branch: os.Getenv(mergeSourceBranchName),
-				Sha:    os.Getenv(mergeSourceBranchSha),
+				Branch: src.Getenv(mergeSourceBranchName),
+				Sha:    src.Getenv(mergeSourceBranchSha),
 			},
 			TargetRef: models.Ref{
-				Branch: os.Getenv(mergeTargetBranchName),
-				Sha:    os.Getenv(mergeTargetBranchSha),
+				Branch: src.Getenv(mergeTargetBranchName),
+				Sha:    src.Getenv(mergeTargetBranchSha),
 			},
 		},
 		Pusher: models.Pusher{
-			Username: getUsername(),
+			Username: getUsername(src),
 		},
-		PipelinePaths: getPipelinePaths(repoPath),
+		PipelinePaths: getPipelinePaths(src, repoPath),
 		Environment:   source,
 		ScmId:         scmId,
 	}
-	return configuration
 }
 
-func (e environment) GetStepLink() string {
+func (e *Environment) GetStepLink() string {
 	return fmt.Sprintf("%s/%s/%s/-/jobs/%s", os.Getenv(gitlabUrlEnv), os.Getenv(groupNameEnv), os.Getenv(projectNameEnv), os.Getenv(jobIdEnv))
 }
 
-func (e environment) GetBuildLink() string {
+func (e *Environment) GetBuildLink() string {
 	return fmt.Sprintf("%s/%s/%s/-/pipelines/%s", os.Getenv(gitlabUrlEnv), os.Getenv(groupNameEnv), os.Getenv(projectNameEnv), os.Getenv(pipelineIdEnv))
 }
 
-func (e environment) GetFileLink(filename string, branch string, commit string) string {
+func (e *Environment) GetFileLink(filename string, branch string, commit string) string {
 	repoURL := os.Getenv(projectUrlEnv)
 	return GetFileLink(
 		repoURL,
@@ -148,7 +171,7 @@ func (e environment) GetFileLink(filename string, branch string, commit string)
 	)
 }
 
-func (e environment) GetFileLineLink(filename string, branch string, commit string, startLine int, endLine int) string {
+func (e *Environment) GetFileLineLink(filename string, branch string, commit string, startLine int, endLine int) string {
 	repoURL := os.Getenv(projectUrlEnv)
 	return GetFileLineLink(
 		repoURL,
@@ -160,6 +183,22 @@ func (e environment) GetFileLineLink(filename string, branch string, commit stri
 	)
 }
 
+func (e *Environment) GetCommitLink(commit string) string {
+	return GetCommitLink(os.Getenv(projectUrlEnv), commit)
+}
+
+func (e *Environment) GetCompareLink(baseCommit string, headCommit string) string {
+	return GetCompareLink(os.Getenv(projectUrlEnv), baseCommit, headCommit)
+}
+
+func (e *Environment) GetPullRequestLink(pullRequestId string) string {
+	return GetPullRequestLink(os.Getenv(projectUrlEnv), pullRequestId)
+}
+
+func (e *Environment) GetRefLink(ref string) string {
+	return GetRefLink(os.Getenv(projectUrlEnv), ref)
+}
+
 func GetFileLink(repositoryURL string, filename, branch string, commit string) string {
 	refToUse := branch
 	if commit != "" {
@@ -185,29 +224,53 @@ func GetFileLineLink(repositoryURL string, filename string, branch string, commi
 	return url
 }
 
-func (e environment) Name() string {
+func (e *Environment) Name() string {
 	return "gitlab"
 }
 
-func getSource() enums.Source {
-	url := os.Getenv(gitlabUrlEnv)
+func getSource(src envsource.EnvSource) enums.Source {
+	url := src.Getenv(gitlabUrlEnv)
 	if url == "https://gitlab.com" {
 		return enums.Gitlab
 	}
 	return enums.GitlabServer
 }
 
-func (e environment) IsCurrentEnvironment() bool {
-	_, isExist := os.LookupEnv("GITLAB_CI")
+func (e *Environment) IsCurrentEnvironment() bool {
+	_, isExist := os.LookupEnv(gitlabCIEnv)
 	return isExist
 }
 
-func getPipelinePaths(rootDir string) []string {
+// DetectionVariables returns the variables used by IsCurrentEnvironment
+func (e *Environment) DetectionVariables() []string {
+	return []string{gitlabCIEnv}
+}
+
+// ExpectedFields returns the configuration fields the environment is expected to fill and the variables they are filled from
+func (e *Environment) ExpectedFields() []models.ExpectedField {
+	return []models.ExpectedField{
+		{Field: "repository.url", SourceEnv: projectUrlEnv, Severity: models.SeverityCritical},
+		{Field: "repository.cloneUrl", SourceEnv: repositoryCloneURLEnv, Severity: models.SeverityCritical},
+		{Field: "commitSha", SourceEnv: commitShaEnv, Severity: models.SeverityCritical},
+		{Field: "branch", SourceEnv: branchEnv, Severity: models.SeverityWarning},
+		{Field: "url", SourceEnv: gitlabUrlEnv, Severity: models.SeverityWarning},
+		{Field: "localPath", SourceEnv: repositoryPathEnv, Severity: models.SeverityWarning},
+		{Field: "repository.id", SourceEnv: projectIdEnv, Severity: models.SeverityWarning},
+		{Field: "repository.fullName", SourceEnv: projectPathEnv, Severity: models.SeverityWarning},
+		{Field: "pipeline.id", SourceEnv: pipelineIdEnv, Severity: models.SeverityWarning},
+		{Field: "job.name", SourceEnv: jobNameEnv, Severity: models.SeverityWarning},
+		{Field: "run.buildId", SourceEnv: jobIdEnv, Severity: models.SeverityWarning},
+		{Field: "runner.id", SourceEnv: runnerIdEnv, Severity: models.SeverityWarning},
+		{Field: "organization.name", SourceEnv: rootNamespaceEnv, Severity: models.SeverityWarning},
+	}
+}
+
+func getPipelinePaths(src envsource.EnvSource, rootDir string) []string {
 	paths := make([]string, 0)
 
 	for _, pipelineFile := range gitlabPipelines {
 		path := filepath.Join(rootDir, pipelineFile)
-		if _, err := os.Stat(path); err == nil {
+		if _, err := envsource.Stat(src, path); err == nil {
 			paths = append(paths, path)
 		}
 	}
@@ -215,11 +278,11 @@ func getPipelinePaths(rootDir string) []string {
 	return paths
 }
 
-func getUsername() string {
-	arr := strings.Split(os.Getenv(commitAuthorEnv), " ")
+func getUsername(src envsource.EnvSource) string {
+	arr := strings.Split(src.Getenv(commitAuthorEnv), " ")
 	username := strings.Join(arr[:len(arr)-1][:], " ")
 	if username == "" {
-		username = utils.DetectPusher()
+		username = utils.DetectPusherFrom(src)
 	}
 	return username
 }
@@ -5,6 +5,7 @@ import (
 	"net/url"
 
 	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
 	"github.com/argonsecurity/go-environments/models"
 )
 
@@ -27,6 +28,19 @@ func (em *EnvironmentMock) GetConfiguration() (*models.Configuration, error) {
 	return mockConfiguration, nil
 }
 
+func (em *EnvironmentMock) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return em.GetConfiguration()
+}
+
+func (em *EnvironmentMock) Refresh() (*models.Configuration, error) {
+	em.Reset()
+	return em.GetConfiguration()
+}
+
+func (em *EnvironmentMock) Reset() {
+	mockConfiguration = nil
+}
+
 func loadMockConfiguration() error {
 	mockConfiguration = &models.Configuration{
 		Url:             "https://gitlab.com",
@@ -98,6 +112,22 @@ func (em *EnvironmentMock) GetFileLineLink(filename string, branch string, commi
 	return fmt.Sprintf("https://gitlab.com/%s/%s/%s/-/blob/%s/%s", MockOrgName, MockSubGroups, MockRepoName, branch, url.PathEscape(filename))
 }
 
+func (em *EnvironmentMock) GetCommitLink(commit string) string {
+	return ""
+}
+
+func (em *EnvironmentMock) GetCompareLink(baseCommit string, headCommit string) string {
+	return ""
+}
+
+func (em *EnvironmentMock) GetPullRequestLink(pullRequestId string) string {
+	return ""
+}
+
+func (em *EnvironmentMock) GetRefLink(ref string) string {
+	return ""
+}
+
 func (em *EnvironmentMock) IsCurrentEnvironment() bool {
 	return true
 }
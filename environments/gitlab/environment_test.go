@@ -450,6 +450,14 @@ func Test_environment_GetFileLineLink(t *testing.T) {
 	}
 }
 
+func Test_environment_GetCommitAndRefLinks(t *testing.T) {
+	e := prepareTest(t, gitlabMainEnvsFilePath)
+	assert.Equal(t, "https://gitlab.com/test-group/test-sub-group/test-project/-/commit/0a1b2c", e.GetCommitLink("0a1b2c"))
+	assert.Equal(t, "https://gitlab.com/test-group/test-sub-group/test-project/-/compare/9f8e7d...0a1b2c", e.GetCompareLink("9f8e7d", "0a1b2c"))
+	assert.Equal(t, "https://gitlab.com/test-group/test-sub-group/test-project/-/merge_requests/12", e.GetPullRequestLink("12"))
+	assert.Equal(t, "https://gitlab.com/test-group/test-sub-group/test-project/-/tree/v1.0", e.GetRefLink("refs/tags/v1.0"))
+}
+
 func TestGetFileLink(t *testing.T) {
 	type args struct {
 		repositoryURL string
@@ -609,9 +617,8 @@ func Test_environment_IsCurrentEnvironment(t *testing.T) {
 	}
 }
 
-func prepareTest(t *testing.T, envsFilePath string) environment {
-	e := environment{}
-	configuration = nil
+func prepareTest(t *testing.T, envsFilePath string) *Environment {
+	e := New()
 	testRepoCleanup := testutils.PrepareTestGitRepository(testRepoPath, testRepoCloneUrl, testdataPath)
 	t.Cleanup(testRepoCleanup)
 	envCleanup := testutils.SetEnvsFromFile(envsFilePath)
@@ -0,0 +1,296 @@
+package harness
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/utils"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/models"
+)
+
+const (
+	builder = "Harness"
+
+	// Harness CI also sets the DRONE variables, HARNESS_BUILD_ID tells it apart from Drone
+	harnessBuildIdEnv = "HARNESS_BUILD_ID"
+
+	accountIdEnv   = "HARNESS_ACCOUNT_ID"
+	orgIdEnv       = "HARNESS_ORG_ID"
+	projectIdEnv   = "HARNESS_PROJECT_ID"
+	pipelineIdEnv  = "HARNESS_PIPELINE_ID"
+	executionIdEnv = "HARNESS_EXECUTION_ID"
+	stageIdEnv     = "HARNESS_STAGE_ID"
+	workspaceEnv   = "HARNESS_WORKSPACE"
+
+	droneWorkspaceEnv = "DRONE_WORKSPACE"
+	buildLinkEnv      = "DRONE_BUILD_LINK"
+	commitShaEnv      = "DRONE_COMMIT_SHA"
+	commitBranchEnv   = "DRONE_COMMIT_BRANCH"
+	commitAuthorEnv   = "DRONE_COMMIT_AUTHOR"
+	pullRequestEnv    = "DRONE_PULL_REQUEST"
+	sourceBranchEnv   = "DRONE_SOURCE_BRANCH"
+	targetBranchEnv   = "DRONE_TARGET_BRANCH"
+	stageNameEnv      = "DRONE_STAGE_NAME"
+
+	harnessUrl = "https://app.harness.io"
+
+	// pipelines that are stored in git with Harness Git Experience are kept in the .harness directory
+	harnessDir = ".harness"
+)
+
+var (
+	// Harness environment
+	Harness = New()
+)
+
+// Environment is the Harness environment
+type Environment struct {
+	cache utils.ConfigurationCache
+}
+
+// New creates a Harness environment with its own configuration cache
+func New() *Environment {
+	return &Environment{}
+}
+
+func (e *Environment) GetConfiguration() (*models.Configuration, error) {
+	return e.cache.Get(e.load)
+}
+
+// Refresh loads the configuration again and replaces the cached configuration
+func (e *Environment) Refresh() (*models.Configuration, error) {
+	return e.cache.Refresh(e.load)
+}
+
+// Reset clears the cached configuration, it is loaded again on the next GetConfiguration
+func (e *Environment) Reset() {
+	e.cache.Reset()
+}
+
+func (e *Environment) load() (*models.Configuration, error) {
+	return loadConfiguration(envsource.OS)
+}
+
+func (e *Environment) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return loadConfiguration(src)
+}
+
+func loadConfiguration(src envsource.EnvSource) (*models.Configuration, error) {
+	workspace := getWorkspace(src)
+	repoPath := envsource.FindRepository(src, workspace)
+	if repoPath == "" {
+		repoPath = workspace
+	}
+
+	gitClient := envsource.GitClient(src)
+	cloneUrl, err := gitClient.GetGitRemoteURL(envsource.Path(src, repoPath))
+	if err != nil {
+		return nil, err
+	}
+	cloneUrl = utils.StripCredentialsFromUrl(cloneUrl)
+
+	source, apiUrl := utils.GetRepositorySource(cloneUrl)
+	repoUrl, org, repoName, repoFullName, err := utils.ParseDataFromCloneUrl(cloneUrl, apiUrl, source)
+	if err != nil {
+		return nil, err
+	}
+
+	commit := src.Getenv(commitShaEnv)
+	if commit == "" {
+		if commit, err = gitClient.GetGitCommit(envsource.Path(src, repoPath)); err != nil {
+			return nil, err
+		}
+	}
+
+	var warnings []models.Warning
+	branch := getBranch(src)
+	if branch == "" {
+		branch, warnings, _ = envsource.GetGitBranch(src, repoPath, commit)
+	}
+
+	pullRequest := models.PullRequest{}
+	if prNumber := src.Getenv(pullRequestEnv); prNumber != "" {
+		pullRequest = models.PullRequest{
+			Id: prNumber,
+			SourceRef: models.Ref{
+				Branch: branch,
+				Sha:    commit,
+			},
+			TargetRef: models.Ref{
+				Branch: src.Getenv(targetBranchEnv),
+			},
+		}
+	}
+
+	pipelinePaths, pipelinesWarnings := getPipelinePaths(src, repoPath)
+	warnings = append(warnings, pipelinesWarnings...)
+	return &models.Configuration{
+		Url:       harnessUrl,
+		SCMApiUrl: apiUrl,
+		LocalPath: repoPath,
+		CommitSha: commit,
+		Branch:    branch,
+		Repository: models.Repository{
+			Name:     repoName,
+			FullName: repoFullName,
+			Url:      repoUrl,
+			CloneUrl: cloneUrl,
+			Source:   source,
+		},
+		Organization: models.Entity{
+			Id:   src.Getenv(orgIdEnv),
+			Name: org,
+		},
+		Pipeline: models.Pipeline{
+			Entity: models.Entity{
+				Id:   src.Getenv(pipelineIdEnv),
+				Name: src.Getenv(pipelineIdEnv),
+			},
+		},
+		Job: models.Entity{
+			Id:   src.Getenv(stageIdEnv),
+			Name: getStageName(src),
+		},
+		Run: models.BuildRun{
+			BuildId:     src.Getenv(executionIdEnv),
+			BuildNumber: src.Getenv(harnessBuildIdEnv),
+		},
+		Runner: models.Runner{
+			OS:           runtime.GOOS,
+			Architecture: runtime.GOARCH,
+		},
+		PullRequest: pullRequest,
+		Builder:     builder,
+		Pusher: models.Pusher{
+			Username: getPusher(src),
+		},
+		PipelinePaths: pipelinePaths,
+		Environment:   enums.Harness,
+		ScmId:         utils.GenerateScmId(cloneUrl),
+		Warnings:      warnings,
+	}, nil
+}
+
+// getWorkspace returns the directory that the codebase is cloned into, /harness by default
+func getWorkspace(src envsource.EnvSource) string {
+	for _, env := range []string{workspaceEnv, droneWorkspaceEnv} {
+		if workspace := src.Getenv(env); workspace != "" {
+			return workspace
+		}
+	}
+	return envsource.Getwd(src)
+}
+
+// getBranch returns the source branch of pull request builds and the pushed branch of push builds
+func getBranch(src envsource.EnvSource) string {
+	if branch := src.Getenv(sourceBranchEnv); branch != "" && src.Getenv(pullRequestEnv) != "" {
+		return branch
+	}
+	return src.Getenv(commitBranchEnv)
+}
+
+func getStageName(src envsource.EnvSource) string {
+	if name := src.Getenv(stageNameEnv); name != "" {
+		return name
+	}
+	return src.Getenv(stageIdEnv)
+}
+
+func getPusher(src envsource.EnvSource) string {
+	if author := src.Getenv(commitAuthorEnv); author != "" {
+		return author
+	}
+	return utils.DetectPusherFrom(src)
+}
+
+// getBuildUrl returns the link to the pipeline execution
+func getBuildUrl() string {
+	if buildLink := os.Getenv(buildLinkEnv); buildLink != "" {
+		return buildLink
+	}
+	return fmt.Sprintf("%s/ng/account/%s/ci/orgs/%s/projects/%s/pipelines/%s/executions/%s/pipeline", harnessUrl,
+		os.Getenv(accountIdEnv), os.Getenv(orgIdEnv), os.Getenv(projectIdEnv), os.Getenv(pipelineIdEnv), os.Getenv(executionIdEnv))
+}
+
+func (e *Environment) GetStepLink() string {
+	return getBuildUrl()
+}
+
+func (e *Environment) GetBuildLink() string {
+	return getBuildUrl()
+}
+
+func (e *Environment) GetFileLink(filename string, branch string, commit string) string {
+	return ""
+}
+
+func (e *Environment) GetFileLineLink(filename string, branch string, commit string, startLine int, endLine int) string {
+	return ""
+}
+
+func (e *Environment) IsCurrentEnvironment() bool {
+	_, isExist := os.LookupEnv(harnessBuildIdEnv)
+	return isExist
+}
+
+// DetectionVariables returns the variables used by IsCurrentEnvironment
+func (e *Environment) DetectionVariables() []string {
+	return []string{harnessBuildIdEnv}
+}
+
+// ExpectedFields returns the configuration fields the environment is expected to fill and the variables they are filled from,
+// the repository is read from the git checkout in the workspace
+func (e *Environment) ExpectedFields() []models.ExpectedField {
+	return []models.ExpectedField{
+		{Field: "repository.url", SourceEnv: workspaceEnv, Severity: models.SeverityCritical},
+		{Field: "repository.cloneUrl", SourceEnv: workspaceEnv, Severity: models.SeverityCritical},
+		{Field: "commitSha", SourceEnv: commitShaEnv, Severity: models.SeverityCritical},
+		{Field: "branch", SourceEnv: commitBranchEnv, Severity: models.SeverityWarning},
+		{Field: "organization.id", SourceEnv: orgIdEnv, Severity: models.SeverityWarning},
+		{Field: "pipeline.id", SourceEnv: pipelineIdEnv, Severity: models.SeverityWarning},
+		{Field: "job.id", SourceEnv: stageIdEnv, Severity: models.SeverityWarning},
+		{Field: "run.buildId", SourceEnv: executionIdEnv, Severity: models.SeverityWarning},
+		{Field: "run.buildNumber", SourceEnv: harnessBuildIdEnv, Severity: models.SeverityWarning},
+	}
+}
+
+func (e *Environment) Name() string {
+	return "harness"
+}
+
+// getPipelinePaths returns the pipeline files that are stored in the .harness directory
+func getPipelinePaths(src envsource.EnvSource, rootDir string) ([]string, []models.Warning) {
+	paths := make([]string, 0)
+	var warnings []models.Warning
+
+	dir := filepath.Join(rootDir, harnessDir)
+	if _, err := envsource.Stat(src, dir); err != nil {
+		return paths, warnings
+	}
+	err := envsource.Walk(src, dir, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			warnings = append(warnings, models.NewWarning(models.PipelinePathsWarning, err, "failed to search %s for pipeline files", path))
+			return nil
+		}
+		if info.IsDir() {
+			if path != dir {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) == ".yml" || filepath.Ext(path) == ".yaml" {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		warnings = append(warnings, models.NewWarning(models.PipelinePathsWarning, err, "failed to search %s for pipeline files", dir))
+	}
+
+	return paths, warnings
+}
@@ -0,0 +1,110 @@
+package harness
+
+import (
+	"fmt"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/models"
+)
+
+var (
+	MockOrgName     = "test-org"
+	MockRepoName    = "test-repo"
+	MockAccountId   = "px7xd_BFRCi-pfWPYXVjvw"
+	MockHarnessOrg  = "default"
+	MockProjectId   = "test_project"
+	MockPipelineId  = "build"
+	MockExecutionId = "Zp1dJ2rfQh6WPzgJ9y5Lqg"
+)
+
+var mockConfiguration *models.Configuration
+
+type EnvironmentMock struct{}
+
+func (em *EnvironmentMock) GetConfiguration() (*models.Configuration, error) {
+	if mockConfiguration == nil {
+		if err := loadMockConfiguration(); err != nil {
+			return nil, err
+		}
+	}
+	return mockConfiguration, nil
+}
+
+func (em *EnvironmentMock) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return em.GetConfiguration()
+}
+
+func (em *EnvironmentMock) Refresh() (*models.Configuration, error) {
+	em.Reset()
+	return em.GetConfiguration()
+}
+
+func (em *EnvironmentMock) Reset() {
+	mockConfiguration = nil
+}
+
+func loadMockConfiguration() error {
+	mockConfiguration = &models.Configuration{
+		Url:       harnessUrl,
+		SCMApiUrl: "https://api.github.com",
+		LocalPath: "/harness",
+		CommitSha: "2c6e3880fd94ddb7ef72d34e683cdc0c47bec6e6",
+		Branch:    "main",
+		Run: models.BuildRun{
+			BuildId:     MockExecutionId,
+			BuildNumber: "42",
+		},
+		Job: models.Entity{
+			Id:   "build",
+			Name: "Build",
+		},
+		Pipeline: models.Pipeline{
+			Entity: models.Entity{
+				Id:   MockPipelineId,
+				Name: MockPipelineId,
+			},
+		},
+		Repository: models.Repository{
+			Name:     MockRepoName,
+			FullName: fmt.Sprintf("%s/%s", MockOrgName, MockRepoName),
+			Url:      fmt.Sprintf("https://github.com/%s/%s", MockOrgName, MockRepoName),
+			CloneUrl: fmt.Sprintf("https://github.com/%s/%s.git", MockOrgName, MockRepoName),
+			Source:   enums.Github,
+		},
+		Builder: "Harness",
+		Organization: models.Entity{
+			Id:   MockHarnessOrg,
+			Name: MockOrgName,
+		},
+		PipelinePaths: []string{"/harness/.harness/build.yaml"},
+		Environment:   enums.Harness,
+	}
+
+	return nil
+}
+
+func (em *EnvironmentMock) GetBuildLink() string {
+	return fmt.Sprintf("%s/ng/account/%s/ci/orgs/%s/projects/%s/pipelines/%s/executions/%s/pipeline", harnessUrl,
+		MockAccountId, MockHarnessOrg, MockProjectId, MockPipelineId, MockExecutionId)
+}
+
+func (em *EnvironmentMock) GetStepLink() string {
+	return em.GetBuildLink()
+}
+
+func (em *EnvironmentMock) GetFileLink(filename string, branch string, commit string) string {
+	return ""
+}
+
+func (em *EnvironmentMock) GetFileLineLink(filename string, branch string, commit string, startLine int, endLine int) string {
+	return ""
+}
+
+func (em *EnvironmentMock) IsCurrentEnvironment() bool {
+	return true
+}
+
+func (em *EnvironmentMock) Name() string {
+	return "harness"
+}
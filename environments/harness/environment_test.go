@@ -0,0 +1,217 @@
+package harness
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/testutils"
+	"github.com/argonsecurity/go-environments/models"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	harnessMainEnvsFilePath = "testdata/harness-github-main-env.json"
+	harnessPrEnvsFilePath   = "testdata/harness-github-pr-env.json"
+	testRepoPath            = "/tmp/harness/workspace"
+	testRepoUrl             = "https://github.com/test-org/test-repo"
+	testRepoCloneUrl        = "https://github.com/test-org/test-repo.git"
+	testdataPath            = "../harness/testdata/repo"
+)
+
+func Test_environment_GetConfiguration(t *testing.T) {
+	tests := []struct {
+		name         string
+		envsFilePath string
+		want         *models.Configuration
+		wantErr      bool
+	}{
+		{
+			name:         "Harness GitHub main configuration",
+			envsFilePath: harnessMainEnvsFilePath,
+			want: &models.Configuration{
+				Url:       "https://app.harness.io",
+				SCMApiUrl: "https://api.github.com",
+				LocalPath: testRepoPath,
+				CommitSha: "2c6e3880fd94ddb7ef72d34e683cdc0c47bec6e6",
+				Branch:    "main",
+				Repository: models.Repository{
+					Name:     "test-repo",
+					FullName: "test-org/test-repo",
+					Url:      testRepoUrl,
+					CloneUrl: testRepoCloneUrl,
+					Source:   enums.Github,
+				},
+				Organization: models.Entity{
+					Id:   "default",
+					Name: "test-org",
+				},
+				Pipeline: models.Pipeline{
+					Entity: models.Entity{
+						Id:   "build",
+						Name: "build",
+					},
+				},
+				Job: models.Entity{
+					Id:   "build",
+					Name: "Build",
+				},
+				Run: models.BuildRun{
+					BuildId:     "Zp1dJ2rfQh6WPzgJ9y5Lqg",
+					BuildNumber: "42",
+				},
+				Runner: models.Runner{
+					OS:           runtime.GOOS,
+					Architecture: runtime.GOARCH,
+				},
+				Pusher: models.Pusher{
+					Username: "test-user",
+				},
+				Builder:       "Harness",
+				PipelinePaths: []string{"/tmp/harness/workspace/.harness/build.yaml"},
+				Environment:   enums.Harness,
+				ScmId:         "b30f418cdcc9970849d3d031de5df54f",
+			},
+		},
+		{
+			name:         "Harness GitHub pull request configuration",
+			envsFilePath: harnessPrEnvsFilePath,
+			want: &models.Configuration{
+				Url:       "https://app.harness.io",
+				SCMApiUrl: "https://api.github.com",
+				LocalPath: testRepoPath,
+				CommitSha: "fe8c38a965d13d9794eb36918cb24cebe49a45c2",
+				Branch:    "feature/test",
+				Repository: models.Repository{
+					Name:     "test-repo",
+					FullName: "test-org/test-repo",
+					Url:      testRepoUrl,
+					CloneUrl: testRepoCloneUrl,
+					Source:   enums.Github,
+				},
+				Organization: models.Entity{
+					Id:   "default",
+					Name: "test-org",
+				},
+				Pipeline: models.Pipeline{
+					Entity: models.Entity{
+						Id:   "build",
+						Name: "build",
+					},
+				},
+				Job: models.Entity{
+					Id:   "build",
+					Name: "Build",
+				},
+				Run: models.BuildRun{
+					BuildId:     "kR3fT0bWSmC1aQ8vN2xYzw",
+					BuildNumber: "43",
+				},
+				Runner: models.Runner{
+					OS:           runtime.GOOS,
+					Architecture: runtime.GOARCH,
+				},
+				PullRequest: models.PullRequest{
+					Id: "7",
+					SourceRef: models.Ref{
+						Branch: "feature/test",
+						Sha:    "fe8c38a965d13d9794eb36918cb24cebe49a45c2",
+					},
+					TargetRef: models.Ref{
+						Branch: "main",
+					},
+				},
+				Pusher: models.Pusher{
+					Username: "test-user",
+				},
+				Builder:       "Harness",
+				PipelinePaths: []string{"/tmp/harness/workspace/.harness/build.yaml"},
+				Environment:   enums.Harness,
+				ScmId:         "b30f418cdcc9970849d3d031de5df54f",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := prepareTest(t, tt.envsFilePath)
+			got, err := e.GetConfiguration()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("environment.GetConfiguration() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_environment_GetBuildLink(t *testing.T) {
+	tests := []struct {
+		name         string
+		envsFilePath string
+		want         string
+	}{
+		{
+			name:         "Execution link from the account, org, project and pipeline",
+			envsFilePath: harnessMainEnvsFilePath,
+			want:         "https://app.harness.io/ng/account/px7xd_BFRCi-pfWPYXVjvw/ci/orgs/default/projects/test_project/pipelines/build/executions/Zp1dJ2rfQh6WPzgJ9y5Lqg/pipeline",
+		},
+		{
+			name:         "Execution link from DRONE_BUILD_LINK",
+			envsFilePath: harnessPrEnvsFilePath,
+			want:         "https://app.harness.io/ng/account/px7xd_BFRCi-pfWPYXVjvw/ci/orgs/default/projects/test_project/pipelines/build/executions/kR3fT0bWSmC1aQ8vN2xYzw/pipeline",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := prepareTest(t, tt.envsFilePath)
+			assert.Equal(t, tt.want, e.GetBuildLink())
+			assert.Equal(t, tt.want, e.GetStepLink())
+		})
+	}
+}
+
+func Test_environment_IsCurrentEnvironment(t *testing.T) {
+	tests := []struct {
+		name         string
+		envsFilePath string
+		want         bool
+	}{
+		{
+			name:         "Harness environment",
+			envsFilePath: harnessMainEnvsFilePath,
+			want:         true,
+		},
+		{
+			name:         "Drone environment",
+			envsFilePath: "../drone/testdata/drone-github-push-env.json",
+			want:         false,
+		},
+		{
+			name:         "Jenkins environment",
+			envsFilePath: "../jenkins/testdata/jenkins-github-main-full-env.json",
+			want:         false,
+		},
+		{
+			name:         "Not Harness environment",
+			envsFilePath: "",
+			want:         false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := prepareTest(t, tt.envsFilePath)
+			if got := e.IsCurrentEnvironment(); got != tt.want {
+				t.Errorf("environment.IsCurrentEnvironment() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func prepareTest(t *testing.T, envsFilePath string) *Environment {
+	e := New()
+	testRepoCleanup := testutils.PrepareTestGitRepository(testRepoPath, testRepoCloneUrl, testdataPath)
+	t.Cleanup(testRepoCleanup)
+	envCleanup := testutils.SetEnvsFromFile(envsFilePath)
+	t.Cleanup(envCleanup)
+	return e
+}
@@ -10,12 +10,14 @@ import (
 	githubserver "github.com/argonsecurity/go-environments/environments/jenkins/environments/github_server"
 
 	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/gitea"
 	"github.com/argonsecurity/go-environments/environments/github"
 	"github.com/argonsecurity/go-environments/environments/jenkins/environments"
 	bitbucketserver "github.com/argonsecurity/go-environments/environments/jenkins/environments/bitbucket_server"
+	"github.com/argonsecurity/go-environments/environments/jenkins/environments/gerrit"
 	"github.com/argonsecurity/go-environments/environments/jenkins/environments/gitlab"
 	"github.com/argonsecurity/go-environments/environments/utils"
-	"github.com/argonsecurity/go-environments/environments/utils/git"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
 	"github.com/argonsecurity/go-environments/http"
 	"github.com/argonsecurity/go-environments/models"
 )
@@ -25,6 +27,7 @@ const (
 	repositoryPathEnv = "WORKSPACE"
 
 	jenkinsURLEnv         = "JENKINS_URL"
+	jenkinsHomeEnv        = "JENKINS_HOME"
 	buildURLEnv           = "BUILD_URL"
 	runURLEnv             = "RUN_DISPLAY_URL"
 	repositoryCloneURLEnv = "GIT_URL"
@@ -42,54 +45,67 @@ const (
 	branchEnv        = "BRANCH_NAME"
 	targetBranchName = "CHANGE_TARGET"
 
-	githubHostname    = "github.com"
-	gitlabHostname    = "gitlab.com"
-	azureHostname     = "dev.azure.com"
-	bitbucketHostname = "bitbucket.org"
-
-	githubApiUrl    = "https://api.github.com"
-	gitlabApiUrl    = "https://gitlab.com/api/v4"
-	azureApiUrl     = ""
-	bitbucketApiUrl = "https://api.bitbucket.org/2.0"
+	githubApiUrl    = utils.GithubApiUrl
+	bitbucketApiUrl = utils.BitbucketApiUrl
 )
 
 var (
-	Jenkins       = environment{}
-	configuration *models.Configuration
+	Jenkins = New()
 )
 
-type environment struct{}
+// Environment is the Jenkins environment
+type Environment struct {
+	cache utils.ConfigurationCache
+}
 
-func (e environment) GetConfiguration() (*models.Configuration, error) {
-	if configuration == nil {
-		loadedConfiguration, err := loadConfiguration()
-		configuration = loadedConfiguration
-		return configuration, err
-	}
-	return configuration, nil
+// New creates a Jenkins environment with its own configuration cache
+func New() *Environment {
+	return &Environment{}
+}
+
+func (e *Environment) GetConfiguration() (*models.Configuration, error) {
+	return e.cache.Get(e.load)
+}
+
+// Refresh loads the configuration again and replaces the cached configuration
+func (e *Environment) Refresh() (*models.Configuration, error) {
+	return e.cache.Refresh(e.load)
+}
+
+// Reset clears the cached configuration, it is loaded again on the next GetConfiguration
+func (e *Environment) Reset() {
+	e.cache.Reset()
+}
+
+func (e *Environment) load() (*models.Configuration, error) {
+	return loadConfiguration(envsource.OS)
+}
+
+func (e *Environment) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return loadConfiguration(src)
 }
 
-func loadConfiguration() (*models.Configuration, error) {
-	repositoryPath := os.Getenv(repositoryPathEnv)
-	if !git.IsPathContainsRepository(repositoryPath) {
+func loadConfiguration(src envsource.EnvSource) (*models.Configuration, error) {
+	repositoryPath := src.Getenv(repositoryPathEnv)
+	if !envsource.IsPathContainsRepository(src, repositoryPath) {
 		return nil, fmt.Errorf("%s is not a repository path", repositoryPath)
 	}
 
-	cloneUrl, err := getRepositoryCloneURL(repositoryPath)
+	cloneUrl, err := getRepositoryCloneURL(src, repositoryPath)
 	cloneUrl = utils.StripCredentialsFromUrl(cloneUrl)
 	if err != nil {
 		return nil, err
 	}
 
-	repoSource, apiUrl := GetRepositorySource(cloneUrl)
+	repoSource, apiUrl := GetRepositorySourceFrom(src, cloneUrl)
 	repositoryURL, org, repositoryName, repositoryFullName, err := utils.ParseDataFromCloneUrl(cloneUrl, apiUrl, repoSource)
 	if err != nil {
 		return nil, err
 	}
 
-	commit := os.Getenv(commitShaEnv)
+	commit := src.Getenv(commitShaEnv)
 	if commit == "" {
-		commit, err = git.GetGitCommit(repositoryPath)
+		commit, err = envsource.GitClient(src).GetGitCommit(envsource.Path(src, repositoryPath))
 		if err != nil {
 			return nil, err
 		}
@@ -97,9 +113,11 @@ func loadConfiguration() (*models.Configuration, error) {
 
 	scmId := utils.GenerateScmId(cloneUrl)
 
-	branch := getBranchName(repositoryPath, commit)
+	branch, warnings := getBranchName(src, repositoryPath, commit)
+	pipelinePaths, pipelinesWarnings := getAllPipelinePaths(src, repositoryPath)
+	warnings = append(warnings, pipelinesWarnings...)
 	configuration := &models.Configuration{
-		Url:       os.Getenv(jenkinsURLEnv),
+		Url:       src.Getenv(jenkinsURLEnv),
 		SCMApiUrl: apiUrl,
 		LocalPath: repositoryPath,
 		Branch:    branch,
@@ -113,21 +131,21 @@ func loadConfiguration() (*models.Configuration, error) {
 		},
 		Pipeline: models.Pipeline{
 			Entity: models.Entity{
-				Id:   os.Getenv(jobNameEnv),
-				Name: os.Getenv(jobNameEnv),
+				Id:   src.Getenv(jobNameEnv),
+				Name: src.Getenv(jobNameEnv),
 			},
 		},
 		Job: models.Entity{
-			Id:   os.Getenv(stageNameEnv),
-			Name: os.Getenv(stageNameEnv),
+			Id:   src.Getenv(stageNameEnv),
+			Name: src.Getenv(stageNameEnv),
 		},
 		Run: models.BuildRun{
-			BuildId:     os.Getenv(buildIDEnv),
-			BuildNumber: os.Getenv(buildNumberEnv),
+			BuildId:     src.Getenv(buildIDEnv),
+			BuildNumber: src.Getenv(buildNumberEnv),
 		},
 		Runner: models.Runner{
-			Id:           os.Getenv(nodeIDEnv),
-			Name:         os.Getenv(nodeNameEnv),
+			Id:           src.Getenv(nodeIDEnv),
+			Name:         src.Getenv(nodeNameEnv),
 			OS:           runtime.GOOS,
 			Architecture: runtime.GOARCH,
 		},
@@ -136,39 +154,42 @@ func loadConfiguration() (*models.Configuration, error) {
 				Branch: branch,
 			},
 			TargetRef: models.Ref{
-				Branch: os.Getenv(targetBranchName),
+				Branch: src.Getenv(targetBranchName),
 			},
 		},
 		Builder: builder,
 		Organization: models.Entity{
 			Name: org,
 		},
-		PipelinePaths: getAllPipelinePaths(repositoryPath),
+		PipelinePaths: pipelinePaths,
 		Environment:   enums.Jenkins,
 		ScmId:         scmId,
+		Warnings:      warnings,
 	}
 
-	configuration = environments.EnhanceConfiguration(configuration)
+	configuration = environments.EnhanceConfigurationFrom(src, configuration)
 	if configuration.Pusher.Username == "" {
-		configuration.Pusher.Username = utils.DetectPusher()
+		configuration.Pusher.Username = utils.DetectPusherFrom(src)
 	}
 	configuration.Repository.CloneUrl = utils.StripCredentialsFromUrl(configuration.Repository.CloneUrl)
 	return configuration, nil
 }
 
-func getBranchName(repositoryPath string, commit string) string {
-	branchName := os.Getenv(branchEnv)
-	if branchName == "" {
-		branchName, _ = git.GetGitBranch(repositoryPath, commit)
+func getBranchName(src envsource.EnvSource, repositoryPath string, commit string) (string, []models.Warning) {
+	branchName := src.Getenv(branchEnv)
+	if branchName != "" {
+		return branchName, nil
 	}
-	return branchName
+
+	branchName, warnings, _ := envsource.GetGitBranch(src, repositoryPath, commit)
+	return branchName, warnings
 }
 
-func (env environment) GetStepLink() string {
+func (env *Environment) GetStepLink() string {
 	return os.Getenv(runURLEnv)
 }
 
-func (env environment) GetBuildLink() string {
+func (env *Environment) GetBuildLink() string {
 	url := os.Getenv(buildURLEnv)
 	if url != "" {
 		return url
@@ -176,31 +197,51 @@ func (env environment) GetBuildLink() string {
 	return os.Getenv(runURLEnv)
 }
 
-func (e environment) GetFileLink(filename string, branch string, commit string) string {
+func (e *Environment) GetFileLink(filename string, branch string, commit string) string {
 	return ""
 }
 
-func (e environment) GetFileLineLink(filename string, branch string, commit string, startLine int, endLine int) string {
+func (e *Environment) GetFileLineLink(filename string, branch string, commit string, startLine int, endLine int) string {
 	return ""
 }
 
-func (e environment) Name() string {
+func (e *Environment) Name() string {
 	return "jenkins"
 }
 
-func (e environment) IsCurrentEnvironment() bool {
+func (e *Environment) IsCurrentEnvironment() bool {
 	var isExist bool
-	if _, isExist = os.LookupEnv("JENKINS_HOME"); !isExist {
-		_, isExist = os.LookupEnv("JENKINS_URL")
+	if _, isExist = os.LookupEnv(jenkinsHomeEnv); !isExist {
+		_, isExist = os.LookupEnv(jenkinsURLEnv)
 	}
 	return isExist
 }
 
-func getRepositoryCloneURL(repositoryPath string) (string, error) {
+// DetectionVariables returns the variables used by IsCurrentEnvironment
+func (e *Environment) DetectionVariables() []string {
+	return []string{jenkinsHomeEnv, jenkinsURLEnv}
+}
+
+// ExpectedFields returns the configuration fields the environment is expected to fill and the variables they are filled from
+func (e *Environment) ExpectedFields() []models.ExpectedField {
+	return []models.ExpectedField{
+		{Field: "repository.url", SourceEnv: repositoryCloneURLEnv, Severity: models.SeverityCritical},
+		{Field: "repository.cloneUrl", SourceEnv: repositoryCloneURLEnv, Severity: models.SeverityCritical},
+		{Field: "commitSha", SourceEnv: commitShaEnv, Severity: models.SeverityCritical},
+		{Field: "branch", SourceEnv: branchEnv, Severity: models.SeverityWarning},
+		{Field: "url", SourceEnv: jenkinsURLEnv, Severity: models.SeverityWarning},
+		{Field: "localPath", SourceEnv: repositoryPathEnv, Severity: models.SeverityWarning},
+		{Field: "pipeline.name", SourceEnv: jobNameEnv, Severity: models.SeverityWarning},
+		{Field: "run.buildId", SourceEnv: buildIDEnv, Severity: models.SeverityWarning},
+		{Field: "runner.name", SourceEnv: nodeNameEnv, Severity: models.SeverityWarning},
+	}
+}
+
+func getRepositoryCloneURL(src envsource.EnvSource, repositoryPath string) (string, error) {
 	var err error
-	cloneUrl, isExist := os.LookupEnv(repositoryCloneURLEnv)
+	cloneUrl, isExist := src.LookupEnv(repositoryCloneURLEnv)
 	if !isExist {
-		cloneUrl, err = git.GetGitRemoteURL(repositoryPath)
+		cloneUrl, err = envsource.GitClient(src).GetGitRemoteURL(envsource.Path(src, repositoryPath))
 		if err != nil {
 			return "", err
 		}
@@ -215,17 +256,21 @@ func getRepositoryCloneURL(repositoryPath string) (string, error) {
 	return cloneUrl, err
 }
 
+// GetRepositorySource detects the SCM of a clone url by its hostname or, for Gerrit, by the SSH port of Gerrit,
+// and discovers self-hosted servers with HTTP requests
 func GetRepositorySource(cloneUrl string) (enums.Source, string) {
-	switch {
-	case strings.Contains(cloneUrl, bitbucketHostname):
-		return enums.Bitbucket, bitbucketApiUrl
-	case strings.Contains(cloneUrl, githubHostname):
-		return enums.Github, githubApiUrl
-	case strings.Contains(cloneUrl, azureHostname):
-		return enums.Azure, azureApiUrl
-	case strings.Contains(cloneUrl, gitlabHostname):
-		return enums.Gitlab, gitlabApiUrl
+	return GetRepositorySourceFrom(envsource.OS, cloneUrl)
+}
 
+// GetRepositorySourceFrom detects the SCM of a clone url like GetRepositorySource,
+// Gerrit HTTP clone urls are detected when the Gerrit Trigger variables of the source point to their server
+func GetRepositorySourceFrom(src envsource.EnvSource, cloneUrl string) (enums.Source, string) {
+	if source, apiUrl := utils.GetRepositorySource(cloneUrl); source != enums.Unknown {
+		return source, apiUrl
+	}
+
+	if gerrit.IsGerritCloneUrl(cloneUrl) || gerrit.IsGerritTriggerCloneUrl(src, cloneUrl) {
+		return enums.Gerrit, gerrit.GetGerritApiUrl(cloneUrl)
 	}
 
 	return discoverSCMSource(cloneUrl)
@@ -239,6 +284,10 @@ func discoverSCMSource(gitUrl string) (enums.Source, string) {
 			return enums.GitlabServer, gitlab.GetGitlabApiUrl(url)
 		}
 
+		if gitea.CheckGiteaByHTTPRequest(url, httpClient) {
+			return enums.Gitea, utils.GetGiteaApiUrl(url)
+		}
+
 		// Checking github_token, after we checked for github saas already
 		if githubserver.CheckGithubServerByHTTPRequest(url, httpClient) {
 			return enums.GithubServer, githubserver.GetGithubServerApiUrl(url)
@@ -249,23 +298,29 @@ func discoverSCMSource(gitUrl string) (enums.Source, string) {
 			return enums.BitbucketServer, url
 		}
 	}
+
+	// Gerrit is checked on the server of the clone url, which may be served under a context path
+	if gerrit.CheckGerritByHTTPRequest(gitUrl, httpClient) {
+		return enums.Gerrit, gerrit.GetGerritApiUrl(gitUrl)
+	}
 	return enums.Unknown, ""
 }
 
-func getJenkinsPipelinePaths(rootDir string) string {
+func getJenkinsPipelinePaths(src envsource.EnvSource, rootDir string) string {
 	jenkinsfilePath := filepath.Join(rootDir, "Jenkinsfile")
-	if _, err := os.Stat(jenkinsfilePath); !os.IsNotExist(err) {
+	if _, err := envsource.Stat(src, jenkinsfilePath); !os.IsNotExist(err) {
 		return jenkinsfilePath
 	}
 	return ""
 }
 
-func getAllPipelinePaths(rootDir string) []string {
+func getAllPipelinePaths(src envsource.EnvSource, rootDir string) ([]string, []models.Warning) {
 	paths := make([]string, 0)
-	if jenkinsfilePath := getJenkinsPipelinePaths(rootDir); jenkinsfilePath != "" {
+	if jenkinsfilePath := getJenkinsPipelinePaths(src, rootDir); jenkinsfilePath != "" {
 		paths = append(paths, jenkinsfilePath)
 	}
-	paths = append(paths, github.GetPipelinePaths(rootDir)...)
+	githubPaths, warnings := github.GetPipelinePathsFrom(src, rootDir)
+	paths = append(paths, githubPaths...)
 
-	return paths
+	return paths, warnings
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
 	"github.com/argonsecurity/go-environments/models"
 )
 
@@ -25,6 +26,19 @@ func (em *EnvironmentBitbucketMock) GetConfiguration() (*models.Configuration, e
 	return mockBitbucketConfiguration, nil
 }
 
+func (em *EnvironmentBitbucketMock) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return em.GetConfiguration()
+}
+
+func (em *EnvironmentBitbucketMock) Refresh() (*models.Configuration, error) {
+	em.Reset()
+	return em.GetConfiguration()
+}
+
+func (em *EnvironmentBitbucketMock) Reset() {
+	mockBitbucketConfiguration = nil
+}
+
 func loadMockBitbucketConfiguration() error {
 	mockBitbucketConfiguration = &models.Configuration{
 		Url:             "https://bitbucket.org",
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
 	"github.com/argonsecurity/go-environments/models"
 )
 
@@ -25,6 +26,19 @@ func (em *EnvironmentBitbucketServerMock) GetConfiguration() (*models.Configurat
 	return mockBitbucketServerConfiguration, nil
 }
 
+func (em *EnvironmentBitbucketServerMock) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return em.GetConfiguration()
+}
+
+func (em *EnvironmentBitbucketServerMock) Refresh() (*models.Configuration, error) {
+	em.Reset()
+	return em.GetConfiguration()
+}
+
+func (em *EnvironmentBitbucketServerMock) Reset() {
+	mockBitbucketServerConfiguration = nil
+}
+
 func loadMockBitbucketServerConfiguration() error {
 	mockBitbucketServerConfiguration = &models.Configuration{
 		Url:             "https://staging-bitbucket.org",
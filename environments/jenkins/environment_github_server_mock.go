@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
 	"github.com/argonsecurity/go-environments/models"
 )
 
@@ -25,6 +26,19 @@ func (em *EnvironmentGithubServerMock) GetConfiguration() (*models.Configuration
 	return mockGithubServerConfiguration, nil
 }
 
+func (em *EnvironmentGithubServerMock) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return em.GetConfiguration()
+}
+
+func (em *EnvironmentGithubServerMock) Refresh() (*models.Configuration, error) {
+	em.Reset()
+	return em.GetConfiguration()
+}
+
+func (em *EnvironmentGithubServerMock) Reset() {
+	mockGithubServerConfiguration = nil
+}
+
 func loadMockGithubServerConfiguration() error {
 	mockGithubServerConfiguration = &models.Configuration{
 		Url:       "https://github.server.com",
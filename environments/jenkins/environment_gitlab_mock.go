@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
 	"github.com/argonsecurity/go-environments/models"
 )
 
@@ -26,6 +27,19 @@ func (em *EnvironmentGitlabMock) GetConfiguration() (*models.Configuration, erro
 	return mockGitlabConfiguration, nil
 }
 
+func (em *EnvironmentGitlabMock) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return em.GetConfiguration()
+}
+
+func (em *EnvironmentGitlabMock) Refresh() (*models.Configuration, error) {
+	em.Reset()
+	return em.GetConfiguration()
+}
+
+func (em *EnvironmentGitlabMock) Reset() {
+	mockGitlabConfiguration = nil
+}
+
 func loadMockGitlabConfiguration() error {
 	mockGitlabConfiguration = &models.Configuration{
 		Url:             "https://server-gitlab.company.com",
@@ -8,6 +8,7 @@ import (
 	"github.com/argonsecurity/go-environments/enums"
 	"github.com/argonsecurity/go-environments/environments/testutils"
 	"github.com/argonsecurity/go-environments/environments/testutils/mocks"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
 	"github.com/argonsecurity/go-environments/environments/utils/git"
 	"github.com/argonsecurity/go-environments/models"
 	"github.com/stretchr/testify/assert"
@@ -17,6 +18,7 @@ var (
 	jenkinsGithubMainFullEnvsFilePath    = "testdata/jenkins-github-main-full-env.json"
 	jenkinsGithubMainNoGitEnvsFilePath   = "testdata/jenkins-github-main-no-.git-env.json"
 	jenkinsGithubMainMinimalEnvsFilePath = "testdata/jenkins-github-main-minimal-env.json"
+	jenkinsGerritChangeEnvsFilePath      = "testdata/jenkins-gerrit-change-env.json"
 	testRepoPath                         = "/tmp/jenkins/repo"
 	testRepoUrl                          = "https://github.com/test-organization/test-repo"
 	testRepoCloneUrl                     = fmt.Sprintf("%s%s", testRepoUrl, ".git")
@@ -143,6 +145,68 @@ func Test_environment_GetConfiguration(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:         "Jenkins Gerrit Trigger change environment",
+			envsFilePath: jenkinsGerritChangeEnvsFilePath,
+			gitClient:    (&mocks.MockGitClient{}).SetBranch("main"),
+			want: &models.Configuration{
+				Url:       "https://test-jenkins.com:8080/",
+				SCMApiUrl: "https://gerrit.company.com",
+				Builder:   "Jenkins",
+				LocalPath: testRepoPath,
+				CommitSha: "5a1c2f0d3b9e8a7c6d5e4f3a2b1c0d9e8f7a6b5c",
+				Branch:    "main",
+				Repository: models.Repository{
+					Name:     "build",
+					FullName: "platform/build",
+					Url:      "https://gerrit.company.com/plugins/gitiles/platform/build",
+					CloneUrl: "ssh://gerrit.company.com:29418/platform/build.git",
+					Source:   enums.Gerrit,
+				},
+				Organization: models.Entity{
+					Name: "platform",
+				},
+				Pipeline: models.Pipeline{
+					Entity: models.Entity{
+						Id:   "gerrit-verify",
+						Name: "gerrit-verify",
+					},
+				},
+				Job: models.Entity{
+					Id:   "Verify",
+					Name: "Verify",
+				},
+				Run: models.BuildRun{
+					BuildId:     "12",
+					BuildNumber: "12",
+				},
+				Runner: models.Runner{
+					Id:           "master",
+					Name:         "master",
+					OS:           runtime.GOOS,
+					Architecture: runtime.GOARCH,
+				},
+				PullRequest: models.PullRequest{
+					Id:  "1234",
+					Url: "https://gerrit.company.com/c/platform/build/+/1234",
+					SourceRef: models.Ref{
+						Sha:    "5a1c2f0d3b9e8a7c6d5e4f3a2b1c0d9e8f7a6b5c",
+						Branch: "refs/changes/34/1234/2",
+					},
+					TargetRef: models.Ref{
+						Branch: "main",
+					},
+				},
+				Pusher: models.Pusher{
+					Username: "User Name",
+					Email:    "user@company.com",
+				},
+				PipelinePaths: []string{"/tmp/jenkins/repo/Jenkinsfile"},
+				Environment:   enums.Jenkins,
+				ScmId:         "b7cad91cbad5edbc8024aec215d24872",
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -330,22 +394,36 @@ func Test_getRepositoryCloneURL(t *testing.T) {
 			t.Run(tt.name, func(t *testing.T) {
 				prepareTest(t, tt.envsFilePath)
 				setMockGitClient(t, tt.gitClient)
-				got, err := getRepositoryCloneURL(tt.args.repositoryPath)
+				got, err := getRepositoryCloneURL(envsource.OS, tt.args.repositoryPath)
 				if (err != nil) != tt.wantErr {
-					t.Errorf("getRepositoryCloneURL() error = %v, wantErr %v", err, tt.wantErr)
+					t.Errorf("getRepositoryCloneURL(envsource.OS, ) error = %v, wantErr %v", err, tt.wantErr)
 					return
 				}
 				if got != tt.want {
-					t.Errorf("getRepositoryCloneURL() = %v, want %v", got, tt.want)
+					t.Errorf("getRepositoryCloneURL(envsource.OS, ) = %v, want %v", got, tt.want)
 				}
 			})
 		})
 	}
 }
 
-func prepareTest(t *testing.T, envsFilePath string) environment {
-	e := environment{}
-	configuration = nil
+func TestGetRepositorySourceFrom(t *testing.T) {
+	src := envsource.New(testutils.LoadEnvsFromFile(jenkinsGerritChangeEnvsFilePath))
+
+	source, apiUrl := GetRepositorySourceFrom(src, "https://gerrit.company.com/a/platform/build.git")
+	assert.Equal(t, enums.Gerrit, source)
+	assert.Equal(t, "https://gerrit.company.com", apiUrl)
+
+	source, apiUrl = GetRepositorySourceFrom(src, "ssh://gerrit.company.com:29418/platform/build.git")
+	assert.Equal(t, enums.Gerrit, source)
+	assert.Equal(t, "https://gerrit.company.com", apiUrl)
+
+	source, _ = GetRepositorySourceFrom(src, "https://github.com/test-organization/test-repo.git")
+	assert.Equal(t, enums.Github, source)
+}
+
+func prepareTest(t *testing.T, envsFilePath string) *Environment {
+	e := New()
 	testRepoCleanup := testutils.PrepareTestGitRepository(testRepoPath, testRepoCloneUrl, testdataPath)
 	t.Cleanup(testRepoCleanup)
 	envCleanup := testutils.SetEnvsFromFile(envsFilePath)
@@ -2,9 +2,9 @@ package bitbucket
 
 import (
 	"encoding/json"
-	"os"
 
 	"github.com/argonsecurity/go-environments/environments/utils"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
 	"github.com/argonsecurity/go-environments/models"
 )
 
@@ -17,15 +17,23 @@ const (
 )
 
 func EnhanceConfiguration(configuration *models.Configuration) *models.Configuration {
-	if _, isExists := os.LookupEnv(bitbucketPayloadEnv); isExists {
-		configuration = EnhanceConfigurationWithPayload(configuration)
+	return EnhanceConfigurationFrom(envsource.OS, configuration)
+}
+
+func EnhanceConfigurationFrom(src envsource.EnvSource, configuration *models.Configuration) *models.Configuration {
+	if _, isExists := src.LookupEnv(bitbucketPayloadEnv); isExists {
+		configuration = EnhanceConfigurationWithPayloadFrom(src, configuration)
 	}
 
-	return EnhanceConfigurationWithEnvs(configuration)
+	return EnhanceConfigurationWithEnvsFrom(src, configuration)
 }
 
 func EnhanceConfigurationWithPayload(configuration *models.Configuration) *models.Configuration {
-	payload, err := initPayload()
+	return EnhanceConfigurationWithPayloadFrom(envsource.OS, configuration)
+}
+
+func EnhanceConfigurationWithPayloadFrom(src envsource.EnvSource, configuration *models.Configuration) *models.Configuration {
+	payload, err := initPayload(src)
 
 	if err != nil {
 		return configuration
@@ -45,19 +53,23 @@ func EnhanceConfigurationWithPayload(configuration *models.Configuration) *model
 }
 
 func EnhanceConfigurationWithEnvs(configuration *models.Configuration) *models.Configuration {
-	utils.SetValueIfEnvExist(configuration, bitbucketSourceBranchEnv, "Branch")
-	utils.SetValueIfEnvExist(configuration, bitbucketSourceBranchEnv, "PullRequest.SourceRef.Branch")
-	utils.SetValueIfEnvExist(configuration, bitbucketTargetBranchEnv, "PullRequest.TargetRef.Branch")
-	utils.SetValueIfEnvExist(configuration, bitbucketPullRequestLinkEnv, "PullRequest.Url")
-	utils.SetValueIfEnvExist(configuration, bitbucketPullRequestIdEnv, "PullRequest.Id")
+	return EnhanceConfigurationWithEnvsFrom(envsource.OS, configuration)
+}
+
+func EnhanceConfigurationWithEnvsFrom(src envsource.EnvSource, configuration *models.Configuration) *models.Configuration {
+	utils.SetValueIfEnvExistFrom(src, configuration, bitbucketSourceBranchEnv, "Branch")
+	utils.SetValueIfEnvExistFrom(src, configuration, bitbucketSourceBranchEnv, "PullRequest.SourceRef.Branch")
+	utils.SetValueIfEnvExistFrom(src, configuration, bitbucketTargetBranchEnv, "PullRequest.TargetRef.Branch")
+	utils.SetValueIfEnvExistFrom(src, configuration, bitbucketPullRequestLinkEnv, "PullRequest.Url")
+	utils.SetValueIfEnvExistFrom(src, configuration, bitbucketPullRequestIdEnv, "PullRequest.Id")
 
 	return configuration
 }
 
-func initPayload() (*BitbucketPayload, error) {
+func initPayload(src envsource.EnvSource) (*BitbucketPayload, error) {
 	var payload *BitbucketPayload
 
-	data, exists := os.LookupEnv(bitbucketPayloadEnv)
+	data, exists := src.LookupEnv(bitbucketPayloadEnv)
 
 	if !exists {
 		return nil, nil
@@ -71,6 +83,10 @@ func initPayload() (*BitbucketPayload, error) {
 }
 
 func IsCurrentEnvironment() bool {
-	_, isExist := os.LookupEnv(bitbucketPayloadEnv)
+	return IsCurrentEnvironmentFrom(envsource.OS)
+}
+
+func IsCurrentEnvironmentFrom(src envsource.EnvSource) bool {
+	_, isExist := src.LookupEnv(bitbucketPayloadEnv)
 	return isExist
 }
@@ -2,9 +2,9 @@ package bitbucketserver
 
 import (
 	"fmt"
-	"os"
 	"strings"
 
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
 	"github.com/argonsecurity/go-environments/http"
 	"github.com/argonsecurity/go-environments/models"
 )
@@ -18,16 +18,24 @@ const (
 )
 
 func EnhanceConfiguration(configuration *models.Configuration) *models.Configuration {
-	configuration.Pusher.Username = os.Getenv(pusherDisplayNameEnv)
-	configuration.Pusher.Email = os.Getenv(pusherEmailEnv)
-	configuration.Pusher.Entity.Name = os.Getenv(pusherNameEnv)
-	configuration.PullRequest.Id = os.Getenv(pullRequestIdEnv)
-	configuration.PullRequest.Url = os.Getenv(pullRequestUrlEnv)
+	return EnhanceConfigurationFrom(envsource.OS, configuration)
+}
+
+func EnhanceConfigurationFrom(src envsource.EnvSource, configuration *models.Configuration) *models.Configuration {
+	configuration.Pusher.Username = src.Getenv(pusherDisplayNameEnv)
+	configuration.Pusher.Email = src.Getenv(pusherEmailEnv)
+	configuration.Pusher.Entity.Name = src.Getenv(pusherNameEnv)
+	configuration.PullRequest.Id = src.Getenv(pullRequestIdEnv)
+	configuration.PullRequest.Url = src.Getenv(pullRequestUrlEnv)
 	return configuration
 }
 
 func IsCurrentEnvironment() bool {
-	_, isExist := os.LookupEnv("CHANGE_BRANCH")
+	return IsCurrentEnvironmentFrom(envsource.OS)
+}
+
+func IsCurrentEnvironmentFrom(src envsource.EnvSource) bool {
+	_, isExist := src.LookupEnv("CHANGE_BRANCH")
 	return isExist
 }
 
@@ -4,26 +4,36 @@ import (
 	"github.com/argonsecurity/go-environments/enums"
 	"github.com/argonsecurity/go-environments/environments/jenkins/environments/bitbucket"
 	bitbucketserver "github.com/argonsecurity/go-environments/environments/jenkins/environments/bitbucket_server"
+	"github.com/argonsecurity/go-environments/environments/jenkins/environments/gerrit"
 	"github.com/argonsecurity/go-environments/environments/jenkins/environments/github"
 	"github.com/argonsecurity/go-environments/environments/jenkins/environments/gitlab"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
 	"github.com/argonsecurity/go-environments/models"
 )
 
 func EnhanceConfiguration(configuration *models.Configuration) *models.Configuration {
-	if gitlab.IsCurrentEnvironment() || configuration.Repository.Source == enums.GitlabServer || configuration.Repository.Source == enums.Gitlab {
-		return gitlab.EnhanceConfiguration(configuration)
+	return EnhanceConfigurationFrom(envsource.OS, configuration)
+}
+
+func EnhanceConfigurationFrom(src envsource.EnvSource, configuration *models.Configuration) *models.Configuration {
+	if gerrit.IsCurrentEnvironment(src) || configuration.Repository.Source == enums.Gerrit {
+		return gerrit.EnhanceConfiguration(src, configuration)
+	}
+
+	if gitlab.IsCurrentEnvironmentFrom(src) || configuration.Repository.Source == enums.GitlabServer || configuration.Repository.Source == enums.Gitlab {
+		return gitlab.EnhanceConfigurationFrom(src, configuration)
 	}
 
-	if bitbucket.IsCurrentEnvironment() || configuration.Repository.Source == enums.Bitbucket {
-		return bitbucket.EnhanceConfiguration(configuration)
+	if bitbucket.IsCurrentEnvironmentFrom(src) || configuration.Repository.Source == enums.Bitbucket {
+		return bitbucket.EnhanceConfigurationFrom(src, configuration)
 	}
 
-	if bitbucketserver.IsCurrentEnvironment() || configuration.Repository.Source == enums.BitbucketServer {
-		return bitbucketserver.EnhanceConfiguration(configuration)
+	if bitbucketserver.IsCurrentEnvironmentFrom(src) || configuration.Repository.Source == enums.BitbucketServer {
+		return bitbucketserver.EnhanceConfigurationFrom(src, configuration)
 	}
 
-	if github.IsGitHubSingleJenkinsPipeline() {
-		return github.EnhanceConfigurationSinglePipeline(configuration)
+	if github.IsGitHubSingleJenkinsPipelineFrom(src) {
+		return github.EnhanceConfigurationSinglePipelineFrom(src, configuration)
 	}
 
 	return configuration
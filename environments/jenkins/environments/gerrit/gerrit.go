@@ -0,0 +1,130 @@
+package gerrit
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/http"
+	"github.com/argonsecurity/go-environments/models"
+)
+
+const (
+	changeNumberEnv     = "GERRIT_CHANGE_NUMBER"
+	changeUrlEnv        = "GERRIT_CHANGE_URL"
+	patchsetRevisionEnv = "GERRIT_PATCHSET_REVISION"
+	refspecEnv          = "GERRIT_REFSPEC"
+	branchEnv           = "GERRIT_BRANCH"
+	uploaderNameEnv     = "GERRIT_PATCHSET_UPLOADER_NAME"
+	uploaderEmailEnv    = "GERRIT_PATCHSET_UPLOADER_EMAIL"
+	// hostEnv is set by the Gerrit Trigger plugin to the Gerrit server of the event, for changes and for ref updates
+	hostEnv = "GERRIT_HOST"
+
+	sshPort = "29418"
+
+	// authenticatedPathPrefix is the prefix of the HTTP clone urls that require authentication, i.e. https://gerrit.company.com/a/platform/build
+	authenticatedPathPrefix = "/a/"
+	gitilesPath             = "plugins/gitiles"
+
+	versionApiPath = "/config/server/version"
+)
+
+// jsonPrefix is the prefix of the JSON responses of the Gerrit REST API, which guards against XSSI
+var jsonPrefix = []byte(")]}'")
+
+// EnhanceConfiguration fills the pull request with the change and patch set of the Gerrit Trigger plugin
+func EnhanceConfiguration(src envsource.EnvSource, configuration *models.Configuration) *models.Configuration {
+	if _, isExist := src.LookupEnv(changeNumberEnv); !isExist {
+		return configuration
+	}
+
+	configuration.PullRequest.Id = src.Getenv(changeNumberEnv)
+	configuration.PullRequest.Url = src.Getenv(changeUrlEnv)
+	configuration.PullRequest.SourceRef.Sha = src.Getenv(patchsetRevisionEnv)
+	if refspec := src.Getenv(refspecEnv); refspec != "" {
+		configuration.PullRequest.SourceRef.Branch = refspec
+	}
+	configuration.PullRequest.TargetRef.Branch = src.Getenv(branchEnv)
+	if uploader := src.Getenv(uploaderNameEnv); uploader != "" {
+		configuration.Pusher.Username = uploader
+		configuration.Pusher.Email = src.Getenv(uploaderEmailEnv)
+	}
+	return configuration
+}
+
+func IsCurrentEnvironment(src envsource.EnvSource) bool {
+	_, isExist := src.LookupEnv(changeNumberEnv)
+	return isExist
+}
+
+// IsGerritCloneUrl detects Gerrit remotes by the SSH port of Gerrit.
+// HTTP clone urls are not detected by their path, other SCMs may have a group or project named "a", i.e. https://gitlab.company.com/a/repo.git
+func IsGerritCloneUrl(cloneUrl string) bool {
+	urlObject, err := url.Parse(cloneUrl)
+	if err != nil {
+		return false
+	}
+	return urlObject.Scheme == "ssh" && urlObject.Port() == sshPort
+}
+
+// IsGerritTriggerCloneUrl checks that the build was triggered by the Gerrit Trigger plugin for the server of the clone url
+func IsGerritTriggerCloneUrl(src envsource.EnvSource, cloneUrl string) bool {
+	host := src.Getenv(hostEnv)
+	if host == "" {
+		return false
+	}
+	urlObject, err := url.Parse(cloneUrl)
+	return err == nil && strings.EqualFold(urlObject.Hostname(), host)
+}
+
+// CheckGerritByHTTPRequest checks that the server of the clone url answers the version endpoint of the Gerrit REST API,
+// whose responses start with the )]}' prefix of Gerrit
+func CheckGerritByHTTPRequest(cloneUrl string, httpClient http.HTTPService) bool {
+	serverUrl, _, err := ParseCloneUrl(cloneUrl)
+	if err != nil {
+		return false
+	}
+	body, err := httpClient.Get(fmt.Sprintf("%s%s", serverUrl, versionApiPath), nil, nil)
+	return err == nil && bytes.HasPrefix(body, jsonPrefix)
+}
+
+// ParseCloneUrl returns the server url and the project of a Gerrit clone url,
+// the server url keeps the context path that Gerrit is served under, i.e. https://company.com/r for https://company.com/r/a/platform/build.git
+func ParseCloneUrl(cloneUrl string) (string, string, error) {
+	urlObject, err := url.Parse(cloneUrl)
+	if err != nil {
+		return "", "", err
+	}
+
+	serverUrl := fmt.Sprintf("https://%s", urlObject.Hostname())
+	project := urlObject.Path
+	if urlObject.Scheme == "http" || urlObject.Scheme == "https" {
+		serverUrl = fmt.Sprintf("%s://%s", urlObject.Scheme, urlObject.Host)
+		if index := strings.Index(project, authenticatedPathPrefix); index != -1 {
+			serverUrl += project[:index]
+			project = project[index+len(authenticatedPathPrefix):]
+		}
+	}
+
+	project = strings.TrimSuffix(strings.Trim(project, "/"), ".git")
+	if urlObject.Hostname() == "" || project == "" {
+		return "", "", fmt.Errorf("could not parse clone url: %s", cloneUrl)
+	}
+	return serverUrl, project, nil
+}
+
+// GetGerritApiUrl returns the server url of a Gerrit clone url, the REST API of Gerrit is served at the root of the server
+func GetGerritApiUrl(cloneUrl string) string {
+	serverUrl, _, err := ParseCloneUrl(cloneUrl)
+	if err != nil {
+		return ""
+	}
+	return serverUrl
+}
+
+// BuildScmLink returns the Gitiles link to the project
+func BuildScmLink(serverUrl, project string) string {
+	return fmt.Sprintf("%s/%s/%s", serverUrl, gitilesPath, project)
+}
@@ -0,0 +1,215 @@
+package gerrit
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/argonsecurity/go-environments/environments/testutils"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/http"
+	"github.com/argonsecurity/go-environments/models"
+	"github.com/stretchr/testify/assert"
+)
+
+const jenkinsGerritChangeEnvs = "testdata/jenkins-gerrit-change-env.json"
+
+func TestEnhanceConfiguration(t *testing.T) {
+	type args struct {
+		configuration *models.Configuration
+	}
+	tests := []struct {
+		name         string
+		envsFilePath string
+		args         args
+		want         *models.Configuration
+	}{
+		{
+			name:         "Jenkins Gerrit Trigger change env",
+			envsFilePath: jenkinsGerritChangeEnvs,
+			args: args{
+				configuration: &models.Configuration{},
+			},
+			want: &models.Configuration{
+				PullRequest: models.PullRequest{
+					Id:  "1234",
+					Url: "https://gerrit.company.com/c/platform/build/+/1234",
+					SourceRef: models.Ref{
+						Sha:    "5a1c2f0d3b9e8a7c6d5e4f3a2b1c0d9e8f7a6b5c",
+						Branch: "refs/changes/34/1234/2",
+					},
+					TargetRef: models.Ref{
+						Branch: "main",
+					},
+				},
+				Pusher: models.Pusher{
+					Username: "User Name",
+					Email:    "user@company.com",
+				},
+			},
+		},
+		{
+			name:         "Without Gerrit Trigger env",
+			envsFilePath: "",
+			args: args{
+				configuration: &models.Configuration{Branch: "main"},
+			},
+			want: &models.Configuration{Branch: "main"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			envCleanup := testutils.SetEnvsFromFile(tt.envsFilePath)
+			t.Cleanup(envCleanup)
+			got := EnhanceConfiguration(envsource.OS, tt.args.configuration)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIsGerritCloneUrl(t *testing.T) {
+	tests := []struct {
+		name     string
+		cloneUrl string
+		want     bool
+	}{
+		{
+			name:     "SSH clone url with the Gerrit port",
+			cloneUrl: "ssh://gerrit.company.com:29418/platform/build.git",
+			want:     true,
+		},
+		{
+			name:     "Authenticated HTTP clone url",
+			cloneUrl: "https://gerrit.company.com/a/platform/build.git",
+			want:     false,
+		},
+		{
+			name:     "GitLab clone url of a group named a",
+			cloneUrl: "https://gitlab.company.com/a/repo.git",
+			want:     false,
+		},
+		{
+			name:     "Authenticated HTTP clone url with a context path",
+			cloneUrl: "https://company.com/r/a/platform/build.git",
+			want:     false,
+		},
+		{
+			name:     "Bitbucket Server clone url of a project named a",
+			cloneUrl: "https://bitbucket.company.com/scm/a/repo.git",
+			want:     false,
+		},
+		{
+			name:     "GitLab clone url of a subgroup named a",
+			cloneUrl: "https://git.company.com/team/a/repo.git",
+			want:     false,
+		},
+		{
+			name:     "SSH clone url with another port",
+			cloneUrl: "ssh://git.company.com:7999/platform/build.git",
+			want:     false,
+		},
+		{
+			name:     "GitHub HTTP clone url",
+			cloneUrl: "https://github.com/test-organization/test-repo.git",
+			want:     false,
+		},
+		{
+			name:     "GitHub SSH clone url",
+			cloneUrl: "git@github.com:test-organization/test-repo.git",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsGerritCloneUrl(tt.cloneUrl))
+		})
+	}
+}
+
+func TestIsGerritTriggerCloneUrl(t *testing.T) {
+	src := envsource.New(map[string]string{hostEnv: "gerrit.company.com"})
+	assert.True(t, IsGerritTriggerCloneUrl(src, "https://gerrit.company.com/a/platform/build.git"))
+	assert.True(t, IsGerritTriggerCloneUrl(src, "https://gerrit.company.com/platform/build.git"))
+	assert.False(t, IsGerritTriggerCloneUrl(src, "https://gitlab.company.com/a/repo.git"))
+	assert.False(t, IsGerritTriggerCloneUrl(envsource.New(nil), "https://gerrit.company.com/a/platform/build.git"))
+}
+
+type httpServiceMock struct {
+	body []byte
+	err  error
+	urls []string
+}
+
+func (m *httpServiceMock) Get(url string, headers http.Headers, params http.Params) ([]byte, error) {
+	m.urls = append(m.urls, url)
+	return m.body, m.err
+}
+
+func (m *httpServiceMock) Post(url string, headers http.Headers, data interface{}) ([]byte, error) {
+	return nil, nil
+}
+
+func (m *httpServiceMock) Put(url string, headers http.Headers, data interface{}) ([]byte, error) {
+	return nil, nil
+}
+
+func (m *httpServiceMock) Delete(url string, headers http.Headers, data interface{}) ([]byte, error) {
+	return nil, nil
+}
+
+func TestCheckGerritByHTTPRequest(t *testing.T) {
+	client := &httpServiceMock{body: []byte(")]}'\n\"3.9.1\"")}
+	assert.True(t, CheckGerritByHTTPRequest("https://company.com/r/a/platform/build.git", client))
+	assert.Equal(t, []string{"https://company.com/r/config/server/version"}, client.urls)
+
+	assert.False(t, CheckGerritByHTTPRequest("https://gitlab.company.com/a/repo.git", &httpServiceMock{body: []byte(`{"version":"16.0"}`)}))
+	assert.False(t, CheckGerritByHTTPRequest("https://gitlab.company.com/a/repo.git", &httpServiceMock{err: errors.New("got a response with status code 404")}))
+}
+
+func TestParseCloneUrl(t *testing.T) {
+	tests := []struct {
+		name          string
+		cloneUrl      string
+		wantServerUrl string
+		wantProject   string
+		wantErr       bool
+	}{
+		{
+			name:          "SSH clone url",
+			cloneUrl:      "ssh://gerrit.company.com:29418/platform/build.git",
+			wantServerUrl: "https://gerrit.company.com",
+			wantProject:   "platform/build",
+		},
+		{
+			name:          "Authenticated HTTP clone url",
+			cloneUrl:      "https://gerrit.company.com/a/tools.git",
+			wantServerUrl: "https://gerrit.company.com",
+			wantProject:   "tools",
+		},
+		{
+			name:          "Authenticated HTTP clone url with a context path and port",
+			cloneUrl:      "https://company.com:8443/r/a/platform/build",
+			wantServerUrl: "https://company.com:8443/r",
+			wantProject:   "platform/build",
+		},
+		{
+			name:     "Clone url without project",
+			cloneUrl: "ssh://gerrit.company.com:29418/",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotServerUrl, gotProject, err := ParseCloneUrl(tt.cloneUrl)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseCloneUrl() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.wantServerUrl, gotServerUrl)
+			assert.Equal(t, tt.wantProject, gotProject)
+		})
+	}
+}
+
+func TestBuildScmLink(t *testing.T) {
+	assert.Equal(t, "https://gerrit.company.com/plugins/gitiles/platform/build", BuildScmLink("https://gerrit.company.com", "platform/build"))
+}
@@ -2,8 +2,8 @@ package github
 
 import (
 	"github.com/argonsecurity/go-environments/environments/utils"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
 	"github.com/argonsecurity/go-environments/models"
-	"os"
 )
 
 const (
@@ -12,14 +12,22 @@ const (
 )
 
 func IsGitHubSingleJenkinsPipeline() bool {
-	_, isExist := os.LookupEnv(branchEnvGhSinglePipeline)
+	return IsGitHubSingleJenkinsPipelineFrom(envsource.OS)
+}
+
+func IsGitHubSingleJenkinsPipelineFrom(src envsource.EnvSource) bool {
+	_, isExist := src.LookupEnv(branchEnvGhSinglePipeline)
 	return isExist
 }
 
 func EnhanceConfigurationSinglePipeline(configuration *models.Configuration) *models.Configuration {
+	return EnhanceConfigurationSinglePipelineFrom(envsource.OS, configuration)
+}
+
+func EnhanceConfigurationSinglePipelineFrom(src envsource.EnvSource, configuration *models.Configuration) *models.Configuration {
 
-	utils.SetValueIfEnvExist(configuration, branchEnvGhSinglePipeline, "PullRequest.SourceRef.Branch")
-	utils.SetValueIfEnvExist(configuration, targetBranchNameGhSinglePipeline, "PullRequest.TargetRef.Branch")
+	utils.SetValueIfEnvExistFrom(src, configuration, branchEnvGhSinglePipeline, "PullRequest.SourceRef.Branch")
+	utils.SetValueIfEnvExistFrom(src, configuration, targetBranchNameGhSinglePipeline, "PullRequest.TargetRef.Branch")
 
 	return configuration
 }
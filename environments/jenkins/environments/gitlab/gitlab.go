@@ -2,9 +2,9 @@ package gitlab
 
 import (
 	"fmt"
-	"os"
 	"strings"
 
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
 	"github.com/argonsecurity/go-environments/http"
 	"github.com/argonsecurity/go-environments/models"
 )
@@ -24,29 +24,41 @@ const (
 )
 
 func EnhanceConfiguration(configuration *models.Configuration) *models.Configuration {
-	if _, isExists := os.LookupEnv(gitlabSourceRepoHttpUrlEnv); isExists {
-		return EnhanceConfigurationWithEnvs(configuration)
+	return EnhanceConfigurationFrom(envsource.OS, configuration)
+}
+
+func EnhanceConfigurationFrom(src envsource.EnvSource, configuration *models.Configuration) *models.Configuration {
+	if _, isExists := src.LookupEnv(gitlabSourceRepoHttpUrlEnv); isExists {
+		return EnhanceConfigurationWithEnvsFrom(src, configuration)
 	}
 
 	return configuration
 }
 
 func EnhanceConfigurationWithEnvs(configuration *models.Configuration) *models.Configuration {
-	configuration.Repository.CloneUrl = os.Getenv(gitlabSourceRepoHttpUrlEnv)
-	configuration.Repository.Name = os.Getenv(gitlabSourceRepoNameEnv)
-	configuration.Branch = os.Getenv(gitlabBranchEnv)
-	configuration.PullRequest.SourceRef.Branch = os.Getenv(gitlabSourceBranchEnv)
-	configuration.PullRequest.TargetRef.Branch = os.Getenv(gitlabTargetBranchEnv)
-	configuration.BeforeCommitSha = os.Getenv(gitlabBeforeEnv)
-	configuration.CommitSha = os.Getenv(gitlabMergeRequestLastCommitEnv)
-	configuration.Pusher.Username = os.Getenv(gitlabUserUsernameEnv)
-	configuration.Pusher.Name = os.Getenv(gitlabUserNameEnv)
+	return EnhanceConfigurationWithEnvsFrom(envsource.OS, configuration)
+}
+
+func EnhanceConfigurationWithEnvsFrom(src envsource.EnvSource, configuration *models.Configuration) *models.Configuration {
+	configuration.Repository.CloneUrl = src.Getenv(gitlabSourceRepoHttpUrlEnv)
+	configuration.Repository.Name = src.Getenv(gitlabSourceRepoNameEnv)
+	configuration.Branch = src.Getenv(gitlabBranchEnv)
+	configuration.PullRequest.SourceRef.Branch = src.Getenv(gitlabSourceBranchEnv)
+	configuration.PullRequest.TargetRef.Branch = src.Getenv(gitlabTargetBranchEnv)
+	configuration.BeforeCommitSha = src.Getenv(gitlabBeforeEnv)
+	configuration.CommitSha = src.Getenv(gitlabMergeRequestLastCommitEnv)
+	configuration.Pusher.Username = src.Getenv(gitlabUserUsernameEnv)
+	configuration.Pusher.Name = src.Getenv(gitlabUserNameEnv)
 
 	return configuration
 }
 
 func IsCurrentEnvironment() bool {
-	_, isExist := os.LookupEnv(gitlabSourceRepoURLEnv)
+	return IsCurrentEnvironmentFrom(envsource.OS)
+}
+
+func IsCurrentEnvironmentFrom(src envsource.EnvSource) bool {
+	_, isExist := src.LookupEnv(gitlabSourceRepoURLEnv)
 	return isExist
 }
 
@@ -1,32 +1,52 @@
 package localhost
 
 import (
-	"os"
-
 	"github.com/argonsecurity/go-environments/enums"
 	"github.com/argonsecurity/go-environments/environments/utils"
-	"github.com/argonsecurity/go-environments/environments/utils/git"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
 	"github.com/argonsecurity/go-environments/models"
 )
 
 var (
-	Localhost     = environment{}
-	configuration *models.Configuration
+	Localhost = New()
 )
 
-type environment struct{}
+// Environment is the localhost environment
+type Environment struct {
+	cache utils.ConfigurationCache
+}
 
-func (e environment) GetConfiguration() (*models.Configuration, error) {
-	if configuration == nil {
-		loadConfiguration()
-	}
-	return configuration, nil
+// New creates a localhost environment with its own configuration cache
+func New() *Environment {
+	return &Environment{}
+}
+
+func (e *Environment) GetConfiguration() (*models.Configuration, error) {
+	return e.cache.Get(e.load)
+}
+
+// Refresh loads the configuration again and replaces the cached configuration
+func (e *Environment) Refresh() (*models.Configuration, error) {
+	return e.cache.Refresh(e.load)
+}
+
+// Reset clears the cached configuration, it is loaded again on the next GetConfiguration
+func (e *Environment) Reset() {
+	e.cache.Reset()
+}
+
+func (e *Environment) load() (*models.Configuration, error) {
+	return loadConfiguration(envsource.OS), nil
+}
+
+func (e *Environment) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return loadConfiguration(src), nil
 }
 
-func loadConfiguration() {
-	commit := getCommit()
-	branch := getBranch(commit)
-	configuration = &models.Configuration{
+func loadConfiguration(src envsource.EnvSource) *models.Configuration {
+	commit := getCommit(src)
+	branch, warnings := getBranch(src, commit)
+	return &models.Configuration{
 		Url:       "localhost",
 		Branch:    branch,
 		CommitSha: commit,
@@ -34,7 +54,7 @@ func loadConfiguration() {
 			Id:     "localhost",
 			Name:   "localhost",
 			Url:    "localhost",
-			Source: getSource(),
+			Source: getSource(src),
 		},
 		Pipeline: models.Pipeline{
 			Entity: models.Entity{
@@ -59,49 +79,50 @@ func loadConfiguration() {
 		PipelinePaths: []string{},
 		ScmId:         "localhost",
 		Pusher: models.Pusher{
-			Username: utils.DetectPusher(),
+			Username: utils.DetectPusherFrom(src),
 		},
+		Warnings: warnings,
 	}
 }
 
-func (e environment) Name() string {
+func (e *Environment) Name() string {
 	return "localhost"
 }
 
-func (e environment) GetStepLink() string {
+func (e *Environment) GetStepLink() string {
 	return "localhost"
 }
 
-func (e environment) GetBuildLink() string {
+func (e *Environment) GetBuildLink() string {
 	return "localhost"
 }
 
-func (e environment) GetFileLink(filename string, branch string, commit string) string {
+func (e *Environment) GetFileLink(filename string, branch string, commit string) string {
 	return ""
 }
 
-func (e environment) GetFileLineLink(filename string, ref string, commit string, startLine int, endLine int) string {
+func (e *Environment) GetFileLineLink(filename string, ref string, commit string, startLine int, endLine int) string {
 	return "localhost"
 }
 
-func getCommit() string {
-	path, _ := os.Getwd()
-	commit, _ := git.GetGitCommit(path)
+func getCommit(src envsource.EnvSource) string {
+	path := envsource.Getwd(src)
+	commit, _ := envsource.GitClient(src).GetGitCommit(envsource.Path(src, path))
 	return commit
 }
 
-func getBranch(commit string) string {
-	if branch, ok := os.LookupEnv("OVERRIDE_BRANCH"); ok {
-		return branch
+func getBranch(src envsource.EnvSource, commit string) (string, []models.Warning) {
+	if branch, ok := src.LookupEnv("OVERRIDE_BRANCH"); ok {
+		return branch, nil
 	}
 
-	path, _ := os.Getwd()
-	branch, _ := git.GetGitBranch(path, commit)
-	return branch
+	path := envsource.Getwd(src)
+	branch, warnings, _ := envsource.GetGitBranch(src, path, commit)
+	return branch, warnings
 }
 
-func getSource() enums.Source {
-	source, ok := os.LookupEnv("OVERRIDE_BUILDSYSTEM")
+func getSource(src envsource.EnvSource) enums.Source {
+	source, ok := src.LookupEnv("OVERRIDE_BUILDSYSTEM")
 	if ok {
 		return enums.Source(source)
 	}
@@ -109,6 +130,6 @@ func getSource() enums.Source {
 	return enums.Localhost
 }
 
-func (e environment) IsCurrentEnvironment() bool {
+func (e *Environment) IsCurrentEnvironment() bool {
 	return false
 }
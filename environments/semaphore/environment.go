@@ -0,0 +1,277 @@
+package semaphore
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/utils"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/models"
+)
+
+const (
+	builder      = "Semaphore"
+	semaphoreEnv = "SEMAPHORE"
+
+	organizationUrlEnv = "SEMAPHORE_ORGANIZATION_URL"
+	projectIdEnv       = "SEMAPHORE_PROJECT_ID"
+	projectNameEnv     = "SEMAPHORE_PROJECT_NAME"
+
+	workflowIdEnv     = "SEMAPHORE_WORKFLOW_ID"
+	workflowNumberEnv = "SEMAPHORE_WORKFLOW_NUMBER"
+
+	jobIdEnv   = "SEMAPHORE_JOB_ID"
+	jobNameEnv = "SEMAPHORE_JOB_NAME"
+
+	agentMachineTypeEnv    = "SEMAPHORE_AGENT_MACHINE_TYPE"
+	agentMachineOsImageEnv = "SEMAPHORE_AGENT_MACHINE_OS_IMAGE"
+
+	repositoryCloneUrlEnv = "SEMAPHORE_GIT_URL"
+	repositoryDirEnv      = "SEMAPHORE_GIT_DIR"
+	commitShaEnv          = "SEMAPHORE_GIT_SHA"
+	branchEnv             = "SEMAPHORE_GIT_BRANCH"
+	workingBranchEnv      = "SEMAPHORE_GIT_WORKING_BRANCH"
+	committerEnv          = "SEMAPHORE_GIT_COMMITTER"
+
+	pullRequestNumberEnv = "SEMAPHORE_GIT_PR_NUMBER"
+	pullRequestBranchEnv = "SEMAPHORE_GIT_PR_BRANCH"
+	pullRequestShaEnv    = "SEMAPHORE_GIT_PR_SHA"
+
+	homeEnv = "HOME"
+
+	semaphoreDir          = ".semaphore"
+	semaphorePipelineFile = ".semaphore/semaphore.yml"
+)
+
+var (
+	// Semaphore environment
+	Semaphore = New()
+)
+
+// Environment is the Semaphore environment
+type Environment struct {
+	cache utils.ConfigurationCache
+}
+
+// New creates a Semaphore environment with its own configuration cache
+func New() *Environment {
+	return &Environment{}
+}
+
+func (e *Environment) GetConfiguration() (*models.Configuration, error) {
+	return e.cache.Get(e.load)
+}
+
+// Refresh loads the configuration again and replaces the cached configuration
+func (e *Environment) Refresh() (*models.Configuration, error) {
+	return e.cache.Refresh(e.load)
+}
+
+// Reset clears the cached configuration, it is loaded again on the next GetConfiguration
+func (e *Environment) Reset() {
+	e.cache.Reset()
+}
+
+func (e *Environment) load() (*models.Configuration, error) {
+	return loadConfiguration(envsource.OS)
+}
+
+func (e *Environment) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return loadConfiguration(src)
+}
+
+func loadConfiguration(src envsource.EnvSource) (*models.Configuration, error) {
+	cloneUrl := utils.StripCredentialsFromUrl(src.Getenv(repositoryCloneUrlEnv))
+	source, apiUrl := utils.GetRepositorySource(cloneUrl)
+	repoUrl, org, repoName, repoFullName, err := utils.ParseDataFromCloneUrl(cloneUrl, apiUrl, source)
+	if err != nil {
+		return nil, err
+	}
+
+	repoPath := getRepositoryPath(src)
+	branch := getBranch(src)
+	commit := src.Getenv(commitShaEnv)
+
+	pullRequest := models.PullRequest{}
+	if prNumber := src.Getenv(pullRequestNumberEnv); prNumber != "" {
+		// the commit of a pull request workflow is the merge commit, the head of the pull request is the commit that was pushed
+		commit = src.Getenv(pullRequestShaEnv)
+		pullRequest = models.PullRequest{
+			Id: prNumber,
+			SourceRef: models.Ref{
+				Branch: branch,
+				Sha:    commit,
+			},
+			TargetRef: models.Ref{
+				Branch: src.Getenv(branchEnv),
+			},
+		}
+	}
+
+	pipelinePaths, warnings := getPipelinePaths(src, repoPath)
+	return &models.Configuration{
+		Url:       src.Getenv(organizationUrlEnv),
+		SCMApiUrl: apiUrl,
+		LocalPath: repoPath,
+		CommitSha: commit,
+		Branch:    branch,
+		Repository: models.Repository{
+			Name:     repoName,
+			FullName: repoFullName,
+			Url:      repoUrl,
+			CloneUrl: cloneUrl,
+			Source:   source,
+		},
+		Organization: models.Entity{
+			Name: org,
+		},
+		Pipeline: models.Pipeline{
+			Entity: models.Entity{
+				Id:   src.Getenv(projectIdEnv),
+				Name: src.Getenv(projectNameEnv),
+			},
+			Path: getPipelinePath(repoPath, pipelinePaths),
+		},
+		Job: models.Entity{
+			Id:   src.Getenv(jobIdEnv),
+			Name: src.Getenv(jobNameEnv),
+		},
+		Run: models.BuildRun{
+			BuildId:     src.Getenv(workflowIdEnv),
+			BuildNumber: src.Getenv(workflowNumberEnv),
+		},
+		Runner: models.Runner{
+			Name:         src.Getenv(agentMachineTypeEnv),
+			OS:           runtime.GOOS,
+			Distribution: src.Getenv(agentMachineOsImageEnv),
+			Architecture: runtime.GOARCH,
+		},
+		PullRequest: pullRequest,
+		Builder:     builder,
+		Pusher: models.Pusher{
+			Username: getPusher(src),
+		},
+		PipelinePaths: pipelinePaths,
+		Environment:   enums.Semaphore,
+		ScmId:         utils.GenerateScmId(cloneUrl),
+		Warnings:      warnings,
+	}, nil
+}
+
+// getRepositoryPath returns the directory of the checkout, a relative SEMAPHORE_GIT_DIR is relative to the home directory
+func getRepositoryPath(src envsource.EnvSource) string {
+	dir := src.Getenv(repositoryDirEnv)
+	if dir == "" || filepath.IsAbs(dir) {
+		return dir
+	}
+	return filepath.Join(src.Getenv(homeEnv), dir)
+}
+
+// getBranch returns the pull request branch of pull request workflows and the pushed branch otherwise
+func getBranch(src envsource.EnvSource) string {
+	if branch := src.Getenv(workingBranchEnv); branch != "" {
+		return branch
+	}
+	if branch := src.Getenv(pullRequestBranchEnv); branch != "" {
+		return branch
+	}
+	return src.Getenv(branchEnv)
+}
+
+func getPusher(src envsource.EnvSource) string {
+	if committer := src.Getenv(committerEnv); committer != "" {
+		return committer
+	}
+	return utils.DetectPusherFrom(src)
+}
+
+func (e *Environment) GetStepLink() string {
+	return fmt.Sprintf("%s/jobs/%s", os.Getenv(organizationUrlEnv), os.Getenv(jobIdEnv))
+}
+
+func (e *Environment) GetBuildLink() string {
+	return fmt.Sprintf("%s/workflows/%s", os.Getenv(organizationUrlEnv), os.Getenv(workflowIdEnv))
+}
+
+func (e *Environment) GetFileLink(filename string, branch string, commit string) string {
+	return ""
+}
+
+func (e *Environment) GetFileLineLink(filename string, branch string, commit string, startLine int, endLine int) string {
+	return ""
+}
+
+func (e *Environment) IsCurrentEnvironment() bool {
+	_, isExist := os.LookupEnv(semaphoreEnv)
+	return isExist
+}
+
+// DetectionVariables returns the variables used by IsCurrentEnvironment
+func (e *Environment) DetectionVariables() []string {
+	return []string{semaphoreEnv}
+}
+
+// ExpectedFields returns the configuration fields the environment is expected to fill and the variables they are filled from
+func (e *Environment) ExpectedFields() []models.ExpectedField {
+	return []models.ExpectedField{
+		{Field: "repository.url", SourceEnv: repositoryCloneUrlEnv, Severity: models.SeverityCritical},
+		{Field: "repository.cloneUrl", SourceEnv: repositoryCloneUrlEnv, Severity: models.SeverityCritical},
+		{Field: "commitSha", SourceEnv: commitShaEnv, Severity: models.SeverityCritical},
+		{Field: "branch", SourceEnv: workingBranchEnv, Severity: models.SeverityWarning},
+		{Field: "localPath", SourceEnv: repositoryDirEnv, Severity: models.SeverityWarning},
+		{Field: "pipeline.id", SourceEnv: projectIdEnv, Severity: models.SeverityWarning},
+		{Field: "job.id", SourceEnv: jobIdEnv, Severity: models.SeverityWarning},
+		{Field: "run.buildId", SourceEnv: workflowIdEnv, Severity: models.SeverityWarning},
+		{Field: "runner.name", SourceEnv: agentMachineTypeEnv, Severity: models.SeverityWarning},
+	}
+}
+
+func (e *Environment) Name() string {
+	return "semaphore"
+}
+
+// getPipelinePath returns the path of the initial pipeline file relative to the repository, or an empty string when the repository has none
+func getPipelinePath(rootDir string, pipelinePaths []string) string {
+	for _, path := range pipelinePaths {
+		if path == filepath.Join(rootDir, semaphorePipelineFile) {
+			return semaphorePipelineFile
+		}
+	}
+	return ""
+}
+
+// getPipelinePaths returns the pipeline files of the .semaphore directory, the initial pipeline and the pipelines of the promotions
+func getPipelinePaths(src envsource.EnvSource, rootDir string) ([]string, []models.Warning) {
+	paths := make([]string, 0)
+	var warnings []models.Warning
+
+	dir := filepath.Join(rootDir, semaphoreDir)
+	if _, err := envsource.Stat(src, dir); err != nil {
+		return paths, warnings
+	}
+	err := envsource.Walk(src, dir, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			warnings = append(warnings, models.NewWarning(models.PipelinePathsWarning, err, "failed to search %s for pipeline files", path))
+			return nil
+		}
+		if info.IsDir() {
+			if path != dir {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) == ".yml" || filepath.Ext(path) == ".yaml" {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		warnings = append(warnings, models.NewWarning(models.PipelinePathsWarning, err, "failed to search %s for pipeline files", dir))
+	}
+
+	return paths, warnings
+}
@@ -0,0 +1,111 @@
+package semaphore
+
+import (
+	"fmt"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/models"
+)
+
+var (
+	MockOrgName         = "test-org"
+	MockRepoName        = "test-repo"
+	MockOrganizationUrl = "https://test-org.semaphoreci.com"
+	MockWorkflowId      = "65c398bb-57ab-4459-90b7-e3d8ca8d0d49"
+	MockJobId           = "a26d42cf-89ac-4c3f-9e2d-51bb231897bf"
+)
+
+var mockConfiguration *models.Configuration
+
+type EnvironmentMock struct{}
+
+func (em *EnvironmentMock) GetConfiguration() (*models.Configuration, error) {
+	if mockConfiguration == nil {
+		if err := loadMockConfiguration(); err != nil {
+			return nil, err
+		}
+	}
+	return mockConfiguration, nil
+}
+
+func (em *EnvironmentMock) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return em.GetConfiguration()
+}
+
+func (em *EnvironmentMock) Refresh() (*models.Configuration, error) {
+	em.Reset()
+	return em.GetConfiguration()
+}
+
+func (em *EnvironmentMock) Reset() {
+	mockConfiguration = nil
+}
+
+func loadMockConfiguration() error {
+	mockConfiguration = &models.Configuration{
+		Url:       MockOrganizationUrl,
+		SCMApiUrl: "https://api.github.com",
+		LocalPath: "/home/semaphore/test-repo",
+		CommitSha: "2c6e3880fd94ddb7ef72d34e683cdc0c47bec6e6",
+		Branch:    "main",
+		Run: models.BuildRun{
+			BuildId:     MockWorkflowId,
+			BuildNumber: "42",
+		},
+		Job: models.Entity{
+			Id:   MockJobId,
+			Name: "go test",
+		},
+		Pipeline: models.Pipeline{
+			Entity: models.Entity{
+				Id:   "0dd982e8-32f5-4037-983e-4de01ac7fb1e",
+				Name: MockRepoName,
+			},
+			Path: ".semaphore/semaphore.yml",
+		},
+		Runner: models.Runner{
+			Name:         "e1-standard-2",
+			Distribution: "ubuntu2004",
+		},
+		Repository: models.Repository{
+			Name:     MockRepoName,
+			FullName: fmt.Sprintf("%s/%s", MockOrgName, MockRepoName),
+			Url:      fmt.Sprintf("https://github.com/%s/%s", MockOrgName, MockRepoName),
+			CloneUrl: fmt.Sprintf("git@github.com:%s/%s.git", MockOrgName, MockRepoName),
+			Source:   enums.Github,
+		},
+		Builder: "Semaphore",
+		Organization: models.Entity{
+			Name: MockOrgName,
+		},
+		PipelinePaths: []string{"/home/semaphore/test-repo/.semaphore/semaphore.yml"},
+		Environment:   enums.Semaphore,
+	}
+
+	return nil
+}
+
+func (em *EnvironmentMock) GetBuildLink() string {
+	return fmt.Sprintf("%s/workflows/%s", MockOrganizationUrl, MockWorkflowId)
+}
+
+func (em *EnvironmentMock) GetStepLink() string {
+	return fmt.Sprintf("%s/jobs/%s", MockOrganizationUrl, MockJobId)
+}
+
+func (em *EnvironmentMock) GetFileLink(filename string, branch string, commit string) string {
+	return ""
+}
+
+func (em *EnvironmentMock) GetFileLineLink(filename string, branch string, commit string, startLine int, endLine int) string {
+	return ""
+}
+
+func (em *EnvironmentMock) IsCurrentEnvironment() bool {
+	return true
+}
+
+func (em *EnvironmentMock) Name() string {
+	return "semaphore"
+}
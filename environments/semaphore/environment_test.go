@@ -0,0 +1,204 @@
+package semaphore
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/testutils"
+	"github.com/argonsecurity/go-environments/models"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	semaphoreMainEnvsFilePath = "testdata/semaphore-github-main-env.json"
+	semaphorePrEnvsFilePath   = "testdata/semaphore-bitbucket-pr-env.json"
+	testRepoPath              = "/tmp/semaphore/repo"
+	testRepoCloneUrl          = "git@github.com:test-org/test-repo.git"
+	testdataPath              = "../semaphore/testdata/repo"
+	testPipelinePaths         = []string{"/tmp/semaphore/repo/.semaphore/production-deploy.yml", "/tmp/semaphore/repo/.semaphore/semaphore.yml"}
+)
+
+func Test_environment_GetConfiguration(t *testing.T) {
+	tests := []struct {
+		name         string
+		envsFilePath string
+		want         *models.Configuration
+		wantErr      bool
+	}{
+		{
+			name:         "Semaphore GitHub main configuration",
+			envsFilePath: semaphoreMainEnvsFilePath,
+			want: &models.Configuration{
+				Url:       "https://test-org.semaphoreci.com",
+				SCMApiUrl: "https://api.github.com",
+				LocalPath: testRepoPath,
+				CommitSha: "2c6e3880fd94ddb7ef72d34e683cdc0c47bec6e6",
+				Branch:    "main",
+				Repository: models.Repository{
+					Name:     "test-repo",
+					FullName: "test-org/test-repo",
+					Url:      "https://github.com/test-org/test-repo",
+					CloneUrl: testRepoCloneUrl,
+					Source:   enums.Github,
+				},
+				Organization: models.Entity{
+					Name: "test-org",
+				},
+				Pipeline: models.Pipeline{
+					Entity: models.Entity{
+						Id:   "0dd982e8-32f5-4037-983e-4de01ac7fb1e",
+						Name: "test-repo",
+					},
+					Path: ".semaphore/semaphore.yml",
+				},
+				Job: models.Entity{
+					Id:   "a26d42cf-89ac-4c3f-9e2d-51bb231897bf",
+					Name: "go test",
+				},
+				Run: models.BuildRun{
+					BuildId:     "65c398bb-57ab-4459-90b7-e3d8ca8d0d49",
+					BuildNumber: "42",
+				},
+				Runner: models.Runner{
+					Name:         "e1-standard-2",
+					OS:           runtime.GOOS,
+					Distribution: "ubuntu2004",
+					Architecture: runtime.GOARCH,
+				},
+				Pusher: models.Pusher{
+					Username: "test-user",
+				},
+				Builder:       "Semaphore",
+				PipelinePaths: testPipelinePaths,
+				Environment:   enums.Semaphore,
+				ScmId:         "b30f418cdcc9970849d3d031de5df54f",
+			},
+		},
+		{
+			name:         "Semaphore Bitbucket pull request configuration",
+			envsFilePath: semaphorePrEnvsFilePath,
+			want: &models.Configuration{
+				Url:       "https://test-org.semaphoreci.com",
+				SCMApiUrl: "https://api.bitbucket.org/2.0",
+				LocalPath: testRepoPath,
+				CommitSha: "fe8c38a965d13d9794eb36918cb24cebe49a45c2",
+				Branch:    "feature/test",
+				Repository: models.Repository{
+					Name:     "test-repo",
+					FullName: "test-org/test-repo",
+					Url:      "https://bitbucket.org/test-org/test-repo",
+					CloneUrl: "git@bitbucket.org:test-org/test-repo.git",
+					Source:   enums.Bitbucket,
+				},
+				Organization: models.Entity{
+					Name: "test-org",
+				},
+				Pipeline: models.Pipeline{
+					Entity: models.Entity{
+						Id:   "0dd982e8-32f5-4037-983e-4de01ac7fb1e",
+						Name: "test-repo",
+					},
+					Path: ".semaphore/semaphore.yml",
+				},
+				Job: models.Entity{
+					Id:   "d1a2b3c4-89ac-4c3f-9e2d-51bb231897bf",
+					Name: "go test",
+				},
+				Run: models.BuildRun{
+					BuildId:     "7e8f9a0b-57ab-4459-90b7-e3d8ca8d0d49",
+					BuildNumber: "43",
+				},
+				Runner: models.Runner{
+					Name:         "s1-linux-agents",
+					OS:           runtime.GOOS,
+					Architecture: runtime.GOARCH,
+				},
+				PullRequest: models.PullRequest{
+					Id: "12",
+					SourceRef: models.Ref{
+						Branch: "feature/test",
+						Sha:    "fe8c38a965d13d9794eb36918cb24cebe49a45c2",
+					},
+					TargetRef: models.Ref{
+						Branch: "main",
+					},
+				},
+				Pusher: models.Pusher{
+					Username: "test-user",
+				},
+				Builder:       "Semaphore",
+				PipelinePaths: testPipelinePaths,
+				Environment:   enums.Semaphore,
+				ScmId:         "d53b8ed5e5fc953e21017ec7a6a611db",
+			},
+		},
+		{
+			name:         "Missing repository",
+			envsFilePath: "",
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := prepareTest(t, tt.envsFilePath)
+			got, err := e.GetConfiguration()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("environment.GetConfiguration() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_environment_GetBuildLink(t *testing.T) {
+	e := prepareTest(t, semaphoreMainEnvsFilePath)
+	assert.Equal(t, "https://test-org.semaphoreci.com/workflows/65c398bb-57ab-4459-90b7-e3d8ca8d0d49", e.GetBuildLink())
+	assert.Equal(t, "https://test-org.semaphoreci.com/jobs/a26d42cf-89ac-4c3f-9e2d-51bb231897bf", e.GetStepLink())
+}
+
+func Test_environment_IsCurrentEnvironment(t *testing.T) {
+	tests := []struct {
+		name         string
+		envsFilePath string
+		want         bool
+	}{
+		{
+			name:         "Semaphore environment",
+			envsFilePath: semaphoreMainEnvsFilePath,
+			want:         true,
+		},
+		{
+			name:         "Not Semaphore environment",
+			envsFilePath: "",
+			want:         false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := prepareTest(t, tt.envsFilePath)
+			if got := e.IsCurrentEnvironment(); got != tt.want {
+				t.Errorf("environment.IsCurrentEnvironment() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getPipelinePath(t *testing.T) {
+	assert.Equal(t, ".semaphore/semaphore.yml", getPipelinePath(testRepoPath, []string{
+		"/tmp/semaphore/repo/.semaphore/deploy.yml",
+		"/tmp/semaphore/repo/.semaphore/semaphore.yml",
+	}))
+	assert.Equal(t, "", getPipelinePath(testRepoPath, []string{"/tmp/semaphore/repo/.semaphore/deploy.yml"}))
+	assert.Equal(t, "", getPipelinePath(testRepoPath, []string{}))
+}
+
+func prepareTest(t *testing.T, envsFilePath string) *Environment {
+	e := New()
+	testRepoCleanup := testutils.PrepareTestGitRepository(testRepoPath, testRepoCloneUrl, testdataPath)
+	t.Cleanup(testRepoCleanup)
+	envCleanup := testutils.SetEnvsFromFile(envsFilePath)
+	t.Cleanup(envCleanup)
+	return e
+}
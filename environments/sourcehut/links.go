@@ -0,0 +1,67 @@
+package sourcehut
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/argonsecurity/go-environments/environments/utils"
+)
+
+// GetFileLink returns the link to a file on git.sr.ht, which serves files under tree/<ref>/item/<path>
+func GetFileLink(repositoryURL string, filename string, branch string, commit string) string {
+	ref := commit
+	if ref == "" {
+		ref = branch
+	}
+	if ref == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("%s/tree/%s/item/%s",
+		strings.TrimSuffix(repositoryURL, "/"),
+		ref,
+		filename)
+}
+
+func GetFileLineLink(repositoryURL string, filename string, branch string, commit string, startLine int, endLine int) string {
+	url := GetFileLink(repositoryURL, filename, branch, commit)
+	if url != "" && startLine != 0 {
+		lines := fmt.Sprintf("#L%d", startLine)
+		if endLine != 0 && endLine != startLine {
+			lines = fmt.Sprintf("%s-%d", lines, endLine)
+		}
+
+		url += lines
+	}
+
+	return url
+}
+
+var (
+	// https://git.sr.ht/~user/repo/tree/<ref>/item/path/to/file
+	fileLinkRegexp = regexp.MustCompile(`^(.+?)/tree/(.+?)/item/(.+)$`)
+	// #L10-20, or #L10 for a single line
+	linesRegexp = regexp.MustCompile(`^L(\d+)(?:-(\d+))?$`)
+)
+
+// ParseFileLineLink returns the repository url, the file, the ref and the lines of a link built by GetFileLineLink,
+// the ref is refs/heads/<branch> for a branch and the commit for a 40 character commit sha, and the end line is the start line for a single line
+func ParseFileLineLink(link string) (string, string, string, int, int, error) {
+	link, fragment, _ := strings.Cut(link, "#")
+	result := fileLinkRegexp.FindStringSubmatch(link)
+	if result == nil {
+		return "", "", "", 0, 0, fmt.Errorf("could not parse file link: %s", link)
+	}
+
+	startLine, endLine := 0, 0
+	if lines := linesRegexp.FindStringSubmatch(fragment); lines != nil {
+		startLine, _ = strconv.Atoi(lines[1])
+		endLine = startLine
+		if lines[2] != "" {
+			endLine, _ = strconv.Atoi(lines[2])
+		}
+	}
+	return result[1], result[3], utils.GetLinkRef(result[2]), startLine, endLine, nil
+}
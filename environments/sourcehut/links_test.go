@@ -0,0 +1,187 @@
+package sourcehut
+
+import (
+	"testing"
+)
+
+const (
+	testRepoURL  = "https://git.sr.ht/~test-user/test-repo"
+	testFilename = "path/to/file"
+	testBranch   = "branch"
+	testCommit   = "commit"
+)
+
+func TestGetFileLink(t *testing.T) {
+	type args struct {
+		repositoryURL string
+		filename      string
+		branch        string
+		commit        string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "With branch",
+			args: args{
+				repositoryURL: testRepoURL,
+				filename:      testFilename,
+				branch:        testBranch,
+			},
+			want: "https://git.sr.ht/~test-user/test-repo/tree/branch/item/path/to/file",
+		},
+		{
+			name: "With commit",
+			args: args{
+				repositoryURL: testRepoURL,
+				filename:      testFilename,
+				commit:        testCommit,
+			},
+			want: "https://git.sr.ht/~test-user/test-repo/tree/commit/item/path/to/file",
+		},
+		{
+			name: "With branch and commit",
+			args: args{
+				repositoryURL: testRepoURL,
+				filename:      testFilename,
+				branch:        testBranch,
+				commit:        testCommit,
+			},
+			want: "https://git.sr.ht/~test-user/test-repo/tree/commit/item/path/to/file",
+		},
+		{
+			name: "Without branch and commit",
+			args: args{
+				repositoryURL: testRepoURL,
+				filename:      testFilename,
+			},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetFileLink(tt.args.repositoryURL, tt.args.filename, tt.args.branch, tt.args.commit); got != tt.want {
+				t.Errorf("GetFileLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetFileLineLink(t *testing.T) {
+	type args struct {
+		repositoryURL string
+		filename      string
+		branch        string
+		commit        string
+		startLine     int
+		endLine       int
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "No lines",
+			args: args{
+				repositoryURL: testRepoURL,
+				filename:      testFilename,
+				branch:        testBranch,
+			},
+			want: "https://git.sr.ht/~test-user/test-repo/tree/branch/item/path/to/file",
+		},
+		{
+			name: "Only start line",
+			args: args{
+				repositoryURL: testRepoURL,
+				filename:      testFilename,
+				commit:        testCommit,
+				startLine:     1,
+			},
+			want: "https://git.sr.ht/~test-user/test-repo/tree/commit/item/path/to/file#L1",
+		},
+		{
+			name: "With start line and end line",
+			args: args{
+				repositoryURL: testRepoURL,
+				filename:      testFilename,
+				branch:        testBranch,
+				commit:        testCommit,
+				startLine:     1,
+				endLine:       2,
+			},
+			want: "https://git.sr.ht/~test-user/test-repo/tree/commit/item/path/to/file#L1-2",
+		},
+		{
+			name: "Same start and end line",
+			args: args{
+				repositoryURL: testRepoURL,
+				filename:      testFilename,
+				branch:        testBranch,
+				startLine:     1,
+				endLine:       1,
+			},
+			want: "https://git.sr.ht/~test-user/test-repo/tree/branch/item/path/to/file#L1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetFileLineLink(tt.args.repositoryURL, tt.args.filename, tt.args.branch, tt.args.commit, tt.args.startLine, tt.args.endLine); got != tt.want {
+				t.Errorf("GetFileLineLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFileLineLink(t *testing.T) {
+	tests := []struct {
+		name              string
+		link              string
+		wantRepositoryURL string
+		wantFilename      string
+		wantRef           string
+		wantStartLine     int
+		wantEndLine       int
+		wantErr           bool
+	}{
+		{
+			name:              "Lines",
+			link:              "https://git.sr.ht/~test-user/test-repo/tree/main/item/path/to/file#L10-20",
+			wantRepositoryURL: "https://git.sr.ht/~test-user/test-repo",
+			wantFilename:      "path/to/file",
+			wantRef:           "refs/heads/main",
+			wantStartLine:     10,
+			wantEndLine:       20,
+		},
+		{
+			name:              "Single line",
+			link:              "https://git.sr.ht/~test-user/test-repo/tree/0a1b2c3d4e5f60718293a4b5c6d7e8f901234567/item/path/to/file#L10",
+			wantRepositoryURL: "https://git.sr.ht/~test-user/test-repo",
+			wantFilename:      "path/to/file",
+			wantRef:           "0a1b2c3d4e5f60718293a4b5c6d7e8f901234567",
+			wantStartLine:     10,
+			wantEndLine:       10,
+		},
+		{
+			name:    "Not a file link",
+			link:    "https://git.sr.ht/~test-user/test-repo/log",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repositoryURL, filename, ref, startLine, endLine, err := ParseFileLineLink(tt.link)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFileLineLink() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if repositoryURL != tt.wantRepositoryURL || filename != tt.wantFilename || ref != tt.wantRef {
+				t.Errorf("ParseFileLineLink() = %v, %v, %v, want %v, %v, %v", repositoryURL, filename, ref, tt.wantRepositoryURL, tt.wantFilename, tt.wantRef)
+			}
+			if startLine != tt.wantStartLine || endLine != tt.wantEndLine {
+				t.Errorf("ParseFileLineLink() lines = %v-%v, want %v-%v", startLine, endLine, tt.wantStartLine, tt.wantEndLine)
+			}
+		})
+	}
+}
@@ -0,0 +1,321 @@
+package teamcity
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/utils"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/models"
+)
+
+const (
+	builder = "TeamCity"
+
+	teamcityVersionEnv     = "TEAMCITY_VERSION"
+	buildPropertiesFileEnv = "TEAMCITY_BUILD_PROPERTIES_FILE"
+
+	configPropertiesFileProp = "teamcity.configuration.properties.file"
+	serverUrlProp            = "teamcity.serverUrl"
+
+	buildIdProp       = "teamcity.build.id"
+	buildNumberProp   = "build.number"
+	buildTypeIdProp   = "teamcity.buildType.id"
+	buildConfNameProp = "teamcity.buildConfName"
+	checkoutDirProp   = "teamcity.build.checkoutDir"
+
+	agentNameProp         = "agent.name"
+	agentOSProp           = "teamcity.agent.jvm.os.name"
+	agentArchitectureProp = "teamcity.agent.jvm.os.arch"
+
+	vcsRootUrlProp    = "vcsroot.url"
+	vcsRootBranchProp = "vcsroot.branch"
+	branchProp        = "teamcity.build.branch"
+	commitShaProp     = "build.vcs.number"
+
+	pullRequestNumberProp = "teamcity.pullRequest.number"
+	pullRequestSourceProp = "teamcity.pullRequest.source.branch"
+	pullRequestTargetProp = "teamcity.pullRequest.target.branch"
+	pullRequestUrlProp    = "teamcity.pullRequest.url"
+
+	defaultBranch   = "<default>"
+	branchRefPrefix = "refs/heads/"
+
+	kotlinDslDir           = ".teamcity"
+	kotlinDslSettingsFile  = "settings.kts"
+	kotlinDslFileExtension = ".kts"
+)
+
+var (
+	// TeamCity environment
+	TeamCity = New()
+)
+
+// Environment is the TeamCity environment
+type Environment struct {
+	cache utils.ConfigurationCache
+}
+
+// New creates a TeamCity environment with its own configuration cache
+func New() *Environment {
+	return &Environment{}
+}
+
+func (e *Environment) GetConfiguration() (*models.Configuration, error) {
+	return e.cache.Get(e.load)
+}
+
+// Refresh loads the configuration again and replaces the cached configuration
+func (e *Environment) Refresh() (*models.Configuration, error) {
+	return e.cache.Refresh(e.load)
+}
+
+// Reset clears the cached configuration, it is loaded again on the next GetConfiguration
+func (e *Environment) Reset() {
+	e.cache.Reset()
+}
+
+func (e *Environment) load() (*models.Configuration, error) {
+	return loadConfiguration(envsource.OS)
+}
+
+func (e *Environment) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return loadConfiguration(src)
+}
+
+func loadConfiguration(src envsource.EnvSource) (*models.Configuration, error) {
+	properties, err := loadProperties(src)
+	if err != nil {
+		return nil, err
+	}
+
+	cloneUrl := utils.StripCredentialsFromUrl(properties[vcsRootUrlProp])
+	source, apiUrl := utils.GetRepositorySource(cloneUrl)
+	repoUrl, org, repoName, repoFullName, err := utils.ParseDataFromCloneUrl(cloneUrl, apiUrl, source)
+	if err != nil {
+		return nil, err
+	}
+
+	repoPath := properties[checkoutDirProp]
+	branch := getBranch(properties)
+	commit := properties[commitShaProp]
+
+	pullRequest := models.PullRequest{}
+	if prNumber := properties[pullRequestNumberProp]; prNumber != "" {
+		branch = strings.TrimPrefix(properties[pullRequestSourceProp], branchRefPrefix)
+		pullRequest = models.PullRequest{
+			Id:  prNumber,
+			Url: properties[pullRequestUrlProp],
+			SourceRef: models.Ref{
+				Branch: branch,
+				Sha:    commit,
+			},
+			TargetRef: models.Ref{
+				Branch: strings.TrimPrefix(properties[pullRequestTargetProp], branchRefPrefix),
+			},
+		}
+	}
+
+	pipelinePaths, warnings := getPipelinePaths(src, repoPath)
+	return &models.Configuration{
+		Url:       properties[serverUrlProp],
+		SCMApiUrl: apiUrl,
+		LocalPath: repoPath,
+		CommitSha: commit,
+		Branch:    branch,
+		Repository: models.Repository{
+			Name:     repoName,
+			FullName: repoFullName,
+			Url:      repoUrl,
+			CloneUrl: cloneUrl,
+			Source:   source,
+		},
+		Organization: models.Entity{
+			Name: org,
+		},
+		Pipeline: models.Pipeline{
+			Entity: models.Entity{
+				Id:   properties[buildTypeIdProp],
+				Name: properties[buildConfNameProp],
+			},
+			Path: getPipelinePath(repoPath, pipelinePaths),
+		},
+		Run: models.BuildRun{
+			BuildId:     properties[buildIdProp],
+			BuildNumber: properties[buildNumberProp],
+		},
+		Runner: models.Runner{
+			Name:         properties[agentNameProp],
+			OS:           getAgentOS(properties[agentOSProp]),
+			Architecture: getAgentArchitecture(properties[agentArchitectureProp]),
+		},
+		PullRequest: pullRequest,
+		Builder:     builder,
+		Pusher: models.Pusher{
+			Username: utils.DetectPusherFrom(src),
+		},
+		PipelinePaths: pipelinePaths,
+		Environment:   enums.TeamCity,
+		ScmId:         utils.GenerateScmId(cloneUrl),
+		Warnings:      warnings,
+	}, nil
+}
+
+// loadProperties reads the build properties file of TeamCity and the configuration properties file it references,
+// the configuration properties hold the VCS root and branch of the build
+func loadProperties(src envsource.EnvSource) (map[string]string, error) {
+	buildPropertiesFile := src.Getenv(buildPropertiesFileEnv)
+	if buildPropertiesFile == "" {
+		return nil, fmt.Errorf("%s is not set", buildPropertiesFileEnv)
+	}
+	content, err := envsource.ReadFile(src, buildPropertiesFile)
+	if err != nil {
+		return nil, err
+	}
+	properties := parseProperties(content)
+
+	if configPropertiesFile := properties[configPropertiesFileProp]; configPropertiesFile != "" {
+		content, err := envsource.ReadFile(src, configPropertiesFile)
+		if err != nil {
+			return nil, err
+		}
+		// the build properties take precedence, they are resolved for the agent that runs the build
+		configProperties := parseProperties(content)
+		for key, value := range properties {
+			configProperties[key] = value
+		}
+		properties = configProperties
+	}
+
+	return properties, nil
+}
+
+// getBranch returns the logical branch name of the build, or the branch of the VCS root
+func getBranch(properties map[string]string) string {
+	if branch := properties[branchProp]; branch != "" && branch != defaultBranch {
+		return strings.TrimPrefix(branch, branchRefPrefix)
+	}
+	return strings.TrimPrefix(properties[vcsRootBranchProp], branchRefPrefix)
+}
+
+// getAgentOS converts the os name of the agent JVM, i.e. Mac OS X, to the names of runtime.GOOS
+func getAgentOS(jvmOS string) string {
+	name := strings.ToLower(jvmOS)
+	switch {
+	case name == "":
+		return runtime.GOOS
+	case strings.HasPrefix(name, "windows"):
+		return "windows"
+	case strings.HasPrefix(name, "mac"):
+		return "darwin"
+	}
+	return name
+}
+
+// getAgentArchitecture converts the os architecture of the agent JVM, i.e. aarch64, to the names of runtime.GOARCH
+func getAgentArchitecture(jvmArchitecture string) string {
+	switch strings.ToLower(jvmArchitecture) {
+	case "":
+		return runtime.GOARCH
+	case "x86_64":
+		return "amd64"
+	case "aarch64":
+		return "arm64"
+	}
+	return strings.ToLower(jvmArchitecture)
+}
+
+func (e *Environment) GetStepLink() string {
+	return e.GetBuildLink()
+}
+
+func (e *Environment) GetBuildLink() string {
+	properties, err := loadProperties(envsource.OS)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%s/buildConfiguration/%s/%s", strings.TrimSuffix(properties[serverUrlProp], "/"), properties[buildTypeIdProp], properties[buildIdProp])
+}
+
+func (e *Environment) GetFileLink(filename string, branch string, commit string) string {
+	return ""
+}
+
+func (e *Environment) GetFileLineLink(filename string, branch string, commit string, startLine int, endLine int) string {
+	return ""
+}
+
+func (e *Environment) IsCurrentEnvironment() bool {
+	_, isExist := os.LookupEnv(teamcityVersionEnv)
+	return isExist
+}
+
+// DetectionVariables returns the variables used by IsCurrentEnvironment
+func (e *Environment) DetectionVariables() []string {
+	return []string{teamcityVersionEnv}
+}
+
+// ExpectedFields returns the configuration fields the environment is expected to fill and the variables they are filled from.
+// TeamCity fills them from its properties files, so they are all filled from the file of TEAMCITY_BUILD_PROPERTIES_FILE
+func (e *Environment) ExpectedFields() []models.ExpectedField {
+	return []models.ExpectedField{
+		{Field: "repository.url", SourceEnv: buildPropertiesFileEnv, Severity: models.SeverityCritical},
+		{Field: "repository.cloneUrl", SourceEnv: buildPropertiesFileEnv, Severity: models.SeverityCritical},
+		{Field: "commitSha", SourceEnv: buildPropertiesFileEnv, Severity: models.SeverityCritical},
+		{Field: "branch", SourceEnv: buildPropertiesFileEnv, Severity: models.SeverityWarning},
+		{Field: "url", SourceEnv: buildPropertiesFileEnv, Severity: models.SeverityWarning},
+		{Field: "localPath", SourceEnv: buildPropertiesFileEnv, Severity: models.SeverityWarning},
+		{Field: "pipeline.name", SourceEnv: buildPropertiesFileEnv, Severity: models.SeverityWarning},
+		{Field: "run.buildId", SourceEnv: buildPropertiesFileEnv, Severity: models.SeverityWarning},
+		{Field: "run.buildNumber", SourceEnv: buildPropertiesFileEnv, Severity: models.SeverityWarning},
+		{Field: "runner.name", SourceEnv: buildPropertiesFileEnv, Severity: models.SeverityWarning},
+	}
+}
+
+func (e *Environment) Name() string {
+	return "teamcity"
+}
+
+// getPipelinePath returns the path of the Kotlin DSL settings relative to the repository,
+// or an empty string when the build configuration is not stored in the repository
+func getPipelinePath(rootDir string, pipelinePaths []string) string {
+	settingsPath := filepath.Join(kotlinDslDir, kotlinDslSettingsFile)
+	for _, path := range pipelinePaths {
+		if path == filepath.Join(rootDir, settingsPath) {
+			return settingsPath
+		}
+	}
+	return ""
+}
+
+// getPipelinePaths returns the Kotlin DSL files of the .teamcity directory
+func getPipelinePaths(src envsource.EnvSource, rootDir string) ([]string, []models.Warning) {
+	paths := make([]string, 0)
+	var warnings []models.Warning
+
+	dslDir := filepath.Join(rootDir, kotlinDslDir)
+	if _, err := envsource.Stat(src, dslDir); err != nil {
+		return paths, nil
+	}
+
+	err := envsource.Walk(src, dslDir, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			warnings = append(warnings, models.NewWarning(models.PipelinePathsWarning, err, "failed to search %s for pipeline files", path))
+			return nil
+		}
+		if !info.IsDir() && filepath.Ext(path) == kotlinDslFileExtension {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		warnings = append(warnings, models.NewWarning(models.PipelinePathsWarning, err, "failed to search %s for pipeline files", dslDir))
+	}
+
+	return paths, warnings
+}
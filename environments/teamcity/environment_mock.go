@@ -0,0 +1,108 @@
+package teamcity
+
+import (
+	"fmt"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/models"
+)
+
+var (
+	MockOrgName     = "test-org"
+	MockRepoName    = "test-repo"
+	MockServerUrl   = "https://teamcity.example.com"
+	MockBuildTypeId = "TestProject_Build"
+	MockBuildId     = "1234"
+)
+
+var mockConfiguration *models.Configuration
+
+type EnvironmentMock struct{}
+
+func (em *EnvironmentMock) GetConfiguration() (*models.Configuration, error) {
+	if mockConfiguration == nil {
+		if err := loadMockConfiguration(); err != nil {
+			return nil, err
+		}
+	}
+	return mockConfiguration, nil
+}
+
+func (em *EnvironmentMock) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return em.GetConfiguration()
+}
+
+func (em *EnvironmentMock) Refresh() (*models.Configuration, error) {
+	em.Reset()
+	return em.GetConfiguration()
+}
+
+func (em *EnvironmentMock) Reset() {
+	mockConfiguration = nil
+}
+
+func loadMockConfiguration() error {
+	mockConfiguration = &models.Configuration{
+		Url:       MockServerUrl,
+		SCMApiUrl: "https://api.github.com",
+		LocalPath: "/opt/buildagent/work/8f3a2b1c4d5e6f70",
+		CommitSha: "3s32e4s818c6d1s5a0f585sf73112673a9bfcfc7",
+		Branch:    "main",
+		Run: models.BuildRun{
+			BuildId:     MockBuildId,
+			BuildNumber: "42",
+		},
+		Pipeline: models.Pipeline{
+			Entity: models.Entity{
+				Id:   MockBuildTypeId,
+				Name: "Build",
+			},
+			Path: ".teamcity/settings.kts",
+		},
+		Runner: models.Runner{
+			Name:         "agent-linux-1",
+			OS:           "linux",
+			Architecture: "amd64",
+		},
+		Repository: models.Repository{
+			Name:     MockRepoName,
+			FullName: fmt.Sprintf("%s/%s", MockOrgName, MockRepoName),
+			Url:      fmt.Sprintf("https://github.com/%s/%s", MockOrgName, MockRepoName),
+			CloneUrl: fmt.Sprintf("https://github.com/%s/%s.git", MockOrgName, MockRepoName),
+			Source:   enums.Github,
+		},
+		Builder: "TeamCity",
+		Organization: models.Entity{
+			Name: MockOrgName,
+		},
+		PipelinePaths: []string{".teamcity/settings.kts"},
+		Environment:   enums.TeamCity,
+	}
+
+	return nil
+}
+
+func (em *EnvironmentMock) GetBuildLink() string {
+	return fmt.Sprintf("%s/buildConfiguration/%s/%s", MockServerUrl, MockBuildTypeId, MockBuildId)
+}
+
+func (em *EnvironmentMock) GetStepLink() string {
+	return em.GetBuildLink()
+}
+
+func (em *EnvironmentMock) GetFileLink(filename string, branch string, commit string) string {
+	return ""
+}
+
+func (em *EnvironmentMock) GetFileLineLink(filename string, branch string, commit string, startLine int, endLine int) string {
+	return ""
+}
+
+func (em *EnvironmentMock) IsCurrentEnvironment() bool {
+	return true
+}
+
+func (em *EnvironmentMock) Name() string {
+	return "teamcity"
+}
@@ -0,0 +1,249 @@
+package teamcity
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/testutils"
+	"github.com/argonsecurity/go-environments/models"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	teamcityMainEnvsFilePath          = "testdata/teamcity-main-env.json"
+	teamcityPrEnvsFilePath            = "testdata/teamcity-pr-env.json"
+	teamcityMissingConfigEnvsFilePath = "testdata/teamcity-missing-config-env.json"
+	testRepoPath                      = "/tmp/teamcity/repo"
+	testRepoUrl                       = "https://github.com/test-organization/test-repo"
+	testRepoCloneUrl                  = fmt.Sprintf("%s%s", testRepoUrl, ".git")
+	testdataPath                      = "../teamcity/testdata/repo"
+)
+
+func Test_environment_GetConfiguration(t *testing.T) {
+	tests := []struct {
+		name         string
+		envsFilePath string
+		want         *models.Configuration
+		wantErr      bool
+	}{
+		{
+			name:         "TeamCity main configuration",
+			envsFilePath: teamcityMainEnvsFilePath,
+			want: &models.Configuration{
+				Url:       "https://teamcity.example.com",
+				SCMApiUrl: "https://api.github.com",
+				LocalPath: testRepoPath,
+				CommitSha: "kcy8v2oazy4acuo1475rgtzg0nh403p23vw812lv",
+				Branch:    "main",
+				Repository: models.Repository{
+					Name:     "test-repo",
+					FullName: "test-organization/test-repo",
+					Url:      testRepoUrl,
+					CloneUrl: testRepoCloneUrl,
+					Source:   enums.Github,
+				},
+				Organization: models.Entity{
+					Name: "test-organization",
+				},
+				Pipeline: models.Pipeline{
+					Entity: models.Entity{
+						Id:   "TestProject_Build",
+						Name: "Build",
+					},
+					Path: ".teamcity/settings.kts",
+				},
+				Run: models.BuildRun{
+					BuildId:     "1234",
+					BuildNumber: "42",
+				},
+				Runner: models.Runner{
+					Name:         "agent-linux-1",
+					OS:           "linux",
+					Architecture: "amd64",
+				},
+				Builder:       "TeamCity",
+				PipelinePaths: []string{"/tmp/teamcity/repo/.teamcity/settings.kts"},
+				Environment:   enums.TeamCity,
+				ScmId:         "8891c0db39f3064732cc1b4ac02c9b9f",
+			},
+		},
+		{
+			name:         "TeamCity pull request configuration",
+			envsFilePath: teamcityPrEnvsFilePath,
+			want: &models.Configuration{
+				Url:       "https://teamcity.example.com/",
+				SCMApiUrl: "https://api.github.com",
+				LocalPath: testRepoPath,
+				CommitSha: "y2nc0ns6qk8qs4dfbsvnjrf2o8dvmrfrthmx9nbl",
+				Branch:    "feature/teamcity",
+				Repository: models.Repository{
+					Name:     "test-repo",
+					FullName: "test-organization/test-repo",
+					Url:      testRepoUrl,
+					CloneUrl: "git@github.com:test-organization/test-repo.git",
+					Source:   enums.Github,
+				},
+				Organization: models.Entity{
+					Name: "test-organization",
+				},
+				Pipeline: models.Pipeline{
+					Entity: models.Entity{
+						Id:   "TestProject_PullRequests",
+						Name: "Pull Requests",
+					},
+					Path: ".teamcity/settings.kts",
+				},
+				Run: models.BuildRun{
+					BuildId:     "1235",
+					BuildNumber: "43",
+				},
+				Runner: models.Runner{
+					Name:         "agent-mac-1",
+					OS:           "darwin",
+					Architecture: "arm64",
+				},
+				PullRequest: models.PullRequest{
+					Id:  "7",
+					Url: "https://github.com/test-organization/test-repo/pull/7",
+					SourceRef: models.Ref{
+						Branch: "feature/teamcity",
+						Sha:    "y2nc0ns6qk8qs4dfbsvnjrf2o8dvmrfrthmx9nbl",
+					},
+					TargetRef: models.Ref{
+						Branch: "main",
+					},
+				},
+				Builder:       "TeamCity",
+				PipelinePaths: []string{"/tmp/teamcity/repo/.teamcity/settings.kts"},
+				Environment:   enums.TeamCity,
+				ScmId:         "8891c0db39f3064732cc1b4ac02c9b9f",
+			},
+		},
+		{
+			name:         "Missing build properties file",
+			envsFilePath: teamcityMissingConfigEnvsFilePath,
+			wantErr:      true,
+		},
+		{
+			name:         "Not TeamCity environment",
+			envsFilePath: "",
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := prepareTest(t, tt.envsFilePath)
+			got, err := e.GetConfiguration()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("environment.GetConfiguration() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_environment_GetBuildLink(t *testing.T) {
+	tests := []struct {
+		name         string
+		envsFilePath string
+		want         string
+	}{
+		{
+			name:         "TeamCity environment",
+			envsFilePath: teamcityMainEnvsFilePath,
+			want:         "https://teamcity.example.com/buildConfiguration/TestProject_Build/1234",
+		},
+		{
+			name:         "TeamCity server url with trailing slash",
+			envsFilePath: teamcityPrEnvsFilePath,
+			want:         "https://teamcity.example.com/buildConfiguration/TestProject_PullRequests/1235",
+		},
+		{
+			name:         "Not TeamCity environment",
+			envsFilePath: "",
+			want:         "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := prepareTest(t, tt.envsFilePath)
+			if got := e.GetBuildLink(); got != tt.want {
+				t.Errorf("environment.GetBuildLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_environment_IsCurrentEnvironment(t *testing.T) {
+	tests := []struct {
+		name         string
+		envsFilePath string
+		want         bool
+	}{
+		{
+			name:         "TeamCity environment",
+			envsFilePath: teamcityMainEnvsFilePath,
+			want:         true,
+		},
+		{
+			name:         "Not TeamCity environment",
+			envsFilePath: "",
+			want:         false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := prepareTest(t, tt.envsFilePath)
+			if got := e.IsCurrentEnvironment(); got != tt.want {
+				t.Errorf("environment.IsCurrentEnvironment() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseProperties(t *testing.T) {
+	content := []byte(`# comment
+! another comment
+build.number=42
+teamcity.serverUrl=https\://teamcity.example.com
+agent.home.dir=C\:\\BuildAgent
+key\ with\ spaces = value
+colon.separated: value
+space.separated value
+empty.value=
+multi.line=first, \
+    second
+unicode=caf\u00e9
+`)
+	assert.Equal(t, map[string]string{
+		"build.number":       "42",
+		"teamcity.serverUrl": "https://teamcity.example.com",
+		"agent.home.dir":     `C:\BuildAgent`,
+		"key with spaces":    "value",
+		"colon.separated":    "value",
+		"space.separated":    "value",
+		"empty.value":        "",
+		"multi.line":         "first, second",
+		"unicode":            "café",
+	}, parseProperties(content))
+}
+
+func Test_getPipelinePath(t *testing.T) {
+	assert.Equal(t, ".teamcity/settings.kts", getPipelinePath(testRepoPath, []string{
+		"/tmp/teamcity/repo/.teamcity/pom.kts",
+		"/tmp/teamcity/repo/.teamcity/settings.kts",
+	}))
+	assert.Equal(t, "", getPipelinePath(testRepoPath, []string{"/tmp/teamcity/repo/.teamcity/pom.kts"}))
+	assert.Equal(t, "", getPipelinePath(testRepoPath, []string{}))
+}
+
+func prepareTest(t *testing.T, envsFilePath string) *Environment {
+	e := New()
+	testRepoCleanup := testutils.PrepareTestGitRepository(testRepoPath, testRepoCloneUrl, testdataPath)
+	t.Cleanup(testRepoCleanup)
+	envCleanup := testutils.SetEnvsFromFile(envsFilePath)
+	t.Cleanup(envCleanup)
+	return e
+}
@@ -0,0 +1,107 @@
+package teamcity
+
+import (
+	"bufio"
+	"bytes"
+	"strconv"
+	"strings"
+)
+
+// parseProperties parses the content of a Java properties file, i.e. the build properties files of TeamCity.
+// Keys and values are unescaped, so Windows paths like C\:\\BuildAgent\\work are returned as C:\BuildAgent\work
+func parseProperties(content []byte) map[string]string {
+	properties := map[string]string{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	logicalLine := ""
+	for scanner.Scan() {
+		line := strings.TrimLeft(scanner.Text(), " \t\f")
+		if logicalLine == "" && (line == "" || line[0] == '#' || line[0] == '!') {
+			continue
+		}
+
+		if isContinued(line) {
+			logicalLine += line[:len(line)-1]
+			continue
+		}
+		logicalLine += line
+
+		key, value := splitProperty(logicalLine)
+		properties[unescapeProperty(key)] = unescapeProperty(value)
+		logicalLine = ""
+	}
+	if logicalLine != "" {
+		key, value := splitProperty(logicalLine)
+		properties[unescapeProperty(key)] = unescapeProperty(value)
+	}
+
+	return properties
+}
+
+// isContinued checks if a line ends with an odd number of backslashes, which continues it on the next line
+func isContinued(line string) bool {
+	backslashes := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		backslashes++
+	}
+	return backslashes%2 == 1
+}
+
+// splitProperty splits a line on the first unescaped separator - '=', ':' or whitespace
+func splitProperty(line string) (string, string) {
+	keyEnd := len(line)
+	for i := 0; i < len(line); i++ {
+		if line[i] == '\\' {
+			i++
+			continue
+		}
+		if strings.ContainsRune("=: \t\f", rune(line[i])) {
+			keyEnd = i
+			break
+		}
+	}
+
+	value := strings.TrimLeft(line[keyEnd:], " \t\f")
+	if value != "" && (value[0] == '=' || value[0] == ':') {
+		value = strings.TrimLeft(value[1:], " \t\f")
+	}
+	return line[:keyEnd], value
+}
+
+func unescapeProperty(value string) string {
+	if !strings.Contains(value, "\\") {
+		return value
+	}
+
+	var builder strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i == len(value)-1 {
+			builder.WriteByte(value[i])
+			continue
+		}
+
+		i++
+		switch value[i] {
+		case 't':
+			builder.WriteByte('\t')
+		case 'n':
+			builder.WriteByte('\n')
+		case 'r':
+			builder.WriteByte('\r')
+		case 'f':
+			builder.WriteByte('\f')
+		case 'u':
+			if i+4 < len(value) {
+				if code, err := strconv.ParseUint(value[i+1:i+5], 16, 32); err == nil {
+					builder.WriteRune(rune(code))
+					i += 4
+					continue
+				}
+			}
+			builder.WriteByte('u')
+		default:
+			builder.WriteByte(value[i])
+		}
+	}
+	return builder.String()
+}
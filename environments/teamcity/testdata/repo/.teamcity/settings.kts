@@ -0,0 +1,7 @@
+import jetbrains.buildServer.configs.kotlin.*
+
+version = "2023.05"
+
+project {
+    buildType(Build)
+}
@@ -0,0 +1,325 @@
+package tekton
+
+import (
+	"io/fs"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/utils"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/models"
+)
+
+const (
+	builder = "Tekton"
+
+	// the step containers of a TaskRun pod have the /tekton directory mounted,
+	// the downward volume holds the ready annotation that starts the first step
+	tektonDownwardDir = "/tekton/downward"
+	tektonRunDir      = "/tekton/run"
+	workspaceDir      = "/workspace"
+
+	hostnameEnv = "HOSTNAME"
+
+	// labels that Tekton adds to the TaskRun pods,
+	// the labels and annotations of the PipelineRun and TaskRun are propagated to the pods too
+	pipelineRunLabel  = "tekton.dev/pipelineRun"
+	pipelineLabel     = "tekton.dev/pipeline"
+	pipelineTaskLabel = "tekton.dev/pipelineTask"
+	taskRunLabel      = "tekton.dev/taskRun"
+	taskLabel         = "tekton.dev/task"
+
+	// labels and annotations that Pipelines as Code adds to the PipelineRuns it starts from git events
+	repoUrlAnnotation      = "pipelinesascode.tekton.dev/repo-url"
+	shaAnnotation          = "pipelinesascode.tekton.dev/sha"
+	branchAnnotation       = "pipelinesascode.tekton.dev/branch"
+	sourceBranchAnnotation = "pipelinesascode.tekton.dev/source-branch"
+	senderAnnotation       = "pipelinesascode.tekton.dev/sender"
+	pullRequestAnnotation  = "pipelinesascode.tekton.dev/pull-request"
+	logUrlAnnotation       = "pipelinesascode.tekton.dev/log-url"
+
+	pipelinesDir = ".tekton"
+)
+
+var (
+	// Tekton environment
+	Tekton = New()
+)
+
+// Environment is the Tekton environment
+type Environment struct {
+	cache utils.ConfigurationCache
+	root  string
+}
+
+// New creates a Tekton environment with its own configuration cache
+func New() *Environment {
+	return &Environment{}
+}
+
+// WithRoot sets the directory that the pod files, i.e. /tekton and /etc/podinfo, are read from instead of "/"
+func (e *Environment) WithRoot(root string) *Environment {
+	e.root = root
+	return e
+}
+
+func (e *Environment) GetConfiguration() (*models.Configuration, error) {
+	return e.cache.Get(e.load)
+}
+
+// Refresh loads the configuration again and replaces the cached configuration
+func (e *Environment) Refresh() (*models.Configuration, error) {
+	return e.cache.Refresh(e.load)
+}
+
+// Reset clears the cached configuration, it is loaded again on the next GetConfiguration
+func (e *Environment) Reset() {
+	e.cache.Reset()
+}
+
+func (e *Environment) load() (*models.Configuration, error) {
+	return loadConfiguration(envsource.OSWithRoot(e.root))
+}
+
+func (e *Environment) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return loadConfiguration(src)
+}
+
+func loadConfiguration(src envsource.EnvSource) (*models.Configuration, error) {
+	metadata := getPodMetadata(src)
+
+	// git-clone checks out to the root of its workspace by default, the workspaces are mounted under /workspace
+	repoPath := envsource.FindRepository(src, workspaceDir)
+	cloneUrl := metadata[repoUrlAnnotation]
+	if cloneUrl == "" {
+		var err error
+		if cloneUrl, err = envsource.GitClient(src).GetGitRemoteURL(envsource.Path(src, repoPath)); err != nil {
+			return nil, err
+		}
+	}
+	cloneUrl = utils.StripCredentialsFromUrl(cloneUrl)
+
+	source, apiUrl := utils.GetRepositorySource(cloneUrl)
+	repoUrl, org, repoName, repoFullName, err := utils.ParseDataFromCloneUrl(cloneUrl, apiUrl, source)
+	if err != nil {
+		return nil, err
+	}
+
+	commit := metadata[shaAnnotation]
+	if commit == "" && repoPath != "" {
+		if commit, err = envsource.GitClient(src).GetGitCommit(envsource.Path(src, repoPath)); err != nil {
+			return nil, err
+		}
+	}
+
+	var warnings []models.Warning
+	branch := getBranch(metadata)
+	if branch == "" && repoPath != "" {
+		branch, warnings, _ = envsource.GetGitBranch(src, repoPath, commit)
+	}
+
+	pullRequest := models.PullRequest{}
+	if prNumber := metadata[pullRequestAnnotation]; prNumber != "" {
+		pullRequest = models.PullRequest{
+			Id: prNumber,
+			SourceRef: models.Ref{
+				Branch: branch,
+				Sha:    commit,
+			},
+			TargetRef: models.Ref{
+				Branch: metadata[branchAnnotation],
+			},
+		}
+	}
+
+	pipelinePaths, pipelinesWarnings := getPipelinePaths(src, repoPath)
+	warnings = append(warnings, pipelinesWarnings...)
+	return &models.Configuration{
+		SCMApiUrl: apiUrl,
+		LocalPath: repoPath,
+		CommitSha: commit,
+		Branch:    branch,
+		Namespace: utils.GetPodNamespace(src, utils.PodInfoDir),
+		Repository: models.Repository{
+			Name:     repoName,
+			FullName: repoFullName,
+			Url:      repoUrl,
+			CloneUrl: cloneUrl,
+			Source:   source,
+		},
+		Organization: models.Entity{
+			Name: org,
+		},
+		Pipeline: models.Pipeline{
+			Entity: models.Entity{
+				Id:   getPipelineName(metadata),
+				Name: getPipelineName(metadata),
+			},
+		},
+		Job: models.Entity{
+			Id:   metadata[taskRunLabel],
+			Name: getTaskName(metadata),
+		},
+		Run: models.BuildRun{
+			BuildId: getRunName(metadata),
+		},
+		Runner: models.Runner{
+			Name:         src.Getenv(hostnameEnv),
+			OS:           runtime.GOOS,
+			Architecture: runtime.GOARCH,
+		},
+		PullRequest: pullRequest,
+		Builder:     builder,
+		Pusher: models.Pusher{
+			Username: getPusher(src, metadata),
+		},
+		PipelinePaths: pipelinePaths,
+		Environment:   enums.Tekton,
+		ScmId:         utils.GenerateScmId(cloneUrl),
+		Warnings:      warnings,
+	}, nil
+}
+
+// getPodMetadata returns the labels and annotations of the pod from the downward API volume,
+// annotations take precedence over labels with the same key
+func getPodMetadata(src envsource.EnvSource) map[string]string {
+	metadata := utils.ReadPodLabels(src, utils.PodInfoDir)
+	for key, value := range utils.ReadPodAnnotations(src, utils.PodInfoDir) {
+		metadata[key] = value
+	}
+	return metadata
+}
+
+// getBranch returns the source branch of pull request events and the pushed branch of push events
+func getBranch(metadata map[string]string) string {
+	if branch := metadata[sourceBranchAnnotation]; branch != "" {
+		return strings.TrimPrefix(branch, "refs/heads/")
+	}
+	return strings.TrimPrefix(metadata[branchAnnotation], "refs/heads/")
+}
+
+// getPipelineName returns the pipeline of the PipelineRun, or the task of a TaskRun that is not part of a pipeline
+func getPipelineName(metadata map[string]string) string {
+	if pipeline := metadata[pipelineLabel]; pipeline != "" {
+		return pipeline
+	}
+	return metadata[taskLabel]
+}
+
+// getRunName returns the PipelineRun, or the TaskRun that is not part of a pipeline
+func getRunName(metadata map[string]string) string {
+	if pipelineRun := metadata[pipelineRunLabel]; pipelineRun != "" {
+		return pipelineRun
+	}
+	return metadata[taskRunLabel]
+}
+
+func getTaskName(metadata map[string]string) string {
+	if pipelineTask := metadata[pipelineTaskLabel]; pipelineTask != "" {
+		return pipelineTask
+	}
+	return metadata[taskLabel]
+}
+
+func getPusher(src envsource.EnvSource, metadata map[string]string) string {
+	if sender := metadata[senderAnnotation]; sender != "" {
+		return sender
+	}
+	return utils.DetectPusherFrom(src)
+}
+
+func (e *Environment) source() envsource.EnvSource {
+	return envsource.OSWithRoot(e.root)
+}
+
+// GetStepLink returns the log link of Pipelines as Code runs, plain Tekton runs have no link
+func (e *Environment) GetStepLink() string {
+	return e.GetBuildLink()
+}
+
+// GetBuildLink returns the log link of Pipelines as Code runs, plain Tekton runs have no link
+func (e *Environment) GetBuildLink() string {
+	return getPodMetadata(e.source())[logUrlAnnotation]
+}
+
+func (e *Environment) GetFileLink(filename string, branch string, commit string) string {
+	return ""
+}
+
+func (e *Environment) GetFileLineLink(filename string, branch string, commit string, startLine int, endLine int) string {
+	return ""
+}
+
+// IsCurrentEnvironment checks for the directories that Tekton mounts into the step containers,
+// Tekton sets no variables of its own
+func (e *Environment) IsCurrentEnvironment() bool {
+	src := e.source()
+	for _, dir := range []string{tektonDownwardDir, tektonRunDir} {
+		if _, err := envsource.Stat(src, dir); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// DetectionVariables returns the variables used by IsCurrentEnvironment, Tekton is detected by its files
+func (e *Environment) DetectionVariables() []string {
+	return []string{}
+}
+
+// ExpectedFields returns the configuration fields the environment is expected to fill and the variables they are filled from,
+// the labels and annotations are read from the downward API volume
+func (e *Environment) ExpectedFields() []models.ExpectedField {
+	return []models.ExpectedField{
+		{Field: "repository.url", SourceEnv: repoUrlAnnotation, Severity: models.SeverityCritical},
+		{Field: "repository.cloneUrl", SourceEnv: repoUrlAnnotation, Severity: models.SeverityCritical},
+		{Field: "commitSha", SourceEnv: shaAnnotation, Severity: models.SeverityCritical},
+		{Field: "branch", SourceEnv: branchAnnotation, Severity: models.SeverityWarning},
+		{Field: "localPath", SourceEnv: workspaceDir, Severity: models.SeverityWarning},
+		{Field: "namespace", SourceEnv: utils.PodInfoDir, Severity: models.SeverityWarning},
+		{Field: "pipeline.name", SourceEnv: pipelineLabel, Severity: models.SeverityWarning},
+		{Field: "job.name", SourceEnv: pipelineTaskLabel, Severity: models.SeverityWarning},
+		{Field: "run.buildId", SourceEnv: pipelineRunLabel, Severity: models.SeverityWarning},
+	}
+}
+
+func (e *Environment) Name() string {
+	return "tekton"
+}
+
+// getPipelinePaths returns the PipelineRun files of the .tekton directory that Pipelines as Code reads
+func getPipelinePaths(src envsource.EnvSource, rootDir string) ([]string, []models.Warning) {
+	paths := make([]string, 0)
+	var warnings []models.Warning
+	if rootDir == "" {
+		return paths, warnings
+	}
+
+	dir := filepath.Join(rootDir, pipelinesDir)
+	if _, err := envsource.Stat(src, dir); err != nil {
+		return paths, warnings
+	}
+	err := envsource.Walk(src, dir, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			warnings = append(warnings, models.NewWarning(models.PipelinePathsWarning, err, "failed to search %s for pipeline files", path))
+			return nil
+		}
+		if info.IsDir() {
+			if path != dir {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) == ".yml" || filepath.Ext(path) == ".yaml" {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		warnings = append(warnings, models.NewWarning(models.PipelinePathsWarning, err, "failed to search %s for pipeline files", dir))
+	}
+
+	return paths, warnings
+}
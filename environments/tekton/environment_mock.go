@@ -0,0 +1,105 @@
+package tekton
+
+import (
+	"fmt"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/models"
+)
+
+var (
+	MockOrgName     = "test-org"
+	MockRepoName    = "test-repo"
+	MockNamespace   = "ci"
+	MockPipelineRun = "test-repo-on-pull-request-x7k2p"
+	MockLogUrl      = "https://console.example.com/k8s/ns/ci/tekton.dev~v1~PipelineRun/test-repo-on-pull-request-x7k2p"
+)
+
+var mockConfiguration *models.Configuration
+
+type EnvironmentMock struct{}
+
+func (em *EnvironmentMock) GetConfiguration() (*models.Configuration, error) {
+	if mockConfiguration == nil {
+		if err := loadMockConfiguration(); err != nil {
+			return nil, err
+		}
+	}
+	return mockConfiguration, nil
+}
+
+func (em *EnvironmentMock) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return em.GetConfiguration()
+}
+
+func (em *EnvironmentMock) Refresh() (*models.Configuration, error) {
+	em.Reset()
+	return em.GetConfiguration()
+}
+
+func (em *EnvironmentMock) Reset() {
+	mockConfiguration = nil
+}
+
+func loadMockConfiguration() error {
+	mockConfiguration = &models.Configuration{
+		SCMApiUrl: "https://api.github.com",
+		LocalPath: "/workspace/source",
+		CommitSha: "2c6e3880fd94ddb7ef72d34e683cdc0c47bec6e6",
+		Branch:    "feature/test",
+		Namespace: MockNamespace,
+		Run: models.BuildRun{
+			BuildId: MockPipelineRun,
+		},
+		Job: models.Entity{
+			Id:   fmt.Sprintf("%s-build", MockPipelineRun),
+			Name: "build",
+		},
+		Pipeline: models.Pipeline{
+			Entity: models.Entity{
+				Id:   "test-repo-on-pull-request",
+				Name: "test-repo-on-pull-request",
+			},
+		},
+		Repository: models.Repository{
+			Name:     MockRepoName,
+			FullName: fmt.Sprintf("%s/%s", MockOrgName, MockRepoName),
+			Url:      fmt.Sprintf("https://github.com/%s/%s", MockOrgName, MockRepoName),
+			CloneUrl: fmt.Sprintf("https://github.com/%s/%s", MockOrgName, MockRepoName),
+			Source:   enums.Github,
+		},
+		Builder: "Tekton",
+		Organization: models.Entity{
+			Name: MockOrgName,
+		},
+		PipelinePaths: []string{"/workspace/source/.tekton/pull-request.yaml"},
+		Environment:   enums.Tekton,
+	}
+
+	return nil
+}
+
+func (em *EnvironmentMock) GetBuildLink() string {
+	return MockLogUrl
+}
+
+func (em *EnvironmentMock) GetStepLink() string {
+	return MockLogUrl
+}
+
+func (em *EnvironmentMock) GetFileLink(filename string, branch string, commit string) string {
+	return ""
+}
+
+func (em *EnvironmentMock) GetFileLineLink(filename string, branch string, commit string, startLine int, endLine int) string {
+	return ""
+}
+
+func (em *EnvironmentMock) IsCurrentEnvironment() bool {
+	return true
+}
+
+func (em *EnvironmentMock) Name() string {
+	return "tekton"
+}
@@ -0,0 +1,200 @@
+package tekton
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/testutils"
+	"github.com/argonsecurity/go-environments/environments/testutils/mocks"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/models"
+	"github.com/otiai10/copy"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	pacEnvsFilePath   = "testdata/pac-env.json"
+	plainEnvsFilePath = "testdata/plain-env.json"
+	pacRootPath       = "testdata/pac-root"
+	plainRootPath     = "testdata/plain-root"
+	testRootPath      = "/tmp/tekton/root"
+	testRepoPath      = "/workspace/source"
+	testRepoCloneUrl  = "https://github.com/test-org/test-repo"
+	testdataPath      = "../tekton/testdata/repo"
+	testPipelinePaths = []string{"/workspace/source/.tekton/pull-request.yaml", "/workspace/source/.tekton/push.yml"}
+)
+
+func Test_environment_GetConfiguration(t *testing.T) {
+	e := prepareTest(t, pacRootPath, pacEnvsFilePath)
+	want := &models.Configuration{
+		SCMApiUrl: "https://api.github.com",
+		LocalPath: testRepoPath,
+		CommitSha: "2c6e3880fd94ddb7ef72d34e683cdc0c47bec6e6",
+		Branch:    "feature/test",
+		Namespace: "ci",
+		Repository: models.Repository{
+			Name:     "test-repo",
+			FullName: "test-org/test-repo",
+			Url:      "https://github.com/test-org/test-repo",
+			CloneUrl: testRepoCloneUrl,
+			Source:   enums.Github,
+		},
+		Organization: models.Entity{
+			Name: "test-org",
+		},
+		Pipeline: models.Pipeline{
+			Entity: models.Entity{
+				Id:   "test-repo-on-pull-request",
+				Name: "test-repo-on-pull-request",
+			},
+		},
+		Job: models.Entity{
+			Id:   "test-repo-on-pull-request-x7k2p-build",
+			Name: "build",
+		},
+		Run: models.BuildRun{
+			BuildId: "test-repo-on-pull-request-x7k2p",
+		},
+		Runner: models.Runner{
+			Name:         "test-repo-on-pull-request-x7k2p-build-pod",
+			OS:           runtime.GOOS,
+			Architecture: runtime.GOARCH,
+		},
+		PullRequest: models.PullRequest{
+			Id: "42",
+			SourceRef: models.Ref{
+				Branch: "feature/test",
+				Sha:    "2c6e3880fd94ddb7ef72d34e683cdc0c47bec6e6",
+			},
+			TargetRef: models.Ref{
+				Branch: "main",
+			},
+		},
+		Pusher: models.Pusher{
+			Username: "test-user",
+		},
+		Builder:       "Tekton",
+		PipelinePaths: testPipelinePaths,
+		Environment:   enums.Tekton,
+		ScmId:         "b30f418cdcc9970849d3d031de5df54f",
+	}
+
+	got, err := e.GetConfiguration()
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func Test_environment_GetConfigurationFrom(t *testing.T) {
+	prepareTest(t, plainRootPath, "")
+	gitClient := (&mocks.MockGitClient{}).
+		SetRemoteUrl("https://gitlab.com/test-group/test-repo.git").
+		SetCommit("fe8c38a965d13d9794eb36918cb24cebe49a45c2").
+		SetBranch("main")
+	src := envsource.New(testutils.LoadEnvsFromFile(plainEnvsFilePath)).WithRoot(testRootPath).WithGitClient(gitClient)
+
+	want := &models.Configuration{
+		SCMApiUrl: "https://gitlab.com/api/v4",
+		LocalPath: testRepoPath,
+		CommitSha: "fe8c38a965d13d9794eb36918cb24cebe49a45c2",
+		Branch:    "main",
+		Namespace: "builds",
+		Repository: models.Repository{
+			Name:     "test-repo",
+			FullName: "test-group/test-repo",
+			Url:      "https://gitlab.com/test-group/test-repo",
+			CloneUrl: "https://gitlab.com/test-group/test-repo.git",
+			Source:   enums.Gitlab,
+		},
+		Organization: models.Entity{
+			Name: "test-group",
+		},
+		Pipeline: models.Pipeline{
+			Entity: models.Entity{
+				Id:   "build",
+				Name: "build",
+			},
+		},
+		Job: models.Entity{
+			Id:   "build-run-abc12-compile",
+			Name: "compile",
+		},
+		Run: models.BuildRun{
+			BuildId: "build-run-abc12",
+		},
+		Runner: models.Runner{
+			Name:         "build-run-abc12-build-pod",
+			OS:           runtime.GOOS,
+			Architecture: runtime.GOARCH,
+		},
+		Builder:       "Tekton",
+		PipelinePaths: testPipelinePaths,
+		Environment:   enums.Tekton,
+		ScmId:         "5992c2ba95a922426aae55f34afec4ed",
+	}
+
+	got, err := New().GetConfigurationFrom(src)
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func Test_environment_GetBuildLink(t *testing.T) {
+	e := prepareTest(t, pacRootPath, pacEnvsFilePath)
+	want := "https://console.example.com/k8s/ns/ci/tekton.dev~v1~PipelineRun/test-repo-on-pull-request-x7k2p"
+	assert.Equal(t, want, e.GetBuildLink())
+	assert.Equal(t, want, e.GetStepLink())
+
+	e = prepareTest(t, plainRootPath, plainEnvsFilePath)
+	assert.Equal(t, "", e.GetBuildLink())
+}
+
+func Test_environment_IsCurrentEnvironment(t *testing.T) {
+	tests := []struct {
+		name     string
+		rootPath string
+		want     bool
+	}{
+		{
+			name:     "Tekton downward volume",
+			rootPath: pacRootPath,
+			want:     true,
+		},
+		{
+			name:     "Tekton run volume",
+			rootPath: plainRootPath,
+			want:     true,
+		},
+		{
+			name:     "Not Tekton environment",
+			rootPath: "",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := prepareTest(t, tt.rootPath, "")
+			if got := e.IsCurrentEnvironment(); got != tt.want {
+				t.Errorf("environment.IsCurrentEnvironment() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// prepareTest lays out the files of a step container under testRootPath, with the cloned repository in a workspace
+func prepareTest(t *testing.T, rootPath string, envsFilePath string) *Environment {
+	e := New().WithRoot(testRootPath)
+	os.RemoveAll(testRootPath)
+	if rootPath != "" {
+		if err := copy.Copy(rootPath, testRootPath); err != nil {
+			t.Fatal(err)
+		}
+	}
+	testRepoCleanup := testutils.PrepareTestGitRepository(filepath.Join(testRootPath, testRepoPath), testRepoCloneUrl, testdataPath)
+	t.Cleanup(testRepoCleanup)
+	t.Cleanup(func() { os.RemoveAll(testRootPath) })
+	envCleanup := testutils.SetEnvsFromFile(envsFilePath)
+	t.Cleanup(envCleanup)
+	return e
+}
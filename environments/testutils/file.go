@@ -19,6 +19,20 @@ func SetEnvsFromFile(filepath string) (cleanup func()) {
 	return envSetter(envs)
 }
 
+// LoadEnvsFromFile loads the variables of an envs file without setting them in the process environment
+func LoadEnvsFromFile(filepath string) map[string]string {
+	envs, _, err := loadJsonFromFile[map[string]any](filepath)
+	if err != nil {
+		panic(err)
+	}
+
+	result := make(map[string]string, len(envs))
+	for name, value := range envs {
+		result[name] = fmt.Sprint(value)
+	}
+	return result
+}
+
 func loadJsonFromFile[T any](filePath string) (T, []byte, error) {
 	var t T
 	data, err := os.ReadFile(filePath)
@@ -0,0 +1,377 @@
+package travis
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/bitbucket"
+	"github.com/argonsecurity/go-environments/environments/github"
+	"github.com/argonsecurity/go-environments/environments/gitlab"
+	"github.com/argonsecurity/go-environments/environments/utils"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/models"
+)
+
+const (
+	builder   = "Travis CI"
+	travisEnv = "TRAVIS"
+
+	repositorySlugEnv = "TRAVIS_REPO_SLUG"
+	repositoryPathEnv = "TRAVIS_BUILD_DIR"
+
+	buildIdEnv     = "TRAVIS_BUILD_ID"
+	buildNumberEnv = "TRAVIS_BUILD_NUMBER"
+	buildUrlEnv    = "TRAVIS_BUILD_WEB_URL"
+
+	jobIdEnv     = "TRAVIS_JOB_ID"
+	jobNameEnv   = "TRAVIS_JOB_NAME"
+	jobNumberEnv = "TRAVIS_JOB_NUMBER"
+	jobUrlEnv    = "TRAVIS_JOB_WEB_URL"
+
+	branchEnv      = "TRAVIS_BRANCH"
+	commitShaEnv   = "TRAVIS_COMMIT"
+	commitRangeEnv = "TRAVIS_COMMIT_RANGE"
+
+	pullRequestEnv       = "TRAVIS_PULL_REQUEST"
+	pullRequestBranchEnv = "TRAVIS_PULL_REQUEST_BRANCH"
+	pullRequestShaEnv    = "TRAVIS_PULL_REQUEST_SHA"
+
+	osNameEnv  = "TRAVIS_OS_NAME"
+	cpuArchEnv = "TRAVIS_CPU_ARCH"
+	distEnv    = "TRAVIS_DIST"
+
+	noPullRequest = "false"
+
+	travisPipelineFile = ".travis.yml"
+)
+
+var (
+	travisUrl = "https://app.travis-ci.com"
+	githubUrl = "https://github.com"
+)
+
+var (
+	// Travis environment
+	Travis = New()
+)
+
+// Environment is the Travis CI environment
+type Environment struct {
+	cache utils.ConfigurationCache
+}
+
+// New creates a Travis CI environment with its own configuration cache
+func New() *Environment {
+	return &Environment{}
+}
+
+func (e *Environment) GetConfiguration() (*models.Configuration, error) {
+	return e.cache.Get(e.load)
+}
+
+// Refresh loads the configuration again and replaces the cached configuration
+func (e *Environment) Refresh() (*models.Configuration, error) {
+	return e.cache.Refresh(e.load)
+}
+
+// Reset clears the cached configuration, it is loaded again on the next GetConfiguration
+func (e *Environment) Reset() {
+	e.cache.Reset()
+}
+
+func (e *Environment) load() (*models.Configuration, error) {
+	return loadConfiguration(envsource.OS)
+}
+
+func (e *Environment) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return loadConfiguration(src)
+}
+
+func loadConfiguration(src envsource.EnvSource) (*models.Configuration, error) {
+	slug := src.Getenv(repositorySlugEnv)
+	org, repoName, found := strings.Cut(slug, "/")
+	if !found {
+		return nil, fmt.Errorf("%s is not a repository slug", slug)
+	}
+
+	// Travis CI builds GitHub repositories unless the remote of the checkout is another SCM
+	repoPath := src.Getenv(repositoryPathEnv)
+	repoUrl := fmt.Sprintf("%s/%s", githubUrl, slug)
+	cloneUrl, err := envsource.GitClient(src).GetGitRemoteURL(envsource.Path(src, repoPath))
+
+	var warnings []models.Warning
+	if err != nil {
+		warnings = append(warnings, models.NewWarning(models.GitRemoteUrlWarning, err, "failed to get the git remote url of %s, using %s", repoPath, repoUrl))
+	}
+	if err != nil || cloneUrl == "" {
+		cloneUrl = fmt.Sprintf("%s.git", repoUrl)
+	}
+	strippedCloneUrl := utils.StripCredentialsFromUrl(cloneUrl)
+
+	source, apiUrl := utils.GetRepositorySource(strippedCloneUrl)
+	if parsedRepoUrl, parsedOrg, parsedRepoName, _, err := utils.ParseDataFromCloneUrl(strippedCloneUrl, apiUrl, source); err == nil {
+		repoUrl, org, repoName = parsedRepoUrl, parsedOrg, parsedRepoName
+	}
+
+	pullRequest := models.PullRequest{}
+	branch := src.Getenv(branchEnv)
+	if prNumber := src.Getenv(pullRequestEnv); prNumber != "" && prNumber != noPullRequest {
+		// on pull request builds TRAVIS_BRANCH is the target branch of the pull request
+		pullRequest = models.PullRequest{
+			Id:  prNumber,
+			Url: getPullRequestUrl(source, repoUrl, prNumber),
+			SourceRef: models.Ref{
+				Branch: src.Getenv(pullRequestBranchEnv),
+				Sha:    src.Getenv(pullRequestShaEnv),
+			},
+			TargetRef: models.Ref{
+				Branch: branch,
+			},
+		}
+		branch = src.Getenv(pullRequestBranchEnv)
+	}
+
+	return &models.Configuration{
+		Url:             getTravisUrl(src.Getenv(buildUrlEnv)),
+		SCMApiUrl:       apiUrl,
+		LocalPath:       repoPath,
+		CommitSha:       src.Getenv(commitShaEnv),
+		BeforeCommitSha: getBeforeCommitSha(src.Getenv(commitRangeEnv)),
+		Branch:          branch,
+		Repository: models.Repository{
+			Name:     repoName,
+			FullName: slug,
+			Url:      repoUrl,
+			CloneUrl: strippedCloneUrl,
+			Source:   source,
+		},
+		Organization: models.Entity{
+			Name: org,
+		},
+		Pipeline: models.Pipeline{
+			Entity: models.Entity{
+				Id:   slug,
+				Name: repoName,
+			},
+			Path: getPipelinePath(src, repoPath),
+		},
+		Job: models.Entity{
+			Id:   src.Getenv(jobIdEnv),
+			Name: getJobName(src),
+		},
+		Run: models.BuildRun{
+			BuildId:     src.Getenv(buildIdEnv),
+			BuildNumber: src.Getenv(buildNumberEnv),
+		},
+		Runner: models.Runner{
+			OS:           src.Getenv(osNameEnv),
+			Distribution: src.Getenv(distEnv),
+			Architecture: src.Getenv(cpuArchEnv),
+		},
+		PullRequest: pullRequest,
+		Builder:     builder,
+		Pusher: models.Pusher{
+			Username: utils.DetectPusherFrom(src),
+		},
+		PipelinePaths: getPipelinePaths(src, repoPath),
+		Environment:   enums.Travis,
+		ScmId:         utils.GenerateScmId(strippedCloneUrl),
+		Warnings:      warnings,
+	}, nil
+}
+
+// getPullRequestUrl returns the link to a pull request on the SCMs that Travis CI builds
+func getPullRequestUrl(source enums.Source, repoUrl string, prNumber string) string {
+	switch source {
+	case enums.Github, enums.GithubServer:
+		return github.GetPullRequestLink(repoUrl, prNumber)
+	case enums.Gitlab, enums.GitlabServer:
+		return gitlab.GetPullRequestLink(repoUrl, prNumber)
+	case enums.Bitbucket:
+		return bitbucket.GetPullRequestLink(repoUrl, prNumber)
+	}
+	return ""
+}
+
+// getTravisUrl returns the Travis CI server of the build, travis-ci.com or travis-ci.org
+func getTravisUrl(buildUrl string) string {
+	parsedUrl, err := url.Parse(buildUrl)
+	if err != nil || parsedUrl.Host == "" {
+		return travisUrl
+	}
+	return fmt.Sprintf("%s://%s", parsedUrl.Scheme, parsedUrl.Host)
+}
+
+// getBeforeCommitSha returns the first commit of a commit range, i.e. 1a2b3c...4d5e6f
+func getBeforeCommitSha(commitRange string) string {
+	if before, _, found := strings.Cut(commitRange, "..."); found {
+		return before
+	}
+	before, _, _ := strings.Cut(commitRange, "..")
+	return before
+}
+
+func getJobName(src envsource.EnvSource) string {
+	if name := src.Getenv(jobNameEnv); name != "" {
+		return name
+	}
+	return src.Getenv(jobNumberEnv)
+}
+
+func (e *Environment) GetStepLink() string {
+	if jobUrl := os.Getenv(jobUrlEnv); jobUrl != "" {
+		return jobUrl
+	}
+	source, _ := e.getRepository()
+	return fmt.Sprintf("%s/%s/%s/jobs/%s", travisUrl, getTravisVcs(source), os.Getenv(repositorySlugEnv), os.Getenv(jobIdEnv))
+}
+
+func (e *Environment) GetBuildLink() string {
+	if buildUrl := os.Getenv(buildUrlEnv); buildUrl != "" {
+		return buildUrl
+	}
+	source, _ := e.getRepository()
+	return fmt.Sprintf("%s/%s/%s/builds/%s", travisUrl, getTravisVcs(source), os.Getenv(repositorySlugEnv), os.Getenv(buildIdEnv))
+}
+
+func (e *Environment) GetFileLink(filename string, branch string, commit string) string {
+	source, repoUrl := e.getRepository()
+	switch source {
+	case enums.Github, enums.GithubServer:
+		return github.GetFileLink(repoUrl, filename, branch, commit)
+	case enums.Gitlab, enums.GitlabServer:
+		return gitlab.GetFileLink(repoUrl, filename, branch, commit)
+	case enums.Bitbucket:
+		return bitbucket.GetFileLink(repoUrl, filename, branch, commit)
+	}
+	return ""
+}
+
+func (e *Environment) GetFileLineLink(filename string, branch string, commit string, startLine int, endLine int) string {
+	source, repoUrl := e.getRepository()
+	switch source {
+	case enums.Github, enums.GithubServer:
+		return github.GetFileLineLink(repoUrl, filename, branch, commit, startLine, endLine)
+	case enums.Gitlab, enums.GitlabServer:
+		return gitlab.GetFileLineLink(repoUrl, filename, branch, commit, startLine, endLine)
+	case enums.Bitbucket:
+		return bitbucket.GetFileLineLink(repoUrl, filename, branch, commit, startLine, endLine)
+	}
+	return ""
+}
+
+func (e *Environment) GetCommitLink(commit string) string {
+	source, repoUrl := e.getRepository()
+	switch source {
+	case enums.Github, enums.GithubServer:
+		return github.GetCommitLink(repoUrl, commit)
+	case enums.Gitlab, enums.GitlabServer:
+		return gitlab.GetCommitLink(repoUrl, commit)
+	case enums.Bitbucket:
+		return bitbucket.GetCommitLink(repoUrl, commit)
+	}
+	return ""
+}
+
+func (e *Environment) GetCompareLink(baseCommit string, headCommit string) string {
+	source, repoUrl := e.getRepository()
+	switch source {
+	case enums.Github, enums.GithubServer:
+		return github.GetCompareLink(repoUrl, baseCommit, headCommit)
+	case enums.Gitlab, enums.GitlabServer:
+		return gitlab.GetCompareLink(repoUrl, baseCommit, headCommit)
+	case enums.Bitbucket:
+		return bitbucket.GetCompareLink(repoUrl, baseCommit, headCommit)
+	}
+	return ""
+}
+
+func (e *Environment) GetPullRequestLink(pullRequestId string) string {
+	source, repoUrl := e.getRepository()
+	return getPullRequestUrl(source, repoUrl, pullRequestId)
+}
+
+func (e *Environment) GetRefLink(ref string) string {
+	source, repoUrl := e.getRepository()
+	switch source {
+	case enums.Github, enums.GithubServer:
+		return github.GetRefLink(repoUrl, ref)
+	case enums.Gitlab, enums.GitlabServer:
+		return gitlab.GetRefLink(repoUrl, ref)
+	case enums.Bitbucket:
+		return bitbucket.GetRefLink(repoUrl, ref)
+	}
+	return ""
+}
+
+func (e *Environment) IsCurrentEnvironment() bool {
+	_, isExist := os.LookupEnv(travisEnv)
+	return isExist
+}
+
+// DetectionVariables returns the variables used by IsCurrentEnvironment
+func (e *Environment) DetectionVariables() []string {
+	return []string{travisEnv}
+}
+
+// ExpectedFields returns the configuration fields the environment is expected to fill and the variables they are filled from
+func (e *Environment) ExpectedFields() []models.ExpectedField {
+	return []models.ExpectedField{
+		{Field: "repository.url", SourceEnv: repositorySlugEnv, Severity: models.SeverityCritical},
+		{Field: "repository.cloneUrl", SourceEnv: repositorySlugEnv, Severity: models.SeverityCritical},
+		{Field: "commitSha", SourceEnv: commitShaEnv, Severity: models.SeverityCritical},
+		{Field: "branch", SourceEnv: branchEnv, Severity: models.SeverityWarning},
+		{Field: "localPath", SourceEnv: repositoryPathEnv, Severity: models.SeverityWarning},
+		{Field: "job.id", SourceEnv: jobIdEnv, Severity: models.SeverityWarning},
+		{Field: "run.buildId", SourceEnv: buildIdEnv, Severity: models.SeverityWarning},
+		{Field: "run.buildNumber", SourceEnv: buildNumberEnv, Severity: models.SeverityWarning},
+		{Field: "runner.os", SourceEnv: osNameEnv, Severity: models.SeverityWarning},
+	}
+}
+
+func (e *Environment) Name() string {
+	return "travis"
+}
+
+func getPipelinePaths(src envsource.EnvSource, rootDir string) []string {
+	paths := make([]string, 0)
+
+	path := filepath.Join(rootDir, travisPipelineFile)
+	if _, err := envsource.Stat(src, path); err == nil {
+		paths = append(paths, path)
+	}
+
+	return paths
+}
+
+// getPipelinePath returns the path of .travis.yml relative to the repository, or an empty string when the repository has none
+func getPipelinePath(src envsource.EnvSource, rootDir string) string {
+	if _, err := envsource.Stat(src, filepath.Join(rootDir, travisPipelineFile)); err != nil {
+		return ""
+	}
+	return travisPipelineFile
+}
+
+// getRepository returns the source and the url of the built repository from the configuration,
+// or the repository of the slug on GitHub when the configuration can't be loaded
+func (e *Environment) getRepository() (enums.Source, string) {
+	if configuration, err := e.GetConfiguration(); err == nil {
+		return configuration.Repository.Source, configuration.Repository.Url
+	}
+	return enums.Github, fmt.Sprintf("%s/%s", githubUrl, os.Getenv(repositorySlugEnv))
+}
+
+// getTravisVcs returns the name of the SCM in Travis CI build links
+func getTravisVcs(source enums.Source) string {
+	switch source {
+	case enums.Gitlab, enums.GitlabServer:
+		return "gitlab"
+	case enums.Bitbucket:
+		return "bitbucket"
+	}
+	return "github"
+}
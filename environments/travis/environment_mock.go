@@ -0,0 +1,127 @@
+package travis
+
+import (
+	"fmt"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/models"
+)
+
+var (
+	MockOrgName  = "test-org"
+	MockRepoName = "test-repo"
+	MockBuildId  = "263495913"
+	MockJobId    = "599623140"
+)
+
+var mockConfiguration *models.Configuration
+
+type EnvironmentMock struct{}
+
+func (em *EnvironmentMock) GetConfiguration() (*models.Configuration, error) {
+	if mockConfiguration == nil {
+		if err := loadMockConfiguration(); err != nil {
+			return nil, err
+		}
+	}
+	return mockConfiguration, nil
+}
+
+func (em *EnvironmentMock) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return em.GetConfiguration()
+}
+
+func (em *EnvironmentMock) Refresh() (*models.Configuration, error) {
+	em.Reset()
+	return em.GetConfiguration()
+}
+
+func (em *EnvironmentMock) Reset() {
+	mockConfiguration = nil
+}
+
+func loadMockConfiguration() error {
+	mockConfiguration = &models.Configuration{
+		Url:       "https://app.travis-ci.com",
+		SCMApiUrl: "https://api.github.com",
+		LocalPath: fmt.Sprintf("/home/travis/build/%s/%s", MockOrgName, MockRepoName),
+		CommitSha: "3s32e4s818c6d1s5a0f585sf73112673a9bfcfc7",
+		Branch:    "main",
+		Run: models.BuildRun{
+			BuildId:     MockBuildId,
+			BuildNumber: "12",
+		},
+		Job: models.Entity{
+			Id:   MockJobId,
+			Name: "12.1",
+		},
+		Pipeline: models.Pipeline{
+			Entity: models.Entity{
+				Id:   fmt.Sprintf("%s/%s", MockOrgName, MockRepoName),
+				Name: MockRepoName,
+			},
+			Path: ".travis.yml",
+		},
+		Runner: models.Runner{
+			OS:           "linux",
+			Distribution: "focal",
+			Architecture: "amd64",
+		},
+		Repository: models.Repository{
+			Name:     MockRepoName,
+			FullName: fmt.Sprintf("%s/%s", MockOrgName, MockRepoName),
+			Url:      fmt.Sprintf("https://github.com/%s/%s", MockOrgName, MockRepoName),
+			CloneUrl: fmt.Sprintf("https://github.com/%s/%s.git", MockOrgName, MockRepoName),
+			Source:   enums.Github,
+		},
+		Builder: "Travis CI",
+		Organization: models.Entity{
+			Name: MockOrgName,
+		},
+		PipelinePaths: []string{".travis.yml"},
+		Environment:   enums.Travis,
+	}
+
+	return nil
+}
+
+func (em *EnvironmentMock) GetBuildLink() string {
+	return fmt.Sprintf("https://app.travis-ci.com/github/%s/%s/builds/%s", MockOrgName, MockRepoName, MockBuildId)
+}
+
+func (em *EnvironmentMock) GetStepLink() string {
+	return fmt.Sprintf("https://app.travis-ci.com/github/%s/%s/jobs/%s", MockOrgName, MockRepoName, MockJobId)
+}
+
+func (em *EnvironmentMock) GetFileLink(filename string, branch string, commit string) string {
+	return fmt.Sprintf("https://github.com/%s/%s/blob/%s/%s", MockOrgName, MockRepoName, branch, filename)
+}
+
+func (em *EnvironmentMock) GetFileLineLink(filename string, branch string, commit string, startLine int, endLine int) string {
+	return fmt.Sprintf("https://github.com/%s/%s/blob/%s/%s#L%d-L%d", MockOrgName, MockRepoName, branch, filename, startLine, endLine)
+}
+
+func (em *EnvironmentMock) GetCommitLink(commit string) string {
+	return ""
+}
+
+func (em *EnvironmentMock) GetCompareLink(baseCommit string, headCommit string) string {
+	return ""
+}
+
+func (em *EnvironmentMock) GetPullRequestLink(pullRequestId string) string {
+	return ""
+}
+
+func (em *EnvironmentMock) GetRefLink(ref string) string {
+	return ""
+}
+
+func (em *EnvironmentMock) IsCurrentEnvironment() bool {
+	return true
+}
+
+func (em *EnvironmentMock) Name() string {
+	return "travis"
+}
@@ -0,0 +1,306 @@
+package travis
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/testutils"
+	"github.com/argonsecurity/go-environments/environments/testutils/mocks"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/models"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	travisMainEnvsFilePath = "testdata/travis-github-main-env.json"
+	travisPrEnvsFilePath   = "testdata/travis-github-pr-env.json"
+	testRepoPath           = "/tmp/travis/repo"
+	testRepoUrl            = "https://github.com/test-organization/test-repo"
+	testRepoCloneUrl       = fmt.Sprintf("%s%s", testRepoUrl, ".git")
+	testdataPath           = "../travis/testdata/repo"
+)
+
+func Test_environment_GetConfiguration(t *testing.T) {
+	tests := []struct {
+		name         string
+		envsFilePath string
+		want         *models.Configuration
+		wantErr      bool
+	}{
+		{
+			name:         "Travis main configuration",
+			envsFilePath: travisMainEnvsFilePath,
+			want: &models.Configuration{
+				Url:             "https://app.travis-ci.com",
+				SCMApiUrl:       "https://api.github.com",
+				LocalPath:       testRepoPath,
+				CommitSha:       "kcy8v2oazy4acuo1475rgtzg0nh403p23vw812lv",
+				BeforeCommitSha: "2c6e3880fd94ddb7ef72d34e683cdc0c47bec6e6",
+				Branch:          "main",
+				Repository: models.Repository{
+					Name:     "test-repo",
+					FullName: "test-organization/test-repo",
+					Url:      testRepoUrl,
+					CloneUrl: testRepoCloneUrl,
+					Source:   enums.Github,
+				},
+				Organization: models.Entity{
+					Name: "test-organization",
+				},
+				Pipeline: models.Pipeline{
+					Entity: models.Entity{
+						Id:   "test-organization/test-repo",
+						Name: "test-repo",
+					},
+					Path: ".travis.yml",
+				},
+				Job: models.Entity{
+					Id:   "599623140",
+					Name: "unit tests",
+				},
+				Run: models.BuildRun{
+					BuildId:     "263495913",
+					BuildNumber: "12",
+				},
+				Runner: models.Runner{
+					OS:           "linux",
+					Distribution: "focal",
+					Architecture: "amd64",
+				},
+				Builder:       "Travis CI",
+				PipelinePaths: []string{"/tmp/travis/repo/.travis.yml"},
+				Environment:   enums.Travis,
+				ScmId:         "8891c0db39f3064732cc1b4ac02c9b9f",
+			},
+		},
+		{
+			name:         "Travis pull request configuration",
+			envsFilePath: travisPrEnvsFilePath,
+			want: &models.Configuration{
+				Url:             "https://app.travis-ci.com",
+				SCMApiUrl:       "https://api.github.com",
+				LocalPath:       testRepoPath,
+				CommitSha:       "y2nc0ns6qk8qs4dfbsvnjrf2o8dvmrfrthmx9nbl",
+				BeforeCommitSha: "kcy8v2oazy4acuo1475rgtzg0nh403p23vw812lv",
+				Branch:          "feature/travis",
+				Repository: models.Repository{
+					Name:     "test-repo",
+					FullName: "test-organization/test-repo",
+					Url:      testRepoUrl,
+					CloneUrl: testRepoCloneUrl,
+					Source:   enums.Github,
+				},
+				Organization: models.Entity{
+					Name: "test-organization",
+				},
+				Pipeline: models.Pipeline{
+					Entity: models.Entity{
+						Id:   "test-organization/test-repo",
+						Name: "test-repo",
+					},
+					Path: ".travis.yml",
+				},
+				Job: models.Entity{
+					Id:   "599623388",
+					Name: "13.1",
+				},
+				Run: models.BuildRun{
+					BuildId:     "263496021",
+					BuildNumber: "13",
+				},
+				Runner: models.Runner{
+					OS:           "osx",
+					Architecture: "arm64",
+				},
+				PullRequest: models.PullRequest{
+					Id:  "7",
+					Url: "https://github.com/test-organization/test-repo/pull/7",
+					SourceRef: models.Ref{
+						Branch: "feature/travis",
+						Sha:    "9f1b2c61f0c1e3d34a36d1b1e0b0a1c8cc66a7a2",
+					},
+					TargetRef: models.Ref{
+						Branch: "main",
+					},
+				},
+				Builder:       "Travis CI",
+				PipelinePaths: []string{"/tmp/travis/repo/.travis.yml"},
+				Environment:   enums.Travis,
+				ScmId:         "8891c0db39f3064732cc1b4ac02c9b9f",
+			},
+		},
+		{
+			name:         "Missing repository slug",
+			envsFilePath: "",
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := prepareTest(t, tt.envsFilePath)
+			got, err := e.GetConfiguration()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("environment.GetConfiguration() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_environment_GetConfigurationFromGitlabRepository(t *testing.T) {
+	envs := testutils.LoadEnvsFromFile(travisPrEnvsFilePath)
+	envs["TRAVIS_REPO_SLUG"] = "test-group/test-repo"
+	envs["TRAVIS_BUILD_DIR"] = t.TempDir()
+	gitClient := (&mocks.MockGitClient{}).SetRemoteUrl("https://gitlab.com/test-group/test-repo.git")
+
+	got, err := New().GetConfigurationFrom(envsource.New(envs).WithGitClient(gitClient))
+	assert.NoError(t, err)
+	assert.Equal(t, "https://gitlab.com/api/v4", got.SCMApiUrl)
+	assert.Equal(t, models.Repository{
+		Name:     "test-repo",
+		FullName: "test-group/test-repo",
+		Url:      "https://gitlab.com/test-group/test-repo",
+		CloneUrl: "https://gitlab.com/test-group/test-repo.git",
+		Source:   enums.Gitlab,
+	}, got.Repository)
+	assert.Equal(t, "https://gitlab.com/test-group/test-repo/-/merge_requests/7", got.PullRequest.Url)
+	assert.Equal(t, "", got.Pipeline.Path)
+	assert.Empty(t, got.PipelinePaths)
+}
+
+func Test_environment_GetStepLink(t *testing.T) {
+	tests := []struct {
+		name         string
+		envsFilePath string
+		want         string
+	}{
+		{
+			name:         "Travis environment",
+			envsFilePath: travisMainEnvsFilePath,
+			want:         "https://app.travis-ci.com/github/test-organization/test-repo/jobs/599623140",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := prepareTest(t, tt.envsFilePath)
+			if got := e.GetStepLink(); got != tt.want {
+				t.Errorf("environment.GetStepLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_environment_GetBuildLink(t *testing.T) {
+	tests := []struct {
+		name         string
+		envsFilePath string
+		unsetEnvs    []string
+		want         string
+	}{
+		{
+			name:         "Travis environment",
+			envsFilePath: travisMainEnvsFilePath,
+			want:         "https://app.travis-ci.com/github/test-organization/test-repo/builds/263495913",
+		},
+		{
+			name:         "Travis environment without build url",
+			envsFilePath: travisMainEnvsFilePath,
+			unsetEnvs:    []string{buildUrlEnv},
+			want:         "https://app.travis-ci.com/github/test-organization/test-repo/builds/263495913",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := prepareTest(t, tt.envsFilePath)
+			for _, env := range tt.unsetEnvs {
+				t.Setenv(env, "")
+			}
+			if got := e.GetBuildLink(); got != tt.want {
+				t.Errorf("environment.GetBuildLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_environment_GetFileLineLink(t *testing.T) {
+	e := prepareTest(t, travisMainEnvsFilePath)
+	assert.Equal(t,
+		"https://github.com/test-organization/test-repo/blob/commit/path/to/file#L1-L3",
+		e.GetFileLineLink("path/to/file", "main", "commit", 1, 3),
+	)
+	assert.Equal(t,
+		"https://github.com/test-organization/test-repo/blob/main/path/to/file",
+		e.GetFileLink("path/to/file", "main", ""),
+	)
+}
+
+func Test_environment_GetLinksGitlabRepository(t *testing.T) {
+	e := New()
+	t.Cleanup(testutils.PrepareTestGitRepository(testRepoPath, "https://gitlab.com/test-group/test-repo.git", testdataPath))
+	t.Cleanup(testutils.SetEnvsFromFile(travisMainEnvsFilePath))
+	t.Setenv(repositorySlugEnv, "test-group/test-repo")
+	t.Setenv(buildUrlEnv, "")
+
+	repoUrl := "https://gitlab.com/test-group/test-repo"
+	assert.Equal(t, repoUrl+"/-/blob/commit/path/to/file#L1-3", e.GetFileLineLink("path/to/file", "main", "commit", 1, 3))
+	assert.Equal(t, repoUrl+"/-/blob/main/path/to/file", e.GetFileLink("path/to/file", "main", ""))
+	assert.Equal(t, repoUrl+"/-/commit/commit", e.GetCommitLink("commit"))
+	assert.Equal(t, repoUrl+"/-/compare/base...head", e.GetCompareLink("base", "head"))
+	assert.Equal(t, repoUrl+"/-/merge_requests/7", e.GetPullRequestLink("7"))
+	assert.Equal(t, repoUrl+"/-/tree/main", e.GetRefLink("main"))
+	assert.Equal(t, "https://app.travis-ci.com/gitlab/test-group/test-repo/builds/263495913", e.GetBuildLink())
+}
+
+func Test_environment_IsCurrentEnvironment(t *testing.T) {
+	tests := []struct {
+		name         string
+		envsFilePath string
+		want         bool
+	}{
+		{
+			name:         "Travis environment",
+			envsFilePath: travisMainEnvsFilePath,
+			want:         true,
+		},
+		{
+			name:         "Not Travis environment",
+			envsFilePath: "",
+			want:         false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := prepareTest(t, tt.envsFilePath)
+			if got := e.IsCurrentEnvironment(); got != tt.want {
+				t.Errorf("environment.IsCurrentEnvironment() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getBeforeCommitSha(t *testing.T) {
+	tests := []struct {
+		commitRange string
+		want        string
+	}{
+		{commitRange: "1a2b3c...4d5e6f", want: "1a2b3c"},
+		{commitRange: "1a2b3c..4d5e6f", want: "1a2b3c"},
+		{commitRange: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.commitRange, func(t *testing.T) {
+			assert.Equal(t, tt.want, getBeforeCommitSha(tt.commitRange))
+		})
+	}
+}
+
+func prepareTest(t *testing.T, envsFilePath string) *Environment {
+	e := New()
+	testRepoCleanup := testutils.PrepareTestGitRepository(testRepoPath, testRepoCloneUrl, testdataPath)
+	t.Cleanup(testRepoCleanup)
+	envCleanup := testutils.SetEnvsFromFile(envsFilePath)
+	t.Cleanup(envCleanup)
+	return e
+}
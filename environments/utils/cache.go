@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"sync"
+
+	"github.com/argonsecurity/go-environments/models"
+)
+
+// ConfigurationCache lazily loads a configuration once and keeps it until it is reset.
+// It is safe for concurrent use
+type ConfigurationCache struct {
+	lock          sync.Mutex
+	configuration *models.Configuration
+}
+
+// Get returns the cached configuration, loading it with load if it was not loaded yet.
+// Failed loads are not cached
+func (c *ConfigurationCache) Get(load func() (*models.Configuration, error)) (*models.Configuration, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.configuration == nil {
+		configuration, err := load()
+		if err != nil {
+			return nil, err
+		}
+		c.configuration = configuration
+	}
+	return c.configuration, nil
+}
+
+// Refresh loads the configuration with load and replaces the cached configuration.
+// On failure the cached configuration is cleared
+func (c *ConfigurationCache) Refresh(load func() (*models.Configuration, error)) (*models.Configuration, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.configuration = nil
+	configuration, err := load()
+	if err != nil {
+		return nil, err
+	}
+	c.configuration = configuration
+	return c.configuration, nil
+}
+
+// Reset clears the cached configuration
+func (c *ConfigurationCache) Reset() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.configuration = nil
+}
@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/argonsecurity/go-environments/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigurationCache(t *testing.T) {
+	var loads int32
+	load := func() (*models.Configuration, error) {
+		count := atomic.AddInt32(&loads, 1)
+		return &models.Configuration{Run: models.BuildRun{BuildNumber: strconv.Itoa(int(count))}}, nil
+	}
+
+	cache := &ConfigurationCache{}
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = cache.Get(load)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), loads)
+
+	first, err := cache.Get(load)
+	assert.NoError(t, err)
+
+	refreshed, err := cache.Refresh(load)
+	assert.NoError(t, err)
+	assert.NotEqual(t, first, refreshed)
+	assert.Equal(t, int32(2), loads)
+
+	cache.Reset()
+	afterReset, err := cache.Get(load)
+	assert.NoError(t, err)
+	assert.NotEqual(t, refreshed, afterReset)
+	assert.Equal(t, int32(3), loads)
+}
+
+func TestConfigurationCache_Error(t *testing.T) {
+	cache := &ConfigurationCache{}
+	got, err := cache.Get(func() (*models.Configuration, error) {
+		return nil, errors.New("load failed")
+	})
+	assert.Error(t, err)
+	assert.Nil(t, got)
+
+	got, err = cache.Get(func() (*models.Configuration, error) {
+		return &models.Configuration{}, nil
+	})
+	assert.NoError(t, err)
+	assert.NotNil(t, got)
+}
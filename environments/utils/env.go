@@ -1,14 +1,17 @@
 package utils
 
 import (
-	"os"
-
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
 	"github.com/argonsecurity/go-environments/models"
 	funk "github.com/thoas/go-funk"
 )
 
 func SetValueIfEnvExist(configuration *models.Configuration, envName string, path string) {
-	if envValue, ok := os.LookupEnv(envName); ok {
+	SetValueIfEnvExistFrom(envsource.OS, configuration, envName, path)
+}
+
+func SetValueIfEnvExistFrom(src envsource.EnvSource, configuration *models.Configuration, envName string, path string) {
+	if envValue, ok := src.LookupEnv(envName); ok {
 		funk.Set(configuration, envValue, path)
 	}
 }
@@ -0,0 +1,283 @@
+package envsource
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/argonsecurity/go-environments/environments/utils/git"
+	"github.com/argonsecurity/go-environments/models"
+)
+
+var (
+	// OS is the default source, it reads from the process environment, file system and git
+	OS EnvSource = osSource{}
+)
+
+// EnvSource provides the variables an environment reads its configuration from
+type EnvSource interface {
+	// LookupEnv get the value of a variable and whether it is set
+	LookupEnv(key string) (string, bool)
+
+	// Getenv get the value of a variable, or an empty string if it is not set
+	Getenv(key string) string
+}
+
+// FileSystemSource is implemented by sources whose files are located under a root directory instead of "/"
+type FileSystemSource interface {
+	// FileSystemRoot get the directory that absolute paths are resolved from
+	FileSystemRoot() string
+}
+
+// GitClientSource is implemented by sources that run git commands with their own client
+type GitClientSource interface {
+	// GitClient get the git client of the source, nil means the global client
+	GitClient() git.GitClient
+}
+
+// WorkingDirectorySource is implemented by sources that have the working directory of the CI run
+type WorkingDirectorySource interface {
+	// WorkingDirectory get the working directory of the CI run, an empty string means it is not known
+	WorkingDirectory() string
+}
+
+// Recorder is implemented by sources that record the files and paths read from the CI run,
+// i.e. for capturing a CI run and replaying it on another machine
+type Recorder interface {
+	// RecordFile is called with the content of every file that is read
+	RecordFile(name string, content []byte)
+
+	// RecordPath is called with every path that is found by Stat or IsPathContainsRepository
+	RecordPath(name string, isDir bool)
+
+	// RecordWalkedPath is called with every file that is visited by Walk,
+	// walks visit files the environment does not use, i.e. the whole repository, so recorders may leave them out
+	RecordWalkedPath(name string)
+}
+
+type osSource struct{}
+
+func (osSource) LookupEnv(key string) (string, bool) {
+	return os.LookupEnv(key)
+}
+
+func (osSource) Getenv(key string) string {
+	return os.Getenv(key)
+}
+
+// osRootSource reads the process environment, and resolves file paths under a root directory
+type osRootSource struct {
+	osSource
+	root string
+}
+
+// OSWithRoot creates a source that reads the process environment and resolves file paths under root,
+// i.e. for reading the files a Kubernetes controller mounts into a pod from a copy of them
+func OSWithRoot(root string) EnvSource {
+	if root == "" {
+		return OS
+	}
+	return osRootSource{root: root}
+}
+
+func (s osRootSource) FileSystemRoot() string {
+	return s.root
+}
+
+// Source is an EnvSource that holds its variables in memory,
+// i.e. for building the configuration of a CI run that was captured on another machine
+type Source struct {
+	Envs       map[string]string
+	Root       string
+	WorkingDir string
+	Git        git.GitClient
+}
+
+// New creates a source from the given variables
+func New(envs map[string]string) *Source {
+	if envs == nil {
+		envs = map[string]string{}
+	}
+	return &Source{
+		Envs: envs,
+	}
+}
+
+// WithRoot set the directory that file paths are resolved from
+func (s *Source) WithRoot(root string) *Source {
+	s.Root = root
+	return s
+}
+
+// WithWorkingDirectory set the working directory of the CI run
+func (s *Source) WithWorkingDirectory(workingDir string) *Source {
+	s.WorkingDir = workingDir
+	return s
+}
+
+// WithGitClient set the git client used for git commands
+func (s *Source) WithGitClient(client git.GitClient) *Source {
+	s.Git = client
+	return s
+}
+
+func (s *Source) LookupEnv(key string) (string, bool) {
+	value, ok := s.Envs[key]
+	return value, ok
+}
+
+func (s *Source) Getenv(key string) string {
+	return s.Envs[key]
+}
+
+func (s *Source) FileSystemRoot() string {
+	return s.Root
+}
+
+func (s *Source) WorkingDirectory() string {
+	return s.WorkingDir
+}
+
+func (s *Source) GitClient() git.GitClient {
+	return s.Git
+}
+
+// Getwd returns the working directory of the CI run, sources that don't have one use the working directory of the process
+func Getwd(src EnvSource) string {
+	if wdSource, ok := src.(WorkingDirectorySource); ok {
+		return wdSource.WorkingDirectory()
+	}
+	workingDir, _ := os.Getwd()
+	return workingDir
+}
+
+// Path resolves a path of the CI run to a path on the local file system
+func Path(src EnvSource, name string) string {
+	root := getRoot(src)
+	if root == "" || name == "" {
+		return name
+	}
+	return filepath.Join(root, name)
+}
+
+// ReadFile reads a file of the CI run
+func ReadFile(src EnvSource, name string) ([]byte, error) {
+	content, err := os.ReadFile(Path(src, name))
+	if err == nil {
+		if recorder, ok := src.(Recorder); ok {
+			recorder.RecordFile(name, content)
+		}
+	}
+	return content, err
+}
+
+// Stat returns the file info of a file of the CI run
+func Stat(src EnvSource, name string) (fs.FileInfo, error) {
+	info, err := os.Stat(Path(src, name))
+	if err == nil {
+		if recorder, ok := src.(Recorder); ok {
+			recorder.RecordPath(name, info.IsDir())
+		}
+	}
+	return info, err
+}
+
+// Walk walks the file tree of the CI run like filepath.Walk.
+// The paths passed to fn are the paths of the CI run and not the resolved local paths
+func Walk(src EnvSource, root string, fn filepath.WalkFunc) error {
+	if recorder, ok := src.(Recorder); ok {
+		fn = recordingWalkFunc(recorder, fn)
+	}
+
+	fsRoot := getRoot(src)
+	if fsRoot == "" {
+		return filepath.Walk(root, fn)
+	}
+
+	resolvedRoot := Path(src, root)
+	return filepath.Walk(resolvedRoot, func(path string, info fs.FileInfo, err error) error {
+		relativePath, relErr := filepath.Rel(resolvedRoot, path)
+		if relErr != nil {
+			return relErr
+		}
+		return fn(filepath.Join(root, relativePath), info, err)
+	})
+}
+
+// recordingWalkFunc records the files that are visited by a walk, directories are recorded through their files
+func recordingWalkFunc(recorder Recorder, fn filepath.WalkFunc) filepath.WalkFunc {
+	return func(path string, info fs.FileInfo, err error) error {
+		if err == nil && info != nil && !info.IsDir() {
+			recorder.RecordWalkedPath(path)
+		}
+		return fn(path, info, err)
+	}
+}
+
+// GitClient returns the git client of the source, or the global git client
+func GitClient(src EnvSource) git.GitClient {
+	if gitSource, ok := src.(GitClientSource); ok {
+		if client := gitSource.GitClient(); client != nil {
+			return client
+		}
+	}
+	return git.GlobalGitClient
+}
+
+// GetGitBranch gets the branch of a repository of the CI run with the git client of the source,
+// and the non-fatal errors of getting it as warnings when the client reports them
+func GetGitBranch(src EnvSource, repositoryPath string, commit string) (string, []models.Warning, error) {
+	client := GitClient(src)
+	warningsGetter, ok := client.(git.BranchWarningsGetter)
+	if !ok {
+		branch, err := client.GetGitBranch(Path(src, repositoryPath), commit)
+		return branch, nil, err
+	}
+
+	branch, branchErrors, err := warningsGetter.GetGitBranchWithWarnings(Path(src, repositoryPath), commit)
+	var warnings []models.Warning
+	for _, branchErr := range branchErrors {
+		warnings = append(warnings, models.NewWarning(models.GitBranchWarning, branchErr, "skipped a branch while searching for the branch of commit %s", commit))
+	}
+	return branch, warnings, err
+}
+
+// IsPathContainsRepository checks if a path of the CI run is a git repository
+func IsPathContainsRepository(src EnvSource, path string) bool {
+	isRepository := git.IsPathContainsRepository(Path(src, path))
+	if isRepository {
+		if recorder, ok := src.(Recorder); ok {
+			recorder.RecordPath(filepath.Join(path, ".git"), true)
+		}
+	}
+	return isRepository
+}
+
+// FindRepository returns dir when it is a git repository of the CI run, or the first of its subdirectories that is one.
+// CI systems that mount the checkout into a working directory, i.e. as a task input, have no variable with its path
+func FindRepository(src EnvSource, dir string) string {
+	if dir == "" {
+		return ""
+	}
+	if IsPathContainsRepository(src, dir) {
+		return dir
+	}
+
+	entries, err := os.ReadDir(Path(src, dir))
+	if err != nil {
+		return ""
+	}
+	for _, entry := range entries {
+		if path := filepath.Join(dir, entry.Name()); entry.IsDir() && IsPathContainsRepository(src, path) {
+			return path
+		}
+	}
+	return ""
+}
+
+func getRoot(src EnvSource) string {
+	if fsSource, ok := src.(FileSystemSource); ok {
+		return fsSource.FileSystemRoot()
+	}
+	return ""
+}
@@ -0,0 +1,205 @@
+package envsource
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/argonsecurity/go-environments/environments/testutils/mocks"
+	"github.com/argonsecurity/go-environments/environments/utils/git"
+	"github.com/argonsecurity/go-environments/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSource_LookupEnv(t *testing.T) {
+	src := New(map[string]string{"SET": "value", "EMPTY": ""})
+
+	value, ok := src.LookupEnv("SET")
+	assert.True(t, ok)
+	assert.Equal(t, "value", value)
+
+	value, ok = src.LookupEnv("EMPTY")
+	assert.True(t, ok)
+	assert.Equal(t, "", value)
+
+	_, ok = src.LookupEnv("NOT_SET")
+	assert.False(t, ok)
+	assert.Equal(t, "", src.Getenv("NOT_SET"))
+}
+
+func TestOSWithRoot_LookupEnv(t *testing.T) {
+	t.Setenv("ENVSOURCE_TEST", "value")
+	src := OSWithRoot("/mounted")
+
+	value, ok := src.LookupEnv("ENVSOURCE_TEST")
+	assert.True(t, ok)
+	assert.Equal(t, "value", value)
+	assert.Equal(t, "value", src.Getenv("ENVSOURCE_TEST"))
+}
+
+func TestPath(t *testing.T) {
+	tests := []struct {
+		name string
+		src  EnvSource
+		path string
+		want string
+	}{
+		{
+			name: "OS source",
+			src:  OS,
+			path: "/repo/file",
+			want: "/repo/file",
+		},
+		{
+			name: "Source without root",
+			src:  New(nil),
+			path: "/repo/file",
+			want: "/repo/file",
+		},
+		{
+			name: "Source with root",
+			src:  New(nil).WithRoot("/captured"),
+			path: "/repo/file",
+			want: "/captured/repo/file",
+		},
+		{
+			name: "OS source with root",
+			src:  OSWithRoot("/mounted"),
+			path: "/repo/file",
+			want: "/mounted/repo/file",
+		},
+		{
+			name: "OS source with empty root",
+			src:  OSWithRoot(""),
+			path: "/repo/file",
+			want: "/repo/file",
+		},
+		{
+			name: "Empty path",
+			src:  New(nil).WithRoot("/captured"),
+			path: "",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Path(tt.src, tt.path))
+		})
+	}
+}
+
+func TestReadFileAndWalk(t *testing.T) {
+	root := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "repo", "dir"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "repo", "dir", "file"), []byte("data"), 0644))
+	src := New(nil).WithRoot(root)
+
+	data, err := ReadFile(src, "/repo/dir/file")
+	assert.NoError(t, err)
+	assert.Equal(t, "data", string(data))
+
+	_, err = Stat(src, "/repo/missing")
+	assert.Error(t, err)
+
+	paths := []string{}
+	err = Walk(src, "/repo", func(path string, info fs.FileInfo, err error) error {
+		paths = append(paths, path)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/repo", "/repo/dir", "/repo/dir/file"}, paths)
+}
+
+func TestGetwd(t *testing.T) {
+	workingDir, err := os.Getwd()
+	assert.NoError(t, err)
+
+	assert.Equal(t, workingDir, Getwd(OS))
+	assert.Equal(t, workingDir, Getwd(OSWithRoot("/mounted")))
+	assert.Equal(t, "/captured/repo", Getwd(New(nil).WithWorkingDirectory("/captured/repo")))
+	assert.Equal(t, "", Getwd(New(nil)))
+}
+
+func TestGitClient(t *testing.T) {
+	mock := &mocks.MockGitClient{}
+
+	assert.Equal(t, git.GlobalGitClient, GitClient(OS))
+	assert.Equal(t, git.GlobalGitClient, GitClient(New(nil)))
+	assert.Equal(t, mock, GitClient(New(nil).WithGitClient(mock)))
+}
+
+type testRecorder struct {
+	*Source
+	files  map[string]string
+	paths  map[string]bool
+	walked []string
+}
+
+func (r *testRecorder) RecordFile(name string, content []byte) {
+	r.files[name] = string(content)
+}
+
+func (r *testRecorder) RecordPath(name string, isDir bool) {
+	r.paths[name] = isDir
+}
+
+func (r *testRecorder) RecordWalkedPath(name string) {
+	r.walked = append(r.walked, name)
+}
+
+func TestRecorder(t *testing.T) {
+	root := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "repo", ".git"), 0755))
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "repo", "dir"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "repo", "dir", "file"), []byte("data"), 0644))
+	src := &testRecorder{Source: New(nil).WithRoot(root), files: map[string]string{}, paths: map[string]bool{}}
+
+	_, err := ReadFile(src, "/repo/dir/file")
+	assert.NoError(t, err)
+	_, err = Stat(src, "/repo/missing")
+	assert.Error(t, err)
+	assert.NoError(t, Walk(src, "/repo/dir", func(path string, info fs.FileInfo, err error) error { return err }))
+	assert.True(t, IsPathContainsRepository(src, "/repo"))
+
+	assert.Equal(t, map[string]string{"/repo/dir/file": "data"}, src.files)
+	assert.Equal(t, map[string]bool{"/repo/.git": true}, src.paths)
+	assert.Equal(t, []string{"/repo/dir/file"}, src.walked)
+}
+
+func TestFindRepository(t *testing.T) {
+	root := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "build", "b-artifacts"), 0755))
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "build", "repo", ".git"), 0755))
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "checkout", ".git"), 0755))
+	src := New(nil).WithRoot(root)
+
+	assert.Equal(t, "/build/repo", FindRepository(src, "/build"))
+	assert.Equal(t, "/checkout", FindRepository(src, "/checkout"))
+	assert.Equal(t, "", FindRepository(src, "/build/b-artifacts"))
+	assert.Equal(t, "", FindRepository(src, "/missing"))
+	assert.Equal(t, "", FindRepository(src, ""))
+}
+
+type branchWarningsClient struct {
+	*mocks.MockGitClient
+}
+
+func (c *branchWarningsClient) GetGitBranchWithWarnings(repositoryPath string, commit string) (string, []error, error) {
+	return "main", []error{errors.New("failed to get branch HEAD commit of feature")}, nil
+}
+
+func TestGetGitBranch(t *testing.T) {
+	branch, warnings, err := GetGitBranch(New(nil).WithGitClient((&mocks.MockGitClient{}).SetBranch("main")), "/repo", "commit")
+	assert.NoError(t, err)
+	assert.Equal(t, "main", branch)
+	assert.Empty(t, warnings)
+
+	branch, warnings, err = GetGitBranch(New(nil).WithGitClient(&branchWarningsClient{&mocks.MockGitClient{}}), "/repo", "commit")
+	assert.NoError(t, err)
+	assert.Equal(t, "main", branch)
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, models.GitBranchWarning, warnings[0].Code)
+	assert.EqualError(t, warnings[0], "skipped a branch while searching for the branch of commit commit: failed to get branch HEAD commit of feature")
+}
@@ -6,22 +6,30 @@ import (
 )
 
 func (gc *Client) GetGitBranch(repositoryPath string, commit string) (string, error) {
+	branch, _, err := gc.GetGitBranchWithWarnings(repositoryPath, commit)
+	return branch, err
+}
+
+// GetGitBranchWithWarnings gets the branch like GetGitBranch, and returns the errors of the branches
+// that were skipped while searching for the branch of a detached commit
+func (gc *Client) GetGitBranchWithWarnings(repositoryPath string, commit string) (string, []error, error) {
 	branch, err := gc.GitExecInDir(repositoryPath, "rev-parse", "--abbrev-ref", "HEAD")
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 	if branch == "HEAD" { // this means we are running in detached mode
 		return gc.getBranchContainingCommit(repositoryPath, commit)
 	}
 
-	return branch, nil
+	return branch, nil, nil
 }
 
-func (gc *Client) getBranchContainingCommit(repositoryPath, commit string) (string, error) {
+func (gc *Client) getBranchContainingCommit(repositoryPath, commit string) (string, []error, error) {
 	parsedOutput, err := gc.GitExecInDir(repositoryPath, "branch", "-a", "--contains", commit)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
+	warnings := []error{}
 	lines := strings.Split(parsedOutput, "\n")
 	for _, line := range lines {
 
@@ -30,20 +38,20 @@ func (gc *Client) getBranchContainingCommit(repositoryPath, commit string) (stri
 		}
 
 		if strings.HasPrefix(line, "*") {
-			return strings.TrimSpace(strings.TrimPrefix(line, "*")), nil
+			return strings.TrimSpace(strings.TrimPrefix(line, "*")), warnings, nil
 		}
 		branch := strings.TrimSpace(line)
 		headCommit, err := gc.getBranchHeadCommit(repositoryPath, branch)
 		if err != nil {
-			fmt.Printf("failed to get branch HEAD commit: %s %s", branch, err)
+			warnings = append(warnings, fmt.Errorf("failed to get branch HEAD commit of %s: %w", branch, err))
 			continue
 		}
 		if headCommit == commit {
-			return TrimBranchName(branch), nil
+			return TrimBranchName(branch), warnings, nil
 		}
 
 	}
-	return "", nil
+	return "", warnings, nil
 }
 
 func (gc *Client) getBranchHeadCommit(repositoryPath, branch string) (string, error) {
@@ -10,6 +10,11 @@ type GitClient interface {
 	CreateGitRepository(path string) error
 }
 
+// BranchWarningsGetter is implemented by git clients that report the non-fatal errors of getting a branch
+type BranchWarningsGetter interface {
+	GetGitBranchWithWarnings(repositoryPath string, commit string) (string, []error, error)
+}
+
 type Client struct {
 	binPath string
 }
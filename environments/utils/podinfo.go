@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+)
+
+const (
+	// PodInfoDir is where pods conventionally mount their labels, annotations, name and namespace with the downward API
+	PodInfoDir = "/etc/podinfo"
+
+	serviceAccountNamespacePath = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+	podInfoLabelsFile      = "labels"
+	podInfoAnnotationsFile = "annotations"
+	podInfoNamespaceFile   = "namespace"
+)
+
+// ParsePodInfo parses the labels or annotations file of a downward API volume,
+// every line is a key and a quoted value, i.e. tekton.dev/pipelineRun="build-x7k2p"
+func ParsePodInfo(content []byte) map[string]string {
+	values := map[string]string{}
+	for _, line := range strings.Split(string(content), "\n") {
+		index := strings.Index(line, "=")
+		if index == -1 {
+			continue
+		}
+		key, value := strings.TrimSpace(line[:index]), strings.TrimSpace(line[index+1:])
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		}
+		values[key] = value
+	}
+	return values
+}
+
+// ReadPodLabels reads the labels file of a downward API volume mounted at dir, a missing file has no labels
+func ReadPodLabels(src envsource.EnvSource, dir string) map[string]string {
+	return readPodInfo(src, filepath.Join(dir, podInfoLabelsFile))
+}
+
+// ReadPodAnnotations reads the annotations file of a downward API volume mounted at dir, a missing file has no annotations
+func ReadPodAnnotations(src envsource.EnvSource, dir string) map[string]string {
+	return readPodInfo(src, filepath.Join(dir, podInfoAnnotationsFile))
+}
+
+// GetPodNamespace returns the namespace of the pod from the namespace file of a downward API volume mounted at dir,
+// or from the service account token volume that is mounted by default
+func GetPodNamespace(src envsource.EnvSource, dir string) string {
+	for _, path := range []string{filepath.Join(dir, podInfoNamespaceFile), serviceAccountNamespacePath} {
+		if content, err := envsource.ReadFile(src, path); err == nil {
+			if namespace := strings.TrimSpace(string(content)); namespace != "" {
+				return namespace
+			}
+		}
+	}
+	return ""
+}
+
+func readPodInfo(src envsource.EnvSource, path string) map[string]string {
+	content, err := envsource.ReadFile(src, path)
+	if err != nil {
+		return map[string]string{}
+	}
+	return ParsePodInfo(content)
+}
@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePodInfo(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    map[string]string
+	}{
+		{
+			name:    "Quoted values",
+			content: "app.kubernetes.io/managed-by=\"tekton-pipelines\"\ntekton.dev/pipelineRun=\"build-x7k2p\"\n",
+			want: map[string]string{
+				"app.kubernetes.io/managed-by": "tekton-pipelines",
+				"tekton.dev/pipelineRun":       "build-x7k2p",
+			},
+		},
+		{
+			name:    "Escaped values",
+			content: `kubectl.kubernetes.io/last-applied-configuration="{\"kind\":\"Pipeline\"}\n"`,
+			want: map[string]string{
+				"kubectl.kubernetes.io/last-applied-configuration": "{\"kind\":\"Pipeline\"}\n",
+			},
+		},
+		{
+			name:    "Unquoted values and invalid lines",
+			content: "key=value\ninvalid\n\n",
+			want: map[string]string{
+				"key": "value",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ParsePodInfo([]byte(tt.content)))
+		})
+	}
+}
+
+func TestReadPodInfo(t *testing.T) {
+	root := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, PodInfoDir), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, PodInfoDir, "labels"), []byte(`tekton.dev/task="build"`), 0644))
+	src := envsource.New(nil).WithRoot(root)
+
+	assert.Equal(t, map[string]string{"tekton.dev/task": "build"}, ReadPodLabels(src, PodInfoDir))
+	assert.Equal(t, map[string]string{}, ReadPodAnnotations(src, PodInfoDir))
+	assert.Equal(t, "", GetPodNamespace(src, PodInfoDir))
+
+	serviceAccountDir := filepath.Join(root, filepath.Dir(serviceAccountNamespacePath))
+	assert.NoError(t, os.MkdirAll(serviceAccountDir, 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, serviceAccountNamespacePath), []byte("ci\n"), 0644))
+	assert.Equal(t, "ci", GetPodNamespace(src, PodInfoDir))
+
+	assert.NoError(t, os.WriteFile(filepath.Join(root, PodInfoDir, "namespace"), []byte("builds"), 0644))
+	assert.Equal(t, "builds", GetPodNamespace(src, PodInfoDir))
+}
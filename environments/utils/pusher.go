@@ -2,9 +2,10 @@ package utils
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 	"regexp"
+
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
 )
 
 var possibleUserEnvVars = []string{
@@ -15,17 +16,20 @@ var possibleUserEnvVars = []string{
 }
 
 func DetectPusher() string {
+	return DetectPusherFrom(envsource.OS)
+}
+
+func DetectPusherFrom(src envsource.EnvSource) string {
 	for _, userEnv := range possibleUserEnvVars {
-		if v, ok := os.LookupEnv(userEnv); ok {
+		if v, ok := src.LookupEnv(userEnv); ok {
 			return v
 		}
 	}
 
 	re := regexp.MustCompile(`(?m)^.*<(.+?)>`)
-	path, _ := os.Getwd()
-	logsHeadFile := filepath.Join(path, ".git", "logs", "HEAD")
-	if _, err := os.Stat(logsHeadFile); err == nil {
-		contents, err := os.ReadFile(logsHeadFile)
+	logsHeadFile := filepath.Join(envsource.Getwd(src), ".git", "logs", "HEAD")
+	if _, err := envsource.Stat(src, logsHeadFile); err == nil {
+		contents, err := envsource.ReadFile(src, logsHeadFile)
 		if err == nil {
 			matches := re.FindAllSubmatch(contents, -1)
 			if len(matches) >= 1 {
@@ -34,7 +38,7 @@ func DetectPusher() string {
 		}
 	}
 
-	if v, ok := os.LookupEnv("USERNAME"); ok {
+	if v, ok := src.LookupEnv("USERNAME"); ok {
 		return fmt.Sprintf("Fallback: %s", v)
 	}
 
@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	branchRefPrefix = "refs/heads/"
+	tagRefPrefix    = "refs/tags/"
+	refPrefix       = "refs/"
+)
+
+var commitShaRegexp = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// ParseRef returns the name of a branch or tag ref and whether it is a tag,
+// i.e. v1.0 and true for refs/tags/v1.0. Refs without the refs/heads/ or refs/tags/ prefix are branch names
+func ParseRef(ref string) (string, bool) {
+	if strings.HasPrefix(ref, tagRefPrefix) {
+		return strings.TrimPrefix(ref, tagRefPrefix), true
+	}
+	return strings.TrimPrefix(ref, branchRefPrefix), false
+}
+
+// GetLinkRef returns the ref of a link that does not tell a branch from a commit,
+// a commit sha and a full ref are kept and any other name is a branch, i.e. refs/heads/main for main
+func GetLinkRef(ref string) string {
+	if ref == "" || commitShaRegexp.MatchString(ref) || strings.HasPrefix(ref, refPrefix) {
+		return ref
+	}
+	return branchRefPrefix + ref
+}
@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRef(t *testing.T) {
+	tests := []struct {
+		name      string
+		ref       string
+		wantName  string
+		wantIsTag bool
+	}{
+		{
+			name:     "Branch name",
+			ref:      "feature/test",
+			wantName: "feature/test",
+		},
+		{
+			name:     "Branch ref",
+			ref:      "refs/heads/feature/test",
+			wantName: "feature/test",
+		},
+		{
+			name:      "Tag ref",
+			ref:       "refs/tags/v1.0.0",
+			wantName:  "v1.0.0",
+			wantIsTag: true,
+		},
+		{
+			name:     "Empty ref",
+			ref:      "",
+			wantName: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotName, gotIsTag := ParseRef(tt.ref)
+			assert.Equal(t, tt.wantName, gotName)
+			assert.Equal(t, tt.wantIsTag, gotIsTag)
+		})
+	}
+}
+
+func TestGetLinkRef(t *testing.T) {
+	tests := []struct {
+		ref  string
+		want string
+	}{
+		{ref: "main", want: "refs/heads/main"},
+		{ref: "feature/test", want: "refs/heads/feature/test"},
+		{ref: "refs/heads/main", want: "refs/heads/main"},
+		{ref: "refs/tags/v1.0.0", want: "refs/tags/v1.0.0"},
+		{ref: "0a1b2c3d4e5f60718293a4b5c6d7e8f901234567", want: "0a1b2c3d4e5f60718293a4b5c6d7e8f901234567"},
+		{ref: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			assert.Equal(t, tt.want, GetLinkRef(tt.ref))
+		})
+	}
+}
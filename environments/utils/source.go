@@ -0,0 +1,110 @@
+package utils
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/codecommit"
+)
+
+const (
+	GithubApiUrl    = "https://api.github.com"
+	GitlabApiUrl    = "https://gitlab.com/api/v4"
+	AzureApiUrl     = ""
+	BitbucketApiUrl = "https://api.bitbucket.org/2.0"
+	SourceHutApiUrl = "https://git.sr.ht/api"
+
+	githubHostname    = "github.com"
+	gitlabHostname    = "gitlab.com"
+	azureHostname     = "dev.azure.com"
+	bitbucketHostname = "bitbucket.org"
+	sourceHutHostname = "git.sr.ht"
+
+	// azureLegacyHostnameSuffix is the hostname suffix of Azure DevOps organizations that still use <organization>.visualstudio.com
+	azureLegacyHostnameSuffix = ".visualstudio.com"
+)
+
+var (
+	// giteaHostnames are the public Gitea and Forgejo servers, self-hosted servers are discovered by their api
+	giteaHostnames = []string{"gitea.com", "codeberg.org"}
+)
+
+// GetRepositorySource detects the SaaS SCM of a clone url by its hostname, and returns the source and its api url.
+// Clone urls of other hosts, i.e. self-hosted servers, return enums.Unknown
+func GetRepositorySource(cloneUrl string) (enums.Source, string) {
+	if codecommit.IsCodeCommitCloneUrl(cloneUrl) {
+		region, _, _ := codecommit.ParseCloneUrl(cloneUrl)
+		return enums.CodeCommit, codecommit.GetApiUrl(region)
+	}
+
+	switch {
+	case strings.Contains(cloneUrl, sourceHutHostname):
+		return enums.SourceHut, SourceHutApiUrl
+	case strings.Contains(cloneUrl, bitbucketHostname):
+		return enums.Bitbucket, BitbucketApiUrl
+	case strings.Contains(cloneUrl, githubHostname):
+		return enums.Github, GithubApiUrl
+	case strings.Contains(cloneUrl, azureHostname):
+		return enums.Azure, AzureApiUrl
+	case strings.Contains(cloneUrl, gitlabHostname):
+		return enums.Gitlab, GitlabApiUrl
+	}
+
+	for _, hostname := range giteaHostnames {
+		if strings.Contains(cloneUrl, hostname) {
+			return enums.Gitea, GetGiteaApiUrl(fmt.Sprintf("https://%s", hostname))
+		}
+	}
+
+	return enums.Unknown, ""
+}
+
+// GetGiteaApiUrl returns the api url of a Gitea or Forgejo server
+func GetGiteaApiUrl(serverUrl string) string {
+	return fmt.Sprintf("%s/api/v1", strings.TrimSuffix(serverUrl, "/"))
+}
+
+// GetFileLinkSource detects the SCM of a link to a file by the path of the link, the way each SCM browses files,
+// and tells the SaaS SCM from the self-hosted one by the hostname. Links that are not file links return enums.Unknown
+func GetFileLinkSource(link string) enums.Source {
+	urlObject, err := url.Parse(link)
+	if err != nil || urlObject.Host == "" {
+		return enums.Unknown
+	}
+	hostname := urlObject.Hostname()
+	path := urlObject.EscapedPath()
+
+	switch {
+	case codecommit.IsConsoleUrl(link) && strings.Contains(path, "/--/"):
+		return enums.CodeCommit
+	case strings.Contains(path, "/+/"):
+		return enums.Gerrit
+	case strings.Contains(path, "/_git/") && urlObject.Query().Has("path"):
+		if hostname == azureHostname || strings.HasSuffix(hostname, azureLegacyHostnameSuffix) {
+			return enums.Azure
+		}
+		return enums.AzureServer
+	case strings.Contains(path, "/-/blob/"):
+		if hostname == gitlabHostname {
+			return enums.Gitlab
+		}
+		return enums.GitlabServer
+	case strings.HasPrefix(path, "/~") && strings.Contains(path, "/tree/") && strings.Contains(path, "/item/"):
+		return enums.SourceHut
+	case strings.Contains(path, "/projects/") && strings.Contains(path, "/repos/") && strings.Contains(path, "/browse/"):
+		return enums.BitbucketServer
+	case hostname == bitbucketHostname && strings.Contains(path, "/src/"):
+		return enums.Bitbucket
+	case strings.Contains(path, "/src/branch/") || strings.Contains(path, "/src/commit/") || strings.Contains(path, "/src/tag/"):
+		return enums.Gitea
+	case strings.Contains(path, "/blob/"):
+		if hostname == githubHostname {
+			return enums.Github
+		}
+		return enums.GithubServer
+	}
+
+	return enums.Unknown
+}
@@ -0,0 +1,174 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRepositorySource(t *testing.T) {
+	tests := []struct {
+		name       string
+		cloneUrl   string
+		wantSource enums.Source
+		wantApiUrl string
+	}{
+		{
+			name:       "GitHub HTTP clone url",
+			cloneUrl:   "https://github.com/test-organization/test-repo.git",
+			wantSource: enums.Github,
+			wantApiUrl: GithubApiUrl,
+		},
+		{
+			name:       "GitHub SSH clone url",
+			cloneUrl:   "git@github.com:test-organization/test-repo.git",
+			wantSource: enums.Github,
+			wantApiUrl: GithubApiUrl,
+		},
+		{
+			name:       "GitLab clone url",
+			cloneUrl:   "https://gitlab.com/test-group/subgroup/test-project.git",
+			wantSource: enums.Gitlab,
+			wantApiUrl: GitlabApiUrl,
+		},
+		{
+			name:       "Azure DevOps clone url",
+			cloneUrl:   "https://test-org@dev.azure.com/test-org/test-project/_git/test-repo",
+			wantSource: enums.Azure,
+			wantApiUrl: AzureApiUrl,
+		},
+		{
+			name:       "Bitbucket clone url",
+			cloneUrl:   "git@bitbucket.org:test-workspace/test-repo.git",
+			wantSource: enums.Bitbucket,
+			wantApiUrl: BitbucketApiUrl,
+		},
+		{
+			name:       "CodeCommit HTTPS clone url",
+			cloneUrl:   "https://git-codecommit.us-east-1.amazonaws.com/v1/repos/test-repo",
+			wantSource: enums.CodeCommit,
+			wantApiUrl: "https://codecommit.us-east-1.amazonaws.com",
+		},
+		{
+			name:       "CodeCommit remote helper url",
+			cloneUrl:   "codecommit::eu-west-1://test-repo",
+			wantSource: enums.CodeCommit,
+			wantApiUrl: "https://codecommit.eu-west-1.amazonaws.com",
+		},
+		{
+			name:       "SourceHut SSH clone url",
+			cloneUrl:   "git@git.sr.ht:~test-user/test-repo",
+			wantSource: enums.SourceHut,
+			wantApiUrl: SourceHutApiUrl,
+		},
+		{
+			name:       "Codeberg clone url",
+			cloneUrl:   "https://codeberg.org/test-organization/test-repo.git",
+			wantSource: enums.Gitea,
+			wantApiUrl: "https://codeberg.org/api/v1",
+		},
+		{
+			name:       "Self-hosted clone url",
+			cloneUrl:   "https://git.company.com/test-organization/test-repo.git",
+			wantSource: enums.Unknown,
+			wantApiUrl: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, apiUrl := GetRepositorySource(tt.cloneUrl)
+			assert.Equal(t, tt.wantSource, source)
+			assert.Equal(t, tt.wantApiUrl, apiUrl)
+		})
+	}
+}
+
+func TestGetFileLinkSource(t *testing.T) {
+	tests := []struct {
+		name string
+		link string
+		want enums.Source
+	}{
+		{
+			name: "GitHub",
+			link: "https://github.com/org/repo/blob/main/path/to/file#L10-L20",
+			want: enums.Github,
+		},
+		{
+			name: "GitHub Server",
+			link: "https://github.company.com/org/repo/blob/main/path/to/file",
+			want: enums.GithubServer,
+		},
+		{
+			name: "GitLab",
+			link: "https://gitlab.com/group/subgroup/repo/-/blob/main/path/to/file#L10-20",
+			want: enums.Gitlab,
+		},
+		{
+			name: "GitLab Server",
+			link: "https://gitlab.company.com/group/repo/-/blob/main/path/to/file",
+			want: enums.GitlabServer,
+		},
+		{
+			name: "Azure DevOps",
+			link: "https://dev.azure.com/org/project/_git/repo?path=path%2Fto%2Ffile&version=GBmain&_a=contents",
+			want: enums.Azure,
+		},
+		{
+			name: "Azure DevOps legacy hostname",
+			link: "https://org.visualstudio.com/project/_git/repo?path=path%2Fto%2Ffile&version=GBmain&_a=contents",
+			want: enums.Azure,
+		},
+		{
+			name: "Azure DevOps Server",
+			link: "https://azure.company.com/collection/project/_git/repo?path=path%2Fto%2Ffile&version=GBmain&_a=contents",
+			want: enums.AzureServer,
+		},
+		{
+			name: "Bitbucket",
+			link: "https://bitbucket.org/workspace/repo/src/main/path/to/file#lines-10:20",
+			want: enums.Bitbucket,
+		},
+		{
+			name: "Bitbucket Server",
+			link: "https://bitbucket.company.com/projects/PROJ/repos/repo/browse/path/to/file?at=main#10-20",
+			want: enums.BitbucketServer,
+		},
+		{
+			name: "Gitea",
+			link: "https://codeberg.org/org/repo/src/branch/main/path/to/file#L10-L20",
+			want: enums.Gitea,
+		},
+		{
+			name: "Gerrit",
+			link: "https://gerrit.company.com/plugins/gitiles/platform/build/+/refs/heads/main/path/to/file#10",
+			want: enums.Gerrit,
+		},
+		{
+			name: "CodeCommit",
+			link: "https://us-east-1.console.aws.amazon.com/codesuite/codecommit/repositories/repo/browse/refs/heads/main/--/path/to/file?region=us-east-1",
+			want: enums.CodeCommit,
+		},
+		{
+			name: "SourceHut",
+			link: "https://git.sr.ht/~user/repo/tree/main/item/path/to/file#L10-20",
+			want: enums.SourceHut,
+		},
+		{
+			name: "Repository link",
+			link: "https://github.com/org/repo",
+			want: enums.Unknown,
+		},
+		{
+			name: "Not a url",
+			link: "path/to/file",
+			want: enums.Unknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, GetFileLinkSource(tt.link))
+		})
+	}
+}
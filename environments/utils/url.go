@@ -3,9 +3,11 @@ package utils
 import (
 	"fmt"
 	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/codecommit"
 	"github.com/argonsecurity/go-environments/environments/jenkins/environments/azure"
 	azureserver "github.com/argonsecurity/go-environments/environments/jenkins/environments/azure_server"
 	bitbucketserver "github.com/argonsecurity/go-environments/environments/jenkins/environments/bitbucket_server"
+	"github.com/argonsecurity/go-environments/environments/jenkins/environments/gerrit"
 	"net/url"
 	"regexp"
 	"strings"
@@ -61,6 +63,13 @@ func StripCredentialsFromUrl(urlToStrip string) string {
 //
 // i.e https://example.company.io/gitlab
 func ParseDataFromCloneUrl(cloneUrl, apiUrl string, repoSource enums.Source) (string, string, string, string, error) {
+	if repoSource == enums.Gerrit {
+		return parseGerritDataFromCloneUrl(cloneUrl)
+	}
+	if repoSource == enums.CodeCommit {
+		return parseCodeCommitDataFromCloneUrl(cloneUrl)
+	}
+
 	var regexp = uriRegexp
 	baseUrl, uri, isSshUrl, err := getUriFromCloneUrl(cloneUrl, apiUrl)
 	if err != nil {
@@ -91,6 +100,35 @@ func ParseDataFromCloneUrl(cloneUrl, apiUrl string, repoSource enums.Source) (st
 	return buildScmLink(baseUrl, org, subgroups, repo, isSshUrl, repoSource), org, repo, repositoryFullName, nil
 }
 
+// parseGerritDataFromCloneUrl extracts data from a Gerrit clone url, Gerrit projects are paths of any depth
+// so the organization is the path of the project without its last part
+//
+// i.e. ssh://gerrit.company.com:29418/platform/build.git
+func parseGerritDataFromCloneUrl(cloneUrl string) (string, string, string, string, error) {
+	serverUrl, project, err := gerrit.ParseCloneUrl(cloneUrl)
+	if err != nil {
+		return "", "", "", "", err
+	}
+
+	org, repo := "", project
+	if index := strings.LastIndex(project, "/"); index != -1 {
+		org, repo = project[:index], project[index+1:]
+	}
+	return gerrit.BuildScmLink(serverUrl, project), org, repo, project, nil
+}
+
+// parseCodeCommitDataFromCloneUrl extracts data from a CodeCommit clone url, CodeCommit repositories have no organization
+// and are browsed in the AWS console
+//
+// i.e. https://git-codecommit.us-east-1.amazonaws.com/v1/repos/test-repo or codecommit::us-east-1://test-repo
+func parseCodeCommitDataFromCloneUrl(cloneUrl string) (string, string, string, string, error) {
+	region, repo, err := codecommit.ParseCloneUrl(cloneUrl)
+	if err != nil {
+		return "", "", "", "", err
+	}
+	return codecommit.BuildScmLink(region, repo), "", repo, repo, nil
+}
+
 func buildGenericScmLink(baseUrl, org, subgroups, repo string, isSshUrl bool) string {
 	return fmt.Sprintf("%s/%s/%s%s", baseUrl, org, subgroups, repo)
 }
@@ -494,6 +494,90 @@ func Test_parseDataFromCloneUrl(t *testing.T) {
 			wantRepo:         "test-repo",
 			wantRepoFullName: "TS/test-repo",
 		},
+		{
+			name: "Gerrit SSH clone url",
+			args: args{
+				cloneUrl:   "ssh://gerrit.company.com:29418/platform/build.git",
+				apiUrl:     "https://gerrit.company.com",
+				repoSource: enums.Gerrit,
+			},
+			wantUrl:          "https://gerrit.company.com/plugins/gitiles/platform/build",
+			wantOrg:          "platform",
+			wantRepo:         "build",
+			wantRepoFullName: "platform/build",
+		},
+		{
+			name: "Gerrit authenticated HTTP clone url",
+			args: args{
+				cloneUrl:   "https://gerrit.company.com/a/tools.git",
+				apiUrl:     "https://gerrit.company.com",
+				repoSource: enums.Gerrit,
+			},
+			wantUrl:          "https://gerrit.company.com/plugins/gitiles/tools",
+			wantOrg:          "",
+			wantRepo:         "tools",
+			wantRepoFullName: "tools",
+		},
+		{
+			name: "CodeCommit HTTPS clone url",
+			args: args{
+				cloneUrl:   "https://git-codecommit.us-east-1.amazonaws.com/v1/repos/test-repo",
+				apiUrl:     "https://codecommit.us-east-1.amazonaws.com",
+				repoSource: enums.CodeCommit,
+			},
+			wantUrl:          "https://us-east-1.console.aws.amazon.com/codesuite/codecommit/repositories/test-repo",
+			wantOrg:          "",
+			wantRepo:         "test-repo",
+			wantRepoFullName: "test-repo",
+		},
+		{
+			name: "CodeCommit remote helper url",
+			args: args{
+				cloneUrl:   "codecommit::eu-west-1://test-profile@test-repo",
+				apiUrl:     "https://codecommit.eu-west-1.amazonaws.com",
+				repoSource: enums.CodeCommit,
+			},
+			wantUrl:          "https://eu-west-1.console.aws.amazon.com/codesuite/codecommit/repositories/test-repo",
+			wantOrg:          "",
+			wantRepo:         "test-repo",
+			wantRepoFullName: "test-repo",
+		},
+		{
+			name: "SourceHut HTTP clone url",
+			args: args{
+				cloneUrl:   "https://git.sr.ht/~test-user/test-repo",
+				apiUrl:     SourceHutApiUrl,
+				repoSource: enums.SourceHut,
+			},
+			wantUrl:          "https://git.sr.ht/~test-user/test-repo",
+			wantOrg:          "~test-user",
+			wantRepo:         "test-repo",
+			wantRepoFullName: "~test-user/test-repo",
+		},
+		{
+			name: "SourceHut SSH clone url",
+			args: args{
+				cloneUrl:   "git@git.sr.ht:~test-user/test-repo.git",
+				apiUrl:     SourceHutApiUrl,
+				repoSource: enums.SourceHut,
+			},
+			wantUrl:          "https://git.sr.ht/~test-user/test-repo",
+			wantOrg:          "~test-user",
+			wantRepo:         "test-repo",
+			wantRepoFullName: "~test-user/test-repo",
+		},
+		{
+			name: "Self-hosted Gitea HTTP clone url",
+			args: args{
+				cloneUrl:   "https://gitea.company.com/test-organization/test-repo.git",
+				apiUrl:     "https://gitea.company.com/api/v1",
+				repoSource: enums.Gitea,
+			},
+			wantUrl:          "https://gitea.company.com/test-organization/test-repo",
+			wantOrg:          "test-organization",
+			wantRepo:         "test-repo",
+			wantRepoFullName: "test-organization/test-repo",
+		},
 		{
 			name: "Cannot parse clone url",
 			args: args{
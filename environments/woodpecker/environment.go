@@ -0,0 +1,285 @@
+package woodpecker
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/utils"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/models"
+)
+
+const (
+	builder = "Woodpecker"
+
+	// Woodpecker shares the CI_ prefix with GitLab, it is detected by the values of CI and CI_SYSTEM_NAME
+	ciEnv         = "CI"
+	systemNameEnv = "CI_SYSTEM_NAME"
+	woodpecker    = "woodpecker"
+
+	systemUrlEnv      = "CI_SYSTEM_URL"
+	systemPlatformEnv = "CI_SYSTEM_PLATFORM"
+	machineEnv        = "CI_MACHINE"
+
+	repositoryUrlEnv      = "CI_REPO_URL"
+	repositoryCloneUrlEnv = "CI_REPO_CLONE_URL"
+	repositoryPathEnv     = "CI_WORKSPACE"
+
+	commitShaEnv         = "CI_COMMIT_SHA"
+	previousCommitShaEnv = "CI_PREV_COMMIT_SHA"
+	commitAuthorEnv      = "CI_COMMIT_AUTHOR"
+	commitAuthorEmailEnv = "CI_COMMIT_AUTHOR_EMAIL"
+	branchEnv            = "CI_COMMIT_BRANCH"
+
+	pullRequestEnv  = "CI_COMMIT_PULL_REQUEST"
+	sourceBranchEnv = "CI_COMMIT_SOURCE_BRANCH"
+	targetBranchEnv = "CI_COMMIT_TARGET_BRANCH"
+
+	pipelineNumberEnv = "CI_PIPELINE_NUMBER"
+	pipelineUrlEnv    = "CI_PIPELINE_URL"
+
+	workflowNameEnv   = "CI_WORKFLOW_NAME"
+	workflowNumberEnv = "CI_WORKFLOW_NUMBER"
+	stepNameEnv       = "CI_STEP_NAME"
+	stepNumberEnv     = "CI_STEP_NUMBER"
+	stepUrlEnv        = "CI_STEP_URL"
+
+	workflowsDir = ".woodpecker"
+)
+
+var (
+	// Woodpecker environment
+	Woodpecker = New()
+
+	pipelineFiles = []string{".woodpecker.yml", ".woodpecker.yaml"}
+)
+
+// Environment is the Woodpecker environment
+type Environment struct {
+	cache utils.ConfigurationCache
+}
+
+// New creates a Woodpecker environment with its own configuration cache
+func New() *Environment {
+	return &Environment{}
+}
+
+func (e *Environment) GetConfiguration() (*models.Configuration, error) {
+	return e.cache.Get(e.load)
+}
+
+// Refresh loads the configuration again and replaces the cached configuration
+func (e *Environment) Refresh() (*models.Configuration, error) {
+	return e.cache.Refresh(e.load)
+}
+
+// Reset clears the cached configuration, it is loaded again on the next GetConfiguration
+func (e *Environment) Reset() {
+	e.cache.Reset()
+}
+
+func (e *Environment) load() (*models.Configuration, error) {
+	return loadConfiguration(envsource.OS)
+}
+
+func (e *Environment) GetConfigurationFrom(src envsource.EnvSource) (*models.Configuration, error) {
+	return loadConfiguration(src)
+}
+
+func loadConfiguration(src envsource.EnvSource) (*models.Configuration, error) {
+	cloneUrl := utils.StripCredentialsFromUrl(src.Getenv(repositoryCloneUrlEnv))
+	source, apiUrl := utils.GetRepositorySource(cloneUrl)
+	repoUrl, org, repoName, repoFullName, err := utils.ParseDataFromCloneUrl(cloneUrl, apiUrl, source)
+	if err != nil {
+		return nil, err
+	}
+	if link := src.Getenv(repositoryUrlEnv); link != "" {
+		repoUrl = link
+	}
+
+	repoPath := src.Getenv(repositoryPathEnv)
+	commit := src.Getenv(commitShaEnv)
+	branch := src.Getenv(branchEnv)
+
+	pullRequest := models.PullRequest{}
+	if prNumber := src.Getenv(pullRequestEnv); prNumber != "" {
+		// on pull request pipelines CI_COMMIT_BRANCH is the target branch of the pull request
+		branch = src.Getenv(sourceBranchEnv)
+		pullRequest = models.PullRequest{
+			Id: prNumber,
+			SourceRef: models.Ref{
+				Branch: branch,
+				Sha:    commit,
+			},
+			TargetRef: models.Ref{
+				Branch: src.Getenv(targetBranchEnv),
+			},
+		}
+	}
+
+	pipelinePaths, warnings := getPipelinePaths(src, repoPath)
+	platformOS, platformArchitecture, _ := strings.Cut(src.Getenv(systemPlatformEnv), "/")
+	return &models.Configuration{
+		Url:             src.Getenv(systemUrlEnv),
+		SCMApiUrl:       apiUrl,
+		LocalPath:       repoPath,
+		CommitSha:       commit,
+		BeforeCommitSha: src.Getenv(previousCommitShaEnv),
+		Branch:          branch,
+		Repository: models.Repository{
+			Name:     repoName,
+			FullName: repoFullName,
+			Url:      repoUrl,
+			CloneUrl: cloneUrl,
+			Source:   source,
+		},
+		Organization: models.Entity{
+			Name: org,
+		},
+		Pipeline: models.Pipeline{
+			Entity: models.Entity{
+				Id:   src.Getenv(workflowNumberEnv),
+				Name: src.Getenv(workflowNameEnv),
+			},
+			Path: getWorkflowPath(src.Getenv(workflowNameEnv), repoPath, pipelinePaths),
+		},
+		Job: models.Entity{
+			Id:   src.Getenv(stepNumberEnv),
+			Name: src.Getenv(stepNameEnv),
+		},
+		Run: models.BuildRun{
+			BuildId:     src.Getenv(pipelineNumberEnv),
+			BuildNumber: src.Getenv(pipelineNumberEnv),
+		},
+		Runner: models.Runner{
+			Name:         src.Getenv(machineEnv),
+			OS:           platformOS,
+			Architecture: platformArchitecture,
+		},
+		PullRequest:   pullRequest,
+		Builder:       builder,
+		Pusher:        getPusher(src),
+		PipelinePaths: pipelinePaths,
+		Environment:   enums.Woodpecker,
+		ScmId:         utils.GenerateScmId(cloneUrl),
+		Warnings:      warnings,
+	}, nil
+}
+
+// getWorkflowPath returns the path of the workflow file relative to the repository, i.e. .woodpecker/build.yml for the build workflow
+func getWorkflowPath(workflowName string, rootDir string, pipelinePaths []string) string {
+	for _, path := range pipelinePaths {
+		base := filepath.Base(path)
+		if workflowName != "" && strings.TrimSuffix(base, filepath.Ext(base)) == workflowName {
+			return strings.TrimPrefix(path, rootDir+string(filepath.Separator))
+		}
+	}
+	if len(pipelinePaths) == 1 {
+		return strings.TrimPrefix(pipelinePaths[0], rootDir+string(filepath.Separator))
+	}
+	return ""
+}
+
+func getPusher(src envsource.EnvSource) models.Pusher {
+	if author := src.Getenv(commitAuthorEnv); author != "" {
+		return models.Pusher{
+			Username: author,
+			Email:    src.Getenv(commitAuthorEmailEnv),
+		}
+	}
+	return models.Pusher{
+		Username: utils.DetectPusherFrom(src),
+	}
+}
+
+func (e *Environment) GetStepLink() string {
+	if stepUrl := os.Getenv(stepUrlEnv); stepUrl != "" {
+		return stepUrl
+	}
+	return os.Getenv(pipelineUrlEnv)
+}
+
+func (e *Environment) GetBuildLink() string {
+	return os.Getenv(pipelineUrlEnv)
+}
+
+func (e *Environment) GetFileLink(filename string, branch string, commit string) string {
+	return ""
+}
+
+func (e *Environment) GetFileLineLink(filename string, branch string, commit string, startLine int, endLine int) string {
+	return ""
+}
+
+// IsCurrentEnvironment checks that CI or CI_SYSTEM_NAME is woodpecker,
+// the other CI_ variables are also set by GitLab and are not used for detection
+func (e *Environment) IsCurrentEnvironment() bool {
+	return os.Getenv(ciEnv) == woodpecker || os.Getenv(systemNameEnv) == woodpecker
+}
+
+// DetectionVariables returns the variables used by IsCurrentEnvironment
+func (e *Environment) DetectionVariables() []string {
+	return []string{ciEnv, systemNameEnv}
+}
+
+// ExpectedFields returns the configuration fields the environment is expected to fill and the variables they are filled from
+func (e *Environment) ExpectedFields() []models.ExpectedField {
+	return []models.ExpectedField{
+		{Field: "repository.url", SourceEnv: repositoryUrlEnv, Severity: models.SeverityCritical},
+		{Field: "repository.cloneUrl", SourceEnv: repositoryCloneUrlEnv, Severity: models.SeverityCritical},
+		{Field: "commitSha", SourceEnv: commitShaEnv, Severity: models.SeverityCritical},
+		{Field: "branch", SourceEnv: branchEnv, Severity: models.SeverityWarning},
+		{Field: "url", SourceEnv: systemUrlEnv, Severity: models.SeverityWarning},
+		{Field: "localPath", SourceEnv: repositoryPathEnv, Severity: models.SeverityWarning},
+		{Field: "pipeline.name", SourceEnv: workflowNameEnv, Severity: models.SeverityWarning},
+		{Field: "job.name", SourceEnv: stepNameEnv, Severity: models.SeverityWarning},
+		{Field: "run.buildNumber", SourceEnv: pipelineNumberEnv, Severity: models.SeverityWarning},
+		{Field: "runner.name", SourceEnv: machineEnv, Severity: models.SeverityWarning},
+	}
+}
+
+func (e *Environment) Name() string {
+	return "woodpecker"
+}
+
+// getPipelinePaths returns the .woodpecker.yml file, or the workflow files of the .woodpecker directory
+func getPipelinePaths(src envsource.EnvSource, rootDir string) ([]string, []models.Warning) {
+	paths := make([]string, 0)
+	var warnings []models.Warning
+
+	for _, file := range pipelineFiles {
+		path := filepath.Join(rootDir, file)
+		if _, err := envsource.Stat(src, path); err == nil {
+			paths = append(paths, path)
+		}
+	}
+
+	dir := filepath.Join(rootDir, workflowsDir)
+	if _, err := envsource.Stat(src, dir); err != nil {
+		return paths, warnings
+	}
+	err := envsource.Walk(src, dir, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			warnings = append(warnings, models.NewWarning(models.PipelinePathsWarning, err, "failed to search %s for pipeline files", path))
+			return nil
+		}
+		if info.IsDir() {
+			if path != dir {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) == ".yml" || filepath.Ext(path) == ".yaml" {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		warnings = append(warnings, models.NewWarning(models.PipelinePathsWarning, err, "failed to search %s for pipeline files", dir))
+	}
+
+	return paths, warnings
+}
@@ -0,0 +1,231 @@
+package woodpecker
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/testutils"
+	"github.com/argonsecurity/go-environments/models"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	woodpeckerPushEnvsFilePath = "testdata/woodpecker-github-push-env.json"
+	woodpeckerPrEnvsFilePath   = "testdata/woodpecker-github-pr-env.json"
+	testRepoPath               = "/tmp/woodpecker/repo"
+	testRepoUrl                = "https://github.com/test-organization/test-repo"
+	testRepoCloneUrl           = fmt.Sprintf("%s%s", testRepoUrl, ".git")
+	testdataPath               = "../woodpecker/testdata/repo"
+	testPipelinePaths          = []string{"/tmp/woodpecker/repo/.woodpecker/build.yml", "/tmp/woodpecker/repo/.woodpecker/release.yaml"}
+)
+
+func Test_environment_GetConfiguration(t *testing.T) {
+	tests := []struct {
+		name         string
+		envsFilePath string
+		want         *models.Configuration
+		wantErr      bool
+	}{
+		{
+			name:         "Woodpecker push configuration",
+			envsFilePath: woodpeckerPushEnvsFilePath,
+			want: &models.Configuration{
+				Url:             "https://ci.example.com",
+				SCMApiUrl:       "https://api.github.com",
+				LocalPath:       testRepoPath,
+				CommitSha:       "kcy8v2oazy4acuo1475rgtzg0nh403p23vw812lv",
+				BeforeCommitSha: "2c6e3880fd94ddb7ef72d34e683cdc0c47bec6e6",
+				Branch:          "main",
+				Repository: models.Repository{
+					Name:     "test-repo",
+					FullName: "test-organization/test-repo",
+					Url:      testRepoUrl,
+					CloneUrl: testRepoCloneUrl,
+					Source:   enums.Github,
+				},
+				Organization: models.Entity{
+					Name: "test-organization",
+				},
+				Pipeline: models.Pipeline{
+					Entity: models.Entity{
+						Id:   "1",
+						Name: "build",
+					},
+					Path: ".woodpecker/build.yml",
+				},
+				Job: models.Entity{
+					Id:   "1",
+					Name: "test",
+				},
+				Run: models.BuildRun{
+					BuildId:     "42",
+					BuildNumber: "42",
+				},
+				Runner: models.Runner{
+					Name:         "agent-1",
+					OS:           "linux",
+					Architecture: "amd64",
+				},
+				Pusher: models.Pusher{
+					Username: "test-user",
+					Email:    "test-user@example.com",
+				},
+				Builder:       "Woodpecker",
+				PipelinePaths: testPipelinePaths,
+				Environment:   enums.Woodpecker,
+				ScmId:         "8891c0db39f3064732cc1b4ac02c9b9f",
+			},
+		},
+		{
+			name:         "Woodpecker pull request configuration",
+			envsFilePath: woodpeckerPrEnvsFilePath,
+			want: &models.Configuration{
+				Url:             "https://ci.example.com",
+				SCMApiUrl:       "https://api.github.com",
+				LocalPath:       testRepoPath,
+				CommitSha:       "y2nc0ns6qk8qs4dfbsvnjrf2o8dvmrfrthmx9nbl",
+				BeforeCommitSha: "kcy8v2oazy4acuo1475rgtzg0nh403p23vw812lv",
+				Branch:          "feature/woodpecker",
+				Repository: models.Repository{
+					Name:     "test-repo",
+					FullName: "test-organization/test-repo",
+					Url:      testRepoUrl,
+					CloneUrl: testRepoCloneUrl,
+					Source:   enums.Github,
+				},
+				Organization: models.Entity{
+					Name: "test-organization",
+				},
+				Pipeline: models.Pipeline{
+					Entity: models.Entity{
+						Id:   "2",
+						Name: "release",
+					},
+					Path: ".woodpecker/release.yaml",
+				},
+				Job: models.Entity{
+					Id:   "1",
+					Name: "release",
+				},
+				Run: models.BuildRun{
+					BuildId:     "43",
+					BuildNumber: "43",
+				},
+				Runner: models.Runner{
+					Name:         "agent-2",
+					OS:           "linux",
+					Architecture: "arm64",
+				},
+				PullRequest: models.PullRequest{
+					Id: "7",
+					SourceRef: models.Ref{
+						Branch: "feature/woodpecker",
+						Sha:    "y2nc0ns6qk8qs4dfbsvnjrf2o8dvmrfrthmx9nbl",
+					},
+					TargetRef: models.Ref{
+						Branch: "main",
+					},
+				},
+				Builder:       "Woodpecker",
+				PipelinePaths: testPipelinePaths,
+				Environment:   enums.Woodpecker,
+				ScmId:         "8891c0db39f3064732cc1b4ac02c9b9f",
+			},
+		},
+		{
+			name:         "Missing clone url",
+			envsFilePath: "",
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := prepareTest(t, tt.envsFilePath)
+			got, err := e.GetConfiguration()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("environment.GetConfiguration() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_environment_GetStepLink(t *testing.T) {
+	tests := []struct {
+		name         string
+		envsFilePath string
+		want         string
+	}{
+		{
+			name:         "Woodpecker environment",
+			envsFilePath: woodpeckerPushEnvsFilePath,
+			want:         "https://ci.example.com/repos/1/pipeline/42/1",
+		},
+		{
+			name:         "Woodpecker environment without step url",
+			envsFilePath: woodpeckerPrEnvsFilePath,
+			want:         "https://ci.example.com/repos/1/pipeline/43",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := prepareTest(t, tt.envsFilePath)
+			if got := e.GetStepLink(); got != tt.want {
+				t.Errorf("environment.GetStepLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_environment_GetBuildLink(t *testing.T) {
+	e := prepareTest(t, woodpeckerPushEnvsFilePath)
+	assert.Equal(t, "https://ci.example.com/repos/1/pipeline/42", e.GetBuildLink())
+}
+
+func Test_environment_IsCurrentEnvironment(t *testing.T) {
+	tests := []struct {
+		name         string
+		envsFilePath string
+		want         bool
+	}{
+		{
+			name:         "Woodpecker environment",
+			envsFilePath: woodpeckerPushEnvsFilePath,
+			want:         true,
+		},
+		{
+			name:         "GitLab environment",
+			envsFilePath: "../gitlab/testdata/gitlab-ci-main-env.json",
+			want:         false,
+		},
+		{
+			name:         "Drone environment",
+			envsFilePath: "../drone/testdata/drone-github-push-env.json",
+			want:         false,
+		},
+		{
+			name:         "Not Woodpecker environment",
+			envsFilePath: "",
+			want:         false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := prepareTest(t, tt.envsFilePath)
+			if got := e.IsCurrentEnvironment(); got != tt.want {
+				t.Errorf("environment.IsCurrentEnvironment() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func prepareTest(t *testing.T, envsFilePath string) *Environment {
+	e := New()
+	testRepoCleanup := testutils.PrepareTestGitRepository(testRepoPath, testRepoCloneUrl, testdataPath)
+	t.Cleanup(testRepoCleanup)
+	envCleanup := testutils.SetEnvsFromFile(envsFilePath)
+	t.Cleanup(envCleanup)
+	return e
+}
@@ -1,15 +1,36 @@
 package environments
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/environments/argo"
 	"github.com/argonsecurity/go-environments/environments/azure"
+	"github.com/argonsecurity/go-environments/environments/bamboo"
 	"github.com/argonsecurity/go-environments/environments/bitbucket"
+	"github.com/argonsecurity/go-environments/environments/buildkite"
+	"github.com/argonsecurity/go-environments/environments/cloudbuild"
+	"github.com/argonsecurity/go-environments/environments/codebuild"
+	"github.com/argonsecurity/go-environments/environments/codefresh"
+	"github.com/argonsecurity/go-environments/environments/concourse"
+	"github.com/argonsecurity/go-environments/environments/drone"
+	"github.com/argonsecurity/go-environments/environments/gitea"
 	"github.com/argonsecurity/go-environments/environments/github"
 	"github.com/argonsecurity/go-environments/environments/gitlab"
+	"github.com/argonsecurity/go-environments/environments/harness"
 	"github.com/argonsecurity/go-environments/environments/jenkins"
 	"github.com/argonsecurity/go-environments/environments/localhost"
+	"github.com/argonsecurity/go-environments/environments/semaphore"
+	"github.com/argonsecurity/go-environments/environments/teamcity"
 	"github.com/argonsecurity/go-environments/environments/testutils"
+	"github.com/argonsecurity/go-environments/environments/testutils/mocks"
+	"github.com/argonsecurity/go-environments/environments/travis"
+	"github.com/argonsecurity/go-environments/environments/utils/envsource"
+	"github.com/argonsecurity/go-environments/environments/woodpecker"
+	"github.com/argonsecurity/go-environments/models"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -95,6 +116,16 @@ func TestDetectEnvironment(t *testing.T) {
 			envsFilePath: "environments/github/testdata/github-workflows-main-env.json",
 			want:         github.Github,
 		},
+		{
+			name:         "Gitea environment",
+			envsFilePath: "environments/gitea/testdata/gitea-actions-main-env.json",
+			want:         gitea.Gitea,
+		},
+		{
+			name:         "Forgejo environment",
+			envsFilePath: "environments/gitea/testdata/forgejo-actions-pr-env.json",
+			want:         gitea.Gitea,
+		},
 		{
 			name:         "GitLab environment",
 			envsFilePath: "environments/gitlab/testdata/gitlab-ci-main-env.json",
@@ -115,6 +146,81 @@ func TestDetectEnvironment(t *testing.T) {
 			envsFilePath: "environments/jenkins/testdata/jenkins-github-main-full-env.json",
 			want:         jenkins.Jenkins,
 		},
+		{
+			name:         "Bamboo environment",
+			envsFilePath: "environments/bamboo/testdata/bamboo-bitbucket-server-main-env.json",
+			want:         bamboo.Bamboo,
+		},
+		{
+			name:         "Argo environment",
+			envsFilePath: "environments/argo/testdata/argo-artifact-env.json",
+			want:         argo.Argo,
+		},
+		{
+			name:         "Semaphore environment",
+			envsFilePath: "environments/semaphore/testdata/semaphore-github-main-env.json",
+			want:         semaphore.Semaphore,
+		},
+		{
+			name:         "Codefresh environment",
+			envsFilePath: "environments/codefresh/testdata/codefresh-github-main-env.json",
+			want:         codefresh.Codefresh,
+		},
+		{
+			name:         "Concourse environment",
+			envsFilePath: "environments/concourse/testdata/concourse-job-env.json",
+			want:         concourse.Concourse,
+		},
+		{
+			name:         "Harness environment with Drone compatibility variables",
+			envsFilePath: "environments/harness/testdata/harness-github-main-env.json",
+			want:         harness.Harness,
+		},
+		{
+			name:         "Travis environment",
+			envsFilePath: "environments/travis/testdata/travis-github-main-env.json",
+			want:         travis.Travis,
+		},
+		{
+			name:         "Buildkite environment",
+			envsFilePath: "environments/buildkite/testdata/buildkite-github-main-env.json",
+			want:         buildkite.Buildkite,
+		},
+		{
+			name:         "CodeBuild environment",
+			envsFilePath: "environments/codebuild/testdata/codebuild-github-push-env.json",
+			want:         codebuild.CodeBuild,
+		},
+		{
+			name:         "Cloud Build environment",
+			envsFilePath: "environments/cloudbuild/testdata/cloudbuild-github-main-env.json",
+			want:         cloudbuild.CloudBuild,
+		},
+		{
+			name:         "TeamCity environment",
+			envsFilePath: "environments/teamcity/testdata/teamcity-main-env.json",
+			want:         teamcity.TeamCity,
+		},
+		{
+			name:         "Drone environment",
+			envsFilePath: "environments/drone/testdata/drone-github-push-env.json",
+			want:         drone.Drone,
+		},
+		{
+			name:         "Woodpecker environment",
+			envsFilePath: "environments/woodpecker/testdata/woodpecker-github-push-env.json",
+			want:         woodpecker.Woodpecker,
+		},
+		{
+			name:         "Woodpecker environment with Drone compatibility variables",
+			envsFilePath: "environments/woodpecker/testdata/woodpecker-github-pr-env.json",
+			want:         woodpecker.Woodpecker,
+		},
+		{
+			name:         "Jenkins environment with Cloud Build variables",
+			envsFilePath: "environments/cloudbuild/testdata/jenkins-with-project-id-env.json",
+			want:         jenkins.Jenkins,
+		},
 		{
 			name:         "Other environment",
 			envsFilePath: "",
@@ -189,3 +295,423 @@ func TestGetOrDetectEnvironment(t *testing.T) {
 		})
 	}
 }
+
+type testEnvironment struct {
+	name      string
+	isCurrent bool
+}
+
+func (e testEnvironment) GetConfiguration() (*models.Configuration, error) {
+	return &models.Configuration{Environment: enums.Source(e.name)}, nil
+}
+func (e testEnvironment) GetBuildLink() string { return "" }
+func (e testEnvironment) GetStepLink() string  { return "" }
+func (e testEnvironment) GetFileLink(filename string, ref string, commit string) string {
+	return ""
+}
+func (e testEnvironment) GetFileLineLink(filename string, ref string, commit string, startLine int, endLine int) string {
+	return ""
+}
+func (e testEnvironment) Name() string               { return e.name }
+func (e testEnvironment) IsCurrentEnvironment() bool { return e.isCurrent }
+
+func TestRegisterEnvironment(t *testing.T) {
+	inhouse := testEnvironment{name: "inhouse", isCurrent: true}
+	notCurrent := testEnvironment{name: "other", isCurrent: false}
+	githubOverride := testEnvironment{name: "github", isCurrent: false}
+
+	tests := []struct {
+		name         string
+		register     map[enums.Source]Environment
+		envsFilePath string
+		getName      string
+		wantGet      Environment
+		wantDetect   Environment
+	}{
+		{
+			name:       "Registered environment is returned by name and detected",
+			register:   map[enums.Source]Environment{"inhouse": inhouse},
+			getName:    "inhouse",
+			wantGet:    inhouse,
+			wantDetect: inhouse,
+		},
+		{
+			name:         "Registered environment takes precedence over built-in detection",
+			register:     map[enums.Source]Environment{"inhouse": inhouse},
+			envsFilePath: "environments/github/testdata/github-workflows-main-env.json",
+			getName:      "github",
+			wantGet:      github.Github,
+			wantDetect:   inhouse,
+		},
+		{
+			name:       "Registered environment that is not current is skipped",
+			register:   map[enums.Source]Environment{"other": notCurrent},
+			getName:    "other",
+			wantGet:    notCurrent,
+			wantDetect: localhost.Localhost,
+		},
+		{
+			name:         "Registered environment overrides built-in environment",
+			register:     map[enums.Source]Environment{enums.Github: githubOverride},
+			envsFilePath: "environments/github/testdata/github-workflows-main-env.json",
+			getName:      "github",
+			wantGet:      githubOverride,
+			wantDetect:   localhost.Localhost,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for source, env := range tt.register {
+				RegisterEnvironment(source, env)
+				source := source
+				t.Cleanup(func() { UnregisterEnvironment(source) })
+			}
+			envCleanup := testutils.SetEnvsFromFile(tt.envsFilePath)
+			t.Cleanup(envCleanup)
+
+			got, err := GetEnvironment(tt.getName)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantGet, got)
+			assert.Equal(t, tt.wantDetect, DetectEnvironment())
+
+			got, err = GetOrDetectEnvironment("")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantDetect, got)
+		})
+	}
+}
+
+func TestUnregisterEnvironment(t *testing.T) {
+	RegisterEnvironment(enums.Github, testEnvironment{name: "github"})
+	UnregisterEnvironment(enums.Github)
+
+	got, err := GetEnvironment("github")
+	assert.NoError(t, err)
+	assert.Equal(t, github.Github, got)
+
+	RegisterEnvironment("inhouse", testEnvironment{name: "inhouse", isCurrent: true})
+	UnregisterEnvironment("inhouse")
+
+	_, err = GetEnvironment("inhouse")
+	assert.Error(t, err)
+	assert.Equal(t, localhost.Localhost, DetectEnvironment())
+}
+
+func TestGetConfigurationFrom(t *testing.T) {
+	gitClient := (&mocks.MockGitClient{}).SetError(errors.New("not a git repository"))
+	src := envsource.New(testutils.LoadEnvsFromFile("environments/codefresh/testdata/codefresh-bitbucket-main-env.json")).WithGitClient(gitClient)
+
+	got, err := GetConfigurationFrom(codefresh.New(), src)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://bitbucket.org/test-org/test-repo.git", got.Repository.CloneUrl)
+
+	got, err = GetConfigurationFrom(testEnvironment{name: "inhouse"}, src)
+	assert.NoError(t, err)
+	assert.Equal(t, &models.Configuration{Environment: "inhouse"}, got)
+}
+
+func TestDetectEnvironmentWithReport(t *testing.T) {
+	tests := []struct {
+		name          string
+		envsFilePaths []string
+		want          Environment
+		wantSource    enums.Source
+		wantMatched   map[enums.Source][]string
+	}{
+		{
+			name:          "GitHub environment",
+			envsFilePaths: []string{"environments/github/testdata/github-workflows-main-env.json"},
+			want:          github.Github,
+			wantSource:    enums.Github,
+			wantMatched: map[enums.Source][]string{
+				enums.Github: {"GITHUB_WORKFLOW"},
+			},
+		},
+		{
+			name: "GitHub workflow in an image with Jenkins variables",
+			envsFilePaths: []string{
+				"environments/jenkins/testdata/jenkins-github-main-full-env.json",
+				"environments/github/testdata/github-workflows-main-env.json",
+			},
+			want:       github.Github,
+			wantSource: enums.Github,
+			wantMatched: map[enums.Source][]string{
+				enums.Github:  {"GITHUB_WORKFLOW"},
+				enums.Jenkins: {"JENKINS_HOME", "JENKINS_URL"},
+			},
+		},
+		{
+			name: "Concourse task in an image with Jenkins variables",
+			envsFilePaths: []string{
+				"environments/jenkins/testdata/jenkins-github-main-full-env.json",
+				"environments/concourse/testdata/concourse-job-env.json",
+			},
+			want:       concourse.Concourse,
+			wantSource: enums.Concourse,
+			wantMatched: map[enums.Source][]string{
+				enums.Concourse: {"ATC_EXTERNAL_URL"},
+				enums.Jenkins:   {"JENKINS_HOME", "JENKINS_URL"},
+			},
+		},
+		{
+			name:          "No environment",
+			envsFilePaths: []string{},
+			want:          localhost.Localhost,
+			wantSource:    enums.Localhost,
+			wantMatched:   map[enums.Source][]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, envsFilePath := range tt.envsFilePaths {
+				envCleanup := testutils.SetEnvsFromFile(envsFilePath)
+				t.Cleanup(envCleanup)
+			}
+
+			got := DetectEnvironmentWithReport()
+			assert.Equal(t, tt.want, got.Environment)
+			assert.Equal(t, tt.wantSource, got.Source)
+			assert.Len(t, got.Candidates, len(detectionOrder))
+
+			matched := map[enums.Source][]string{}
+			for i, candidate := range got.Candidates {
+				assert.Equal(t, detectionOrder[i], candidate.Source)
+				if candidate.Matched {
+					matched[candidate.Source] = candidate.MatchedVariables
+				}
+			}
+			assert.Equal(t, tt.wantMatched, matched)
+		})
+	}
+}
+
+func TestDetectionOrder(t *testing.T) {
+	for source := range environmentMapping {
+		if source == enums.Localhost {
+			continue
+		}
+		assert.Contains(t, detectionOrder, source)
+	}
+}
+
+func TestGetCommitLink(t *testing.T) {
+	tests := []struct {
+		name          string
+		source        enums.Source
+		repositoryURL string
+		want          string
+	}{
+		{
+			name:          "GitHub Server",
+			source:        enums.GithubServer,
+			repositoryURL: "https://github.company.com/org/repo",
+			want:          "https://github.company.com/org/repo/commit/0a1b2c",
+		},
+		{
+			name:          "GitLab with subgroups",
+			source:        enums.Gitlab,
+			repositoryURL: "https://gitlab.com/group/subgroup/repo",
+			want:          "https://gitlab.com/group/subgroup/repo/-/commit/0a1b2c",
+		},
+		{
+			name:          "Azure DevOps Server",
+			source:        enums.AzureServer,
+			repositoryURL: "https://azure.company.com/collection/project/_git/repo",
+			want:          "https://azure.company.com/collection/project/_git/repo/commit/0a1b2c",
+		},
+		{
+			name:          "Bitbucket Server",
+			source:        enums.BitbucketServer,
+			repositoryURL: "https://bitbucket.company.com/projects/PROJ/repos/repo",
+			want:          "https://bitbucket.company.com/projects/PROJ/repos/repo/commits/0a1b2c",
+		},
+		{
+			name:          "Unsupported source",
+			source:        enums.Jenkins,
+			repositoryURL: "https://jenkins.company.com",
+			want:          "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, GetCommitLink(tt.source, tt.repositoryURL, "0a1b2c"))
+		})
+	}
+}
+
+func TestLinkBuilder(t *testing.T) {
+	var env Environment = gitlab.New()
+	_, ok := env.(LinkBuilder)
+	assert.True(t, ok)
+
+	env = concourse.New()
+	_, ok = env.(LinkBuilder)
+	assert.False(t, ok)
+}
+
+func TestGetCompareLink(t *testing.T) {
+	assert.Equal(t, "https://bitbucket.org/org/repo/branches/compare/0a1b2c%0D9f8e7d", GetCompareLink(enums.Bitbucket, "https://bitbucket.org/org/repo", "9f8e7d", "0a1b2c"))
+	assert.Equal(t, "", GetCompareLink(enums.Localhost, "https://bitbucket.org/org/repo", "9f8e7d", "0a1b2c"))
+}
+
+func TestGetPullRequestLink(t *testing.T) {
+	assert.Equal(t, "https://gitlab.company.com/group/subgroup/repo/-/merge_requests/12", GetPullRequestLink(enums.GitlabServer, "https://gitlab.company.com/group/subgroup/repo", "12"))
+	assert.Equal(t, "", GetPullRequestLink(enums.Localhost, "https://gitlab.company.com/group/subgroup/repo", "12"))
+}
+
+func TestGetRefLink(t *testing.T) {
+	assert.Equal(t, "https://dev.azure.com/org/project/_git/repo?version=GTv1.0", GetRefLink(enums.Azure, "https://dev.azure.com/org/project/_git/repo", "refs/tags/v1.0"))
+	assert.Equal(t, "", GetRefLink(enums.Localhost, "https://dev.azure.com/org/project/_git/repo", "refs/tags/v1.0"))
+}
+
+func TestParseFileLink(t *testing.T) {
+	tests := []struct {
+		name              string
+		link              string
+		wantSource        enums.Source
+		wantRepositoryURL string
+		wantFilename      string
+		wantRef           string
+		wantStartLine     int
+		wantEndLine       int
+		wantErr           bool
+	}{
+		{
+			name:              "GitHub lines",
+			link:              "https://github.com/org/repo/blob/0a1b2c3d4e5f60718293a4b5c6d7e8f901234567/path/to/file#L10-L20",
+			wantSource:        enums.Github,
+			wantRepositoryURL: "https://github.com/org/repo",
+			wantFilename:      "path/to/file",
+			wantRef:           "0a1b2c3d4e5f60718293a4b5c6d7e8f901234567",
+			wantStartLine:     10,
+			wantEndLine:       20,
+		},
+		{
+			name:              "GitHub single line",
+			link:              "https://github.com/org/repo/blob/main/path/to/file#L10",
+			wantSource:        enums.Github,
+			wantRepositoryURL: "https://github.com/org/repo",
+			wantFilename:      "path/to/file",
+			wantRef:           "refs/heads/main",
+			wantStartLine:     10,
+			wantEndLine:       10,
+		},
+		{
+			name:              "Bitbucket Server lines",
+			link:              "https://bitbucket.company.com/projects/PROJ/repos/repo/browse/path/to/file?at=main#5-8",
+			wantSource:        enums.BitbucketServer,
+			wantRepositoryURL: "https://bitbucket.company.com/projects/PROJ/repos/repo",
+			wantFilename:      "path/to/file",
+			wantRef:           "refs/heads/main",
+			wantStartLine:     5,
+			wantEndLine:       8,
+		},
+		{
+			name:              "Azure DevOps branch without lines",
+			link:              "https://dev.azure.com/org/project/_git/repo?path=path%2Fto%2Ffile&version=GBmain&_a=contents",
+			wantSource:        enums.Azure,
+			wantRepositoryURL: "https://dev.azure.com/org/project/_git/repo",
+			wantFilename:      "path/to/file",
+			wantRef:           "refs/heads/main",
+		},
+		{
+			name:    "Repository link",
+			link:    "https://github.com/org/repo",
+			wantErr: true,
+		},
+		{
+			name:    "Azure DevOps link without version",
+			link:    "https://dev.azure.com/org/project/_git/repo?path=path%2Fto%2Ffile",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, repositoryURL, filename, ref, startLine, endLine, err := ParseFileLink(tt.link)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, enums.Unknown, source)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantSource, source)
+			assert.Equal(t, tt.wantRepositoryURL, repositoryURL)
+			assert.Equal(t, tt.wantFilename, filename)
+			assert.Equal(t, tt.wantRef, ref)
+			assert.Equal(t, tt.wantStartLine, startLine)
+			assert.Equal(t, tt.wantEndLine, endLine)
+		})
+	}
+}
+
+// TestParseFileLinkRoundTrip parses the links of GetFileLineLink for every source, ref and line range,
+// and checks that the parsed values build the same link again
+func TestParseFileLinkRoundTrip(t *testing.T) {
+	repositoryURLs := map[enums.Source]string{
+		enums.Github:          "https://github.com/org/repo",
+		enums.GithubServer:    "https://github.company.com/org/repo",
+		enums.Gitlab:          "https://gitlab.com/group/subgroup/repo",
+		enums.GitlabServer:    "https://gitlab.company.com/group/subgroup/repo",
+		enums.Azure:           "https://dev.azure.com/org/project/_git/repo",
+		enums.AzureServer:     "https://azure.company.com/collection/project/_git/repo",
+		enums.Bitbucket:       "https://bitbucket.org/workspace/repo",
+		enums.BitbucketServer: "https://bitbucket.company.com/projects/PROJ/repos/repo",
+		enums.Gitea:           "https://gitea.com/org/repo",
+		enums.Gerrit:          "https://gerrit.company.com/plugins/gitiles/platform/build",
+		enums.CodeCommit:      "https://us-east-1.console.aws.amazon.com/codesuite/codecommit/repositories/repo",
+		enums.SourceHut:       "https://git.sr.ht/~user/repo",
+	}
+	filenames := []string{"README.md", "path/to/file.go"}
+	refs := []struct {
+		branch string
+		commit string
+	}{
+		{branch: "main"},
+		{commit: "0a1b2c3d4e5f60718293a4b5c6d7e8f901234567"},
+		{branch: "feature/x"},
+	}
+	branches := []string{"main", "feature", "refs/heads/feature/x"}
+	lines := [][2]int{{0, 0}, {5, 0}, {5, 5}, {5, 8}}
+
+	for source, repositoryURL := range repositoryURLs {
+		for _, filename := range filenames {
+			for _, ref := range refs {
+				for _, line := range lines {
+					link := GetFileLineLink(source, repositoryURL, filename, ref.branch, ref.commit, line[0], line[1])
+					t.Run(fmt.Sprintf("%s %s", source, link), func(t *testing.T) {
+						gotSource, gotRepositoryURL, gotFilename, gotRef, gotStartLine, gotEndLine, err := ParseFileLinkWithBranches(link, branches)
+						assert.NoError(t, err)
+						assert.Equal(t, source, gotSource)
+						assert.Equal(t, repositoryURL, gotRepositoryURL)
+						assert.Equal(t, filename, gotFilename)
+						assert.Equal(t, line[0], gotStartLine)
+
+						wantRef := ref.commit
+						if ref.branch != "" {
+							wantRef = fmt.Sprintf("refs/heads/%s", ref.branch)
+						}
+						assert.Equal(t, wantRef, gotRef)
+
+						branch := strings.TrimPrefix(gotRef, "refs/heads/")
+						commit := ""
+						if ref.commit != "" {
+							branch, commit = "", gotRef
+						}
+						assert.Equal(t, link, GetFileLineLink(gotSource, gotRepositoryURL, gotFilename, branch, commit, gotStartLine, gotEndLine))
+
+						// without the branches of the repository, a branch with a slash in the path of the link is read up to the first slash
+						_, _, gotFilename, gotRef, _, _, err = ParseFileLink(link)
+						assert.NoError(t, err)
+						if isPathRefSource(source) && ref.branch == "feature/x" {
+							assert.Equal(t, "refs/heads/feature", gotRef)
+							assert.Equal(t, fmt.Sprintf("x/%s", filename), gotFilename)
+						} else {
+							assert.Equal(t, wantRef, gotRef)
+							assert.Equal(t, filename, gotFilename)
+						}
+					})
+				}
+			}
+		}
+	}
+}
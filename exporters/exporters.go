@@ -0,0 +1,189 @@
+package exporters
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/argonsecurity/go-environments/models"
+)
+
+// DefaultPrefix is the prefix of the exported variable names, i.e. ARGON_REPO_URL
+const DefaultPrefix = "ARGON_"
+
+type Format string
+
+const (
+	// DotenvFormat renders a .env file
+	DotenvFormat Format = "dotenv"
+	// GithubFormat renders the GITHUB_OUTPUT/GITHUB_ENV file syntax
+	GithubFormat Format = "github"
+	// AzureFormat renders Azure Pipelines ##vso[task.setvariable] logging commands
+	AzureFormat Format = "azure"
+	// GitlabDotenvFormat renders a GitLab dotenv report artifact
+	GitlabDotenvFormat Format = "gitlab-dotenv"
+)
+
+var (
+	// Formats lists the supported formats
+	Formats = []Format{DotenvFormat, GithubFormat, AzureFormat, GitlabDotenvFormat}
+
+	variableNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	azureEscaper       = strings.NewReplacer("%", "%AZP25", "\r", "%0D", "\n", "%0A")
+	dotenvEscaper      = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "$", `\$`)
+)
+
+// Variable is a single exported value
+type Variable struct {
+	Name  string
+	Value string
+}
+
+// Exporter renders a configuration as variables for later shell steps
+type Exporter struct {
+	Prefix string
+}
+
+// New creates an exporter with the given variable name prefix
+func New(prefix string) *Exporter {
+	return &Exporter{
+		Prefix: prefix,
+	}
+}
+
+// Variables returns the exported variables of the configuration, in a fixed order
+func (e *Exporter) Variables(configuration *models.Configuration) []Variable {
+	variables := []Variable{
+		{"ENVIRONMENT", string(configuration.Environment)},
+		{"URL", configuration.Url},
+		{"SCM_API_URL", configuration.SCMApiUrl},
+		{"BUILDER", configuration.Builder},
+		{"LOCAL_PATH", configuration.LocalPath},
+		{"COMMIT_SHA", configuration.CommitSha},
+		{"BEFORE_COMMIT_SHA", configuration.BeforeCommitSha},
+		{"BRANCH", configuration.Branch},
+		{"PROJECT_ID", configuration.ProjectId},
+		{"NAMESPACE", configuration.Namespace},
+		{"REPO_ID", configuration.Repository.Id},
+		{"REPO_NAME", configuration.Repository.Name},
+		{"REPO_FULL_NAME", configuration.Repository.FullName},
+		{"REPO_URL", configuration.Repository.Url},
+		{"REPO_CLONE_URL", configuration.Repository.CloneUrl},
+		{"REPO_SOURCE", string(configuration.Repository.Source)},
+		{"SCM_ID", configuration.ScmId},
+		{"PIPELINE_ID", configuration.Pipeline.Id},
+		{"PIPELINE_NAME", configuration.Pipeline.Name},
+		{"PIPELINE_PATH", configuration.Pipeline.Path},
+		{"JOB_ID", configuration.Job.Id},
+		{"JOB_NAME", configuration.Job.Name},
+		{"RUN_BUILD_ID", configuration.Run.BuildId},
+		{"RUN_BUILD_NUMBER", configuration.Run.BuildNumber},
+		{"RUNNER_ID", configuration.Runner.Id},
+		{"RUNNER_NAME", configuration.Runner.Name},
+		{"RUNNER_OS", configuration.Runner.OS},
+		{"RUNNER_ARCH", configuration.Runner.Architecture},
+		{"PR_ID", configuration.PullRequest.Id},
+		{"PR_URL", configuration.PullRequest.Url},
+		{"PR_SOURCE_BRANCH", configuration.PullRequest.SourceRef.Branch},
+		{"PR_SOURCE_SHA", configuration.PullRequest.SourceRef.Sha},
+		{"PR_TARGET_BRANCH", configuration.PullRequest.TargetRef.Branch},
+		{"PR_TARGET_SHA", configuration.PullRequest.TargetRef.Sha},
+		{"ORG_ID", configuration.Organization.Id},
+		{"ORG_NAME", configuration.Organization.Name},
+		{"PUSHER_USERNAME", configuration.Pusher.Username},
+		{"PUSHER_EMAIL", configuration.Pusher.Email},
+	}
+
+	for i := range variables {
+		variables[i].Name = e.Prefix + variables[i].Name
+	}
+	return variables
+}
+
+// Write renders the configuration in the given format
+func (e *Exporter) Write(w io.Writer, format Format, configuration *models.Configuration) error {
+	switch format {
+	case DotenvFormat:
+		return e.WriteDotenv(w, configuration)
+	case GithubFormat:
+		return e.WriteGithub(w, configuration)
+	case AzureFormat:
+		return e.WriteAzure(w, configuration)
+	case GitlabDotenvFormat:
+		return e.WriteGitlabDotenv(w, configuration)
+	}
+	return fmt.Errorf("export format %s is not supported", format)
+}
+
+// WriteDotenv renders the configuration as a .env file, values with special characters are double quoted
+func (e *Exporter) WriteDotenv(w io.Writer, configuration *models.Configuration) error {
+	return e.write(w, configuration, func(variable Variable) (string, error) {
+		return fmt.Sprintf("%s=%s\n", variable.Name, quoteDotenvValue(variable.Value)), nil
+	})
+}
+
+// WriteGithub renders the configuration in the syntax of the GITHUB_OUTPUT and GITHUB_ENV files.
+// Multiline values are written with a delimiter that does not appear in the value
+func (e *Exporter) WriteGithub(w io.Writer, configuration *models.Configuration) error {
+	return e.write(w, configuration, func(variable Variable) (string, error) {
+		if !strings.ContainsAny(variable.Value, "\r\n") {
+			return fmt.Sprintf("%s=%s\n", variable.Name, variable.Value), nil
+		}
+		delimiter := githubDelimiter(variable.Value)
+		return fmt.Sprintf("%s<<%s\n%s\n%s\n", variable.Name, delimiter, variable.Value, delimiter), nil
+	})
+}
+
+// WriteAzure renders the configuration as Azure Pipelines logging commands
+func (e *Exporter) WriteAzure(w io.Writer, configuration *models.Configuration) error {
+	return e.write(w, configuration, func(variable Variable) (string, error) {
+		return fmt.Sprintf("##vso[task.setvariable variable=%s]%s\n", variable.Name, azureEscaper.Replace(variable.Value)), nil
+	})
+}
+
+// WriteGitlabDotenv renders the configuration as a GitLab dotenv report artifact.
+// GitLab does not support multiline or quoted values in these reports, so values are written as is
+func (e *Exporter) WriteGitlabDotenv(w io.Writer, configuration *models.Configuration) error {
+	return e.write(w, configuration, func(variable Variable) (string, error) {
+		if strings.ContainsAny(variable.Value, "\r\n") {
+			return "", fmt.Errorf("variable %s has a multiline value, which is not supported by gitlab dotenv reports", variable.Name)
+		}
+		return fmt.Sprintf("%s=%s\n", variable.Name, variable.Value), nil
+	})
+}
+
+func (e *Exporter) write(w io.Writer, configuration *models.Configuration, render func(Variable) (string, error)) error {
+	if configuration == nil {
+		return fmt.Errorf("configuration is empty")
+	}
+
+	for _, variable := range e.Variables(configuration) {
+		if !variableNameRegexp.MatchString(variable.Name) {
+			return fmt.Errorf("invalid variable name %s", variable.Name)
+		}
+
+		line, err := render(variable)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func quoteDotenvValue(value string) string {
+	if value != "" && !strings.ContainsAny(value, " \t\r\n\"'$#\\") {
+		return value
+	}
+	return fmt.Sprintf(`"%s"`, dotenvEscaper.Replace(value))
+}
+
+func githubDelimiter(value string) string {
+	hash := sha256.Sum256([]byte(value))
+	return fmt.Sprintf("ghadelimiter_%s", hex.EncodeToString(hash[:8]))
+}
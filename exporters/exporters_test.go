@@ -0,0 +1,124 @@
+package exporters
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/argonsecurity/go-environments/enums"
+	"github.com/argonsecurity/go-environments/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func testConfiguration() *models.Configuration {
+	return &models.Configuration{
+		CommitSha: "3ufl0xuicz460no9xck5j3xyyvk9w8m4j7bwr3ta",
+		Branch:    "main",
+		Repository: models.Repository{
+			Url:    "https://gitlab.com/test-organization/test-repo",
+			Source: enums.Gitlab,
+		},
+		Pipeline: models.Pipeline{
+			Entity: models.Entity{
+				Name: "test pipeline",
+			},
+		},
+		PullRequest: models.PullRequest{
+			Url: "line 1\nline 2",
+		},
+		Environment: enums.Gitlab,
+	}
+}
+
+func TestExporter_Variables(t *testing.T) {
+	variables := New("TEST_").Variables(testConfiguration())
+
+	assert.Equal(t, Variable{"TEST_ENVIRONMENT", "gitlab"}, variables[0])
+	assert.Contains(t, variables, Variable{"TEST_REPO_URL", "https://gitlab.com/test-organization/test-repo"})
+	for _, variable := range variables {
+		assert.True(t, strings.HasPrefix(variable.Name, "TEST_"))
+	}
+}
+
+func TestExporter_Write(t *testing.T) {
+	tests := []struct {
+		name         string
+		prefix       string
+		format       Format
+		wantContains []string
+		wantErr      bool
+	}{
+		{
+			name:   "Dotenv",
+			prefix: DefaultPrefix,
+			format: DotenvFormat,
+			wantContains: []string{
+				"ARGON_REPO_URL=https://gitlab.com/test-organization/test-repo\n",
+				"ARGON_PIPELINE_NAME=\"test pipeline\"\n",
+				"ARGON_PR_URL=\"line 1\\nline 2\"\n",
+				"ARGON_JOB_ID=\"\"\n",
+			},
+		},
+		{
+			name:   "Github",
+			prefix: DefaultPrefix,
+			format: GithubFormat,
+			wantContains: []string{
+				"ARGON_REPO_URL=https://gitlab.com/test-organization/test-repo\n",
+				"ARGON_PIPELINE_NAME=test pipeline\n",
+				"ARGON_PR_URL<<" + githubDelimiter("line 1\nline 2") + "\nline 1\nline 2\n" + githubDelimiter("line 1\nline 2") + "\n",
+			},
+		},
+		{
+			name:   "Azure",
+			prefix: "CI_",
+			format: AzureFormat,
+			wantContains: []string{
+				"##vso[task.setvariable variable=CI_REPO_URL]https://gitlab.com/test-organization/test-repo\n",
+				"##vso[task.setvariable variable=CI_PR_URL]line 1%0Aline 2\n",
+			},
+		},
+		{
+			name:    "Gitlab dotenv with multiline value",
+			prefix:  DefaultPrefix,
+			format:  GitlabDotenvFormat,
+			wantErr: true,
+		},
+		{
+			name:    "Invalid prefix",
+			prefix:  "ARGON-",
+			format:  DotenvFormat,
+			wantErr: true,
+		},
+		{
+			name:    "Unsupported format",
+			prefix:  DefaultPrefix,
+			format:  Format("xml"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			err := New(tt.prefix).Write(out, tt.format, testConfiguration())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Exporter.Write() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			for _, want := range tt.wantContains {
+				assert.Contains(t, out.String(), want)
+			}
+		})
+	}
+}
+
+func TestExporter_WriteGitlabDotenv(t *testing.T) {
+	configuration := testConfiguration()
+	configuration.PullRequest.Url = "https://gitlab.com/test-organization/test-repo/-/merge_requests/1"
+
+	out := &bytes.Buffer{}
+	assert.NoError(t, New(DefaultPrefix).WriteGitlabDotenv(out, configuration))
+	assert.Contains(t, out.String(), "ARGON_PIPELINE_NAME=test pipeline\n")
+	assert.Contains(t, out.String(), "ARGON_PR_URL=https://gitlab.com/test-organization/test-repo/-/merge_requests/1\n")
+	assert.Equal(t, len(New(DefaultPrefix).Variables(configuration)), strings.Count(out.String(), "\n"))
+}
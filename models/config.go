@@ -3,88 +3,92 @@ package models
 import "github.com/argonsecurity/go-environments/enums"
 
 type Runner struct {
-	Id           string
-	Name         string
-	OS           string
-	Distribution string
-	Architecture string
+	Id           string `json:"id,omitempty" yaml:"id,omitempty"`
+	Name         string `json:"name,omitempty" yaml:"name,omitempty"`
+	OS           string `json:"os,omitempty" yaml:"os,omitempty"`
+	Distribution string `json:"distribution,omitempty" yaml:"distribution,omitempty"`
+	Architecture string `json:"architecture,omitempty" yaml:"architecture,omitempty"`
 }
 
 type Entity struct {
-	Id   string
-	Name string
+	Id   string `json:"id,omitempty" yaml:"id,omitempty"`
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
 }
 
 type Ref struct {
-	Sha    string
-	Branch string
+	Sha    string `json:"sha,omitempty" yaml:"sha,omitempty"`
+	Branch string `json:"branch,omitempty" yaml:"branch,omitempty"`
 }
 
 type PullRequest struct {
-	Id        string
-	SourceRef Ref
-	TargetRef Ref
-	Url       string
+	Id        string `json:"id,omitempty" yaml:"id,omitempty"`
+	SourceRef Ref    `json:"sourceRef" yaml:"sourceRef"`
+	TargetRef Ref    `json:"targetRef" yaml:"targetRef"`
+	Url       string `json:"url,omitempty" yaml:"url,omitempty"`
 }
 
 type Repository struct {
-	Id       string
-	Name     string
-	FullName string
-	Url      string
-	CloneUrl string
-	Source   enums.Source
+	Id       string       `json:"id,omitempty" yaml:"id,omitempty"`
+	Name     string       `json:"name,omitempty" yaml:"name,omitempty"`
+	FullName string       `json:"fullName,omitempty" yaml:"fullName,omitempty"`
+	Url      string       `json:"url,omitempty" yaml:"url,omitempty"`
+	CloneUrl string       `json:"cloneUrl,omitempty" yaml:"cloneUrl,omitempty"`
+	Source   enums.Source `json:"source,omitempty" yaml:"source,omitempty"`
 }
 
 type Pipeline struct {
-	Entity
-	Path string
+	Entity `yaml:",inline"`
+	Path   string `json:"path,omitempty" yaml:"path,omitempty"`
 }
 
 type Configuration struct {
-	Url             string
-	SCMApiUrl       string
-	Builder         string
-	LocalPath       string
-	CommitSha       string
-	BeforeCommitSha string
-	Branch          string
-	ProjectId       string
-	Job             Entity
-	Run             BuildRun
-	Pipeline        Pipeline
-	Runner          Runner
-	Repository      Repository
-	PullRequest     PullRequest
-	Commits         []Commit
-	Organization    Entity
-	Pusher          Pusher
-	PipelinePaths   []string
-	Environment     enums.Source
-	ScmId           string
+	Url             string       `json:"url,omitempty" yaml:"url,omitempty"`
+	SCMApiUrl       string       `json:"scmApiUrl,omitempty" yaml:"scmApiUrl,omitempty"`
+	Builder         string       `json:"builder,omitempty" yaml:"builder,omitempty"`
+	LocalPath       string       `json:"localPath,omitempty" yaml:"localPath,omitempty"`
+	CommitSha       string       `json:"commitSha,omitempty" yaml:"commitSha,omitempty"`
+	BeforeCommitSha string       `json:"beforeCommitSha,omitempty" yaml:"beforeCommitSha,omitempty"`
+	Branch          string       `json:"branch,omitempty" yaml:"branch,omitempty"`
+	ProjectId       string       `json:"projectId,omitempty" yaml:"projectId,omitempty"`
+	Namespace       string       `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Job             Entity       `json:"job" yaml:"job"`
+	Run             BuildRun     `json:"run" yaml:"run"`
+	Pipeline        Pipeline     `json:"pipeline" yaml:"pipeline"`
+	Runner          Runner       `json:"runner" yaml:"runner"`
+	Repository      Repository   `json:"repository" yaml:"repository"`
+	PullRequest     PullRequest  `json:"pullRequest" yaml:"pullRequest"`
+	Commits         []Commit     `json:"commits,omitempty" yaml:"commits,omitempty"`
+	Organization    Entity       `json:"organization" yaml:"organization"`
+	Pusher          Pusher       `json:"pusher" yaml:"pusher"`
+	PipelinePaths   []string     `json:"pipelinePaths,omitempty" yaml:"pipelinePaths,omitempty"`
+	Environment     enums.Source `json:"environment" yaml:"environment"`
+	ScmId           string       `json:"scmId,omitempty" yaml:"scmId,omitempty"`
+
+	// Warnings are the non-fatal problems that happened while loading the configuration, they are not serialized
+	Warnings []Warning `json:"-" yaml:"-"`
 }
 
 type Author struct {
-	Email    string
-	Name     string
-	Username string
+	Email    string `json:"email,omitempty" yaml:"email,omitempty"`
+	Name     string `json:"name,omitempty" yaml:"name,omitempty"`
+	Username string `json:"username,omitempty" yaml:"username,omitempty"`
 }
 
 type Pusher struct {
-	Entity
-	Username string
-	Email    string
+	Entity   `yaml:",inline"`
+	Username string `json:"username,omitempty" yaml:"username,omitempty"`
+	Email    string `json:"email,omitempty" yaml:"email,omitempty"`
 }
 
 type BuildRun struct {
-	BuildId     string
-	BuildNumber string
+	BuildId     string `json:"buildId,omitempty" yaml:"buildId,omitempty"`
+	BuildNumber string `json:"buildNumber,omitempty" yaml:"buildNumber,omitempty"`
 }
 
 type Commit struct {
-	Id         string
-	Message    string
-	CommitDate string
-	Url        string
-	Author     Author
+	Id         string `json:"id,omitempty" yaml:"id,omitempty"`
+	Message    string `json:"message,omitempty" yaml:"message,omitempty"`
+	CommitDate string `json:"commitDate,omitempty" yaml:"commitDate,omitempty"`
+	Url        string `json:"url,omitempty" yaml:"url,omitempty"`
+	Author     Author `json:"author" yaml:"author"`
 }
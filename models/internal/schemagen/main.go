@@ -0,0 +1,27 @@
+// schemagen writes the JSON Schema of models.Configuration to the given file
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/argonsecurity/go-environments/models"
+)
+
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintln(os.Stderr, "usage: schemagen <output file>")
+		os.Exit(2)
+	}
+
+	schema, err := models.GenerateConfigurationSchema()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if err := os.WriteFile(os.Args[1], schema, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,128 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+type jsonSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Ref                  string                 `json:"$ref,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Const                string                 `json:"const,omitempty"`
+	Items                *jsonSchema            `json:"items,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties *bool                  `json:"additionalProperties,omitempty"`
+	Definitions          map[string]*jsonSchema `json:"definitions,omitempty"`
+}
+
+// GenerateConfigurationSchema generates the JSON Schema of a serialized Configuration from its json tags.
+// Fields without omitempty are required, nested structs are referenced through the schema definitions
+func GenerateConfigurationSchema() ([]byte, error) {
+	definitions := map[string]*jsonSchema{}
+	root, err := structSchema(reflect.TypeOf(Configuration{}), definitions)
+	if err != nil {
+		return nil, err
+	}
+
+	root.Schema = jsonSchemaDraft
+	root.Title = "Configuration"
+	root.Properties["schemaVersion"] = &jsonSchema{Type: "string", Const: ConfigurationSchemaVersion}
+	root.Required = append([]string{"schemaVersion"}, root.Required...)
+	root.Definitions = definitions
+
+	data, err := json.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+func structSchema(t reflect.Type, definitions map[string]*jsonSchema) (*jsonSchema, error) {
+	additionalProperties := false
+	schema := &jsonSchema{
+		Type:                 "object",
+		Properties:           map[string]*jsonSchema{},
+		AdditionalProperties: &additionalProperties,
+	}
+	if err := addStructFields(schema, t, definitions); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+func addStructFields(schema *jsonSchema, t reflect.Type, definitions map[string]*jsonSchema) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, omitEmpty := parseJsonTag(field)
+
+		if field.Anonymous && name == "" {
+			if err := addStructFields(schema, field.Type, definitions); err != nil {
+				return err
+			}
+			continue
+		}
+		if name == "" || name == "-" {
+			continue
+		}
+
+		fieldSchema, err := typeSchema(field.Type, definitions)
+		if err != nil {
+			return fmt.Errorf("field %s.%s: %w", t.Name(), field.Name, err)
+		}
+		schema.Properties[name] = fieldSchema
+		if !omitEmpty {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	return nil
+}
+
+func typeSchema(t reflect.Type, definitions map[string]*jsonSchema) (*jsonSchema, error) {
+	switch t.Kind() {
+	case reflect.String:
+		return &jsonSchema{Type: "string"}, nil
+	case reflect.Slice:
+		items, err := typeSchema(t.Elem(), definitions)
+		if err != nil {
+			return nil, err
+		}
+		return &jsonSchema{Type: "array", Items: items}, nil
+	case reflect.Struct:
+		if _, ok := definitions[t.Name()]; !ok {
+			definitions[t.Name()] = nil // reserve the name in case of recursive types
+			definition, err := structSchema(t, definitions)
+			if err != nil {
+				return nil, err
+			}
+			definitions[t.Name()] = definition
+		}
+		return &jsonSchema{Ref: fmt.Sprintf("#/definitions/%s", t.Name())}, nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", t.Kind())
+}
+
+func parseJsonTag(field reflect.StructField) (string, bool) {
+	tag, ok := field.Tag.Lookup("json")
+	if !ok {
+		if field.Anonymous {
+			return "", false
+		}
+		return field.Name, false
+	}
+
+	parts := strings.Split(tag, ",")
+	omitEmpty := false
+	for _, option := range parts[1:] {
+		if option == "omitempty" {
+			omitEmpty = true
+		}
+	}
+	return parts[0], omitEmpty
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	_ "embed"
+	"encoding/json"
+)
+
+//go:generate go run ./internal/schemagen configuration.schema.json
+
+// ConfigurationSchemaVersion is the version of the serialized Configuration format.
+// It is written as "schemaVersion" and should be bumped on any incompatible change to the json/yaml tags
+const ConfigurationSchemaVersion = "1"
+
+var (
+	// ConfigurationSchema is the JSON Schema of a serialized Configuration
+	//go:embed configuration.schema.json
+	ConfigurationSchema []byte
+)
+
+type configurationFields Configuration
+
+type versionedConfiguration struct {
+	SchemaVersion       string `json:"schemaVersion" yaml:"schemaVersion"`
+	configurationFields `yaml:",inline"`
+}
+
+func newVersionedConfiguration(c Configuration) versionedConfiguration {
+	return versionedConfiguration{
+		SchemaVersion:       ConfigurationSchemaVersion,
+		configurationFields: configurationFields(c),
+	}
+}
+
+// MarshalJSON serializes the configuration with its schema version
+func (c Configuration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(newVersionedConfiguration(c))
+}
+
+// MarshalYAML serializes the configuration with its schema version
+func (c Configuration) MarshalYAML() (interface{}, error) {
+	return newVersionedConfiguration(c), nil
+}
@@ -0,0 +1,139 @@
+package models
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/argonsecurity/go-environments/enums"
+	schemavalidator "github.com/argonsecurity/go-environments/schema-validator"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+var testConfiguration = Configuration{
+	Url:       "https://github.com",
+	SCMApiUrl: "https://api.github.com",
+	LocalPath: "/tmp/repo",
+	CommitSha: "2c6e3880fd94ddb7ef72d34e683cdc0c47bec6e6",
+	Branch:    "refs/heads/main",
+	Job: Entity{
+		Id:   "test",
+		Name: "test",
+	},
+	Run: BuildRun{
+		BuildId:     "3008488429",
+		BuildNumber: "3",
+	},
+	Pipeline: Pipeline{
+		Entity: Entity{
+			Id:   "test",
+			Name: "test",
+		},
+		Path: ".github/workflows/test.yml",
+	},
+	Repository: Repository{
+		Id:       "507947722",
+		Name:     "test-repo",
+		FullName: "test-org/test-repo",
+		Url:      "https://github.com/test-org/test-repo",
+		CloneUrl: "https://github.com/test-org/test-repo.git",
+		Source:   enums.Github,
+	},
+	PullRequest: PullRequest{
+		SourceRef: Ref{
+			Branch: "feature",
+		},
+	},
+	Commits: []Commit{
+		{
+			Id: "2c6e3880fd94ddb7ef72d34e683cdc0c47bec6e6",
+			Author: Author{
+				Username: "username123",
+			},
+		},
+	},
+	Pusher: Pusher{
+		Entity: Entity{
+			Id: "19283746",
+		},
+		Username: "username123",
+	},
+	PipelinePaths: []string{"/tmp/repo/.github/workflows/test.yml"},
+	Environment:   enums.Github,
+	ScmId:         "b30f418cdcc9970849d3d031de5df54f",
+}
+
+func TestConfigurationSchemaIsUpToDate(t *testing.T) {
+	generated, err := GenerateConfigurationSchema()
+	assert.NoError(t, err)
+
+	published, err := os.ReadFile("configuration.schema.json")
+	assert.NoError(t, err)
+	assert.Equal(t, string(published), string(generated), "run go generate ./models")
+	assert.Equal(t, published, ConfigurationSchema)
+}
+
+func TestConfiguration_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(testConfiguration)
+	assert.NoError(t, err)
+
+	var fields map[string]any
+	assert.NoError(t, json.Unmarshal(data, &fields))
+	assert.Equal(t, ConfigurationSchemaVersion, fields["schemaVersion"])
+	assert.Equal(t, "github", fields["environment"])
+	assert.Equal(t, map[string]any{"id": "test", "name": "test", "path": ".github/workflows/test.yml"}, fields["pipeline"])
+	assert.Equal(t, map[string]any{"sourceRef": map[string]any{"branch": "feature"}, "targetRef": map[string]any{}}, fields["pullRequest"])
+	assert.NotContains(t, fields, "beforeCommitSha")
+
+	var got Configuration
+	assert.NoError(t, json.Unmarshal(data, &got))
+	assert.Equal(t, testConfiguration, got)
+
+	assert.NoError(t, schemavalidator.ValidateJson(data, ConfigurationSchema))
+
+	data, err = json.Marshal(&Configuration{})
+	assert.NoError(t, err)
+	assert.NoError(t, schemavalidator.ValidateJson(data, ConfigurationSchema))
+}
+
+func TestConfiguration_MarshalYAML(t *testing.T) {
+	data, err := yaml.Marshal(testConfiguration)
+	assert.NoError(t, err)
+
+	var fields map[string]any
+	assert.NoError(t, yaml.Unmarshal(data, &fields))
+	assert.Equal(t, ConfigurationSchemaVersion, fields["schemaVersion"])
+	assert.Equal(t, map[string]any{"id": "19283746", "username": "username123"}, fields["pusher"])
+
+	var got Configuration
+	assert.NoError(t, yaml.Unmarshal(data, &got))
+	assert.Equal(t, testConfiguration, got)
+
+	assert.NoError(t, schemavalidator.ValidateYaml(data, ConfigurationSchema))
+}
+
+func TestConfigurationSchema_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{
+			name: "Missing schema version",
+			data: `{"environment": "github", "job": {}, "run": {}, "pipeline": {}, "runner": {}, "repository": {}, "pullRequest": {"sourceRef": {}, "targetRef": {}}, "organization": {}, "pusher": {}}`,
+		},
+		{
+			name: "Unknown field",
+			data: `{"schemaVersion": "1", "Url": "https://github.com", "environment": "github", "job": {}, "run": {}, "pipeline": {}, "runner": {}, "repository": {}, "pullRequest": {"sourceRef": {}, "targetRef": {}}, "organization": {}, "pusher": {}}`,
+		},
+		{
+			name: "Wrong schema version",
+			data: `{"schemaVersion": "0", "environment": "github", "job": {}, "run": {}, "pipeline": {}, "runner": {}, "repository": {}, "pullRequest": {"sourceRef": {}, "targetRef": {}}, "organization": {}, "pusher": {}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, schemavalidator.ValidateJson([]byte(tt.data), ConfigurationSchema))
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package models
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+type Severity string
+
+const (
+	// SeverityCritical is a gap that makes the configuration unusable, i.e. a missing repository or commit
+	SeverityCritical Severity = "critical"
+	// SeverityWarning is a gap in a field that is expected to be filled but is not required
+	SeverityWarning Severity = "warning"
+)
+
+// ExpectedField is a field that an environment is expected to fill, and the variable it is filled from
+type ExpectedField struct {
+	// Field is the json path of the field, i.e. repository.url
+	Field     string   `json:"field"`
+	SourceEnv string   `json:"sourceEnv,omitempty"`
+	Severity  Severity `json:"severity"`
+}
+
+// ValidationDiagnostic is an expected field that was not filled
+type ValidationDiagnostic struct {
+	Field     string   `json:"field"`
+	SourceEnv string   `json:"sourceEnv,omitempty"`
+	Severity  Severity `json:"severity"`
+	Message   string   `json:"message"`
+}
+
+// ValidationError is returned when a configuration has critical gaps
+type ValidationError struct {
+	Diagnostics []ValidationDiagnostic
+}
+
+func (e *ValidationError) Error() string {
+	fields := []string{}
+	for _, diagnostic := range e.Diagnostics {
+		fields = append(fields, diagnostic.Field)
+	}
+	return fmt.Sprintf("configuration is missing critical fields: %s", strings.Join(fields, ", "))
+}
+
+// ValidateConfiguration checks that the expected fields of the configuration are filled,
+// and returns a diagnostic for every expected field that is empty
+func ValidateConfiguration(configuration *Configuration, expectedFields []ExpectedField) []ValidationDiagnostic {
+	diagnostics := []ValidationDiagnostic{}
+	for _, expected := range expectedFields {
+		value, ok := lookupField(reflect.ValueOf(configuration), strings.Split(expected.Field, "."))
+		if !ok {
+			diagnostics = append(diagnostics, newDiagnostic(expected, fmt.Sprintf("%s is not a configuration field", expected.Field)))
+			continue
+		}
+		if value.IsZero() || (value.Kind() == reflect.Slice && value.Len() == 0) {
+			diagnostics = append(diagnostics, newDiagnostic(expected, missingFieldMessage(expected)))
+		}
+	}
+	return diagnostics
+}
+
+// CriticalDiagnostics returns the diagnostics with critical severity
+func CriticalDiagnostics(diagnostics []ValidationDiagnostic) []ValidationDiagnostic {
+	critical := []ValidationDiagnostic{}
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Severity == SeverityCritical {
+			critical = append(critical, diagnostic)
+		}
+	}
+	return critical
+}
+
+// IsConfigurationField checks if a json path, i.e. repository.url, is a field of the configuration
+func IsConfigurationField(path string) bool {
+	_, ok := lookupField(reflect.ValueOf(&Configuration{}), strings.Split(path, "."))
+	return ok
+}
+
+func newDiagnostic(expected ExpectedField, message string) ValidationDiagnostic {
+	return ValidationDiagnostic{
+		Field:     expected.Field,
+		SourceEnv: expected.SourceEnv,
+		Severity:  expected.Severity,
+		Message:   message,
+	}
+}
+
+func missingFieldMessage(expected ExpectedField) string {
+	if expected.SourceEnv == "" {
+		return fmt.Sprintf("%s is empty", expected.Field)
+	}
+	return fmt.Sprintf("%s is empty, it is filled from %s", expected.Field, expected.SourceEnv)
+}
+
+// lookupField finds a field by the json names of its path
+func lookupField(value reflect.Value, path []string) (reflect.Value, bool) {
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return reflect.Value{}, false
+		}
+		value = value.Elem()
+	}
+	if len(path) == 0 {
+		return value, true
+	}
+	if value.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+
+	t := value.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _ := parseJsonTag(field)
+		if field.Anonymous && name == "" {
+			if found, ok := lookupField(value.Field(i), path); ok {
+				return found, true
+			}
+			continue
+		}
+		if name == path[0] {
+			return lookupField(value.Field(i), path[1:])
+		}
+	}
+	return reflect.Value{}, false
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateConfiguration(t *testing.T) {
+	configuration := &Configuration{
+		CommitSha: "commit",
+		Repository: Repository{
+			Url: "https://github.com/test-organization/test-repo",
+		},
+		Pipeline: Pipeline{
+			Entity: Entity{
+				Name: "pipeline",
+			},
+		},
+	}
+	expectedFields := []ExpectedField{
+		{Field: "commitSha", SourceEnv: "COMMIT", Severity: SeverityCritical},
+		{Field: "repository.url", SourceEnv: "REPO_URL", Severity: SeverityCritical},
+		{Field: "pipeline.name", SourceEnv: "PIPELINE", Severity: SeverityWarning},
+		{Field: "repository.cloneUrl", SourceEnv: "CLONE_URL", Severity: SeverityCritical},
+		{Field: "pipelinePaths", Severity: SeverityWarning},
+		{Field: "repository.unknown", Severity: SeverityWarning},
+	}
+
+	diagnostics := ValidateConfiguration(configuration, expectedFields)
+	assert.Equal(t, []ValidationDiagnostic{
+		{Field: "repository.cloneUrl", SourceEnv: "CLONE_URL", Severity: SeverityCritical, Message: "repository.cloneUrl is empty, it is filled from CLONE_URL"},
+		{Field: "pipelinePaths", Severity: SeverityWarning, Message: "pipelinePaths is empty"},
+		{Field: "repository.unknown", Severity: SeverityWarning, Message: "repository.unknown is not a configuration field"},
+	}, diagnostics)
+
+	critical := CriticalDiagnostics(diagnostics)
+	assert.Len(t, critical, 1)
+	assert.EqualError(t, &ValidationError{Diagnostics: critical}, "configuration is missing critical fields: repository.cloneUrl")
+}
+
+func TestIsConfigurationField(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "commitSha", want: true},
+		{path: "repository.url", want: true},
+		{path: "pipeline.name", want: true},
+		{path: "pullRequest.sourceRef.branch", want: true},
+		{path: "pusher.username", want: true},
+		{path: "repository", want: true},
+		{path: "repository.missing", want: false},
+		{path: "commitSha.id", want: false},
+		{path: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsConfigurationField(tt.path))
+		})
+	}
+}
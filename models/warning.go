@@ -0,0 +1,41 @@
+package models
+
+import "fmt"
+
+type WarningCode string
+
+const (
+	// GitRemoteUrlWarning is reported when the remote URL of the repository cannot be read and the URL is built from variables instead
+	GitRemoteUrlWarning WarningCode = "git_remote_url"
+	// GitBranchWarning is reported when a branch is skipped while searching for the branch of a commit
+	GitBranchWarning WarningCode = "git_branch"
+	// PipelinePathsWarning is reported when a directory or file cannot be read while searching for pipeline files
+	PipelinePathsWarning WarningCode = "pipeline_paths"
+)
+
+// Warning is a non-fatal problem that happened while loading a configuration, the configuration is still usable
+type Warning struct {
+	Code    WarningCode
+	Message string
+	Err     error
+}
+
+// NewWarning creates a warning
+func NewWarning(code WarningCode, err error, format string, args ...any) Warning {
+	return Warning{
+		Code:    code,
+		Message: fmt.Sprintf(format, args...),
+		Err:     err,
+	}
+}
+
+func (w Warning) Error() string {
+	if w.Err == nil {
+		return w.Message
+	}
+	return fmt.Sprintf("%s: %s", w.Message, w.Err)
+}
+
+func (w Warning) Unwrap() error {
+	return w.Err
+}
@@ -0,0 +1,56 @@
+package environments
+
+import (
+	"github.com/argonsecurity/go-environments/models"
+)
+
+// ExpectedFieldsGetter is implemented by environments that know which configuration fields they are expected to fill
+type ExpectedFieldsGetter interface {
+	// ExpectedFields get the fields the environment is expected to fill and the variables they are filled from
+	ExpectedFields() []models.ExpectedField
+}
+
+// ConfigurationOption changes how GetConfiguration loads the configuration
+type ConfigurationOption func(*configurationOptions)
+
+type configurationOptions struct {
+	failOnCriticalGaps bool
+}
+
+// FailOnCriticalGaps makes GetConfiguration fail with a *models.ValidationError when a critical field of the configuration is empty
+func FailOnCriticalGaps() ConfigurationOption {
+	return func(options *configurationOptions) {
+		options.failOnCriticalGaps = true
+	}
+}
+
+// Validate checks the configuration against the fields the environment is expected to fill,
+// and returns a diagnostic for every expected field that is empty.
+// Environments that do not implement ExpectedFieldsGetter have no expected fields
+func Validate(env Environment, configuration *models.Configuration) []models.ValidationDiagnostic {
+	getter, ok := env.(ExpectedFieldsGetter)
+	if !ok {
+		return []models.ValidationDiagnostic{}
+	}
+	return models.ValidateConfiguration(configuration, getter.ExpectedFields())
+}
+
+// GetConfiguration gets the configuration of the environment with the given options
+func GetConfiguration(env Environment, options ...ConfigurationOption) (*models.Configuration, error) {
+	opts := &configurationOptions{}
+	for _, option := range options {
+		option(opts)
+	}
+
+	configuration, err := env.GetConfiguration()
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.failOnCriticalGaps {
+		if critical := models.CriticalDiagnostics(Validate(env, configuration)); len(critical) > 0 {
+			return nil, &models.ValidationError{Diagnostics: critical}
+		}
+	}
+	return configuration, nil
+}
@@ -0,0 +1,59 @@
+package environments
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/argonsecurity/go-environments/environments/gitlab"
+	"github.com/argonsecurity/go-environments/environments/testutils"
+	"github.com/argonsecurity/go-environments/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpectedFields(t *testing.T) {
+	for source, env := range environmentMapping {
+		getter, ok := env.(ExpectedFieldsGetter)
+		if !ok {
+			continue
+		}
+		for _, expected := range getter.ExpectedFields() {
+			assert.True(t, models.IsConfigurationField(expected.Field), "%s expects unknown field %s", source, expected.Field)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	envCleanup := testutils.SetEnvsFromFile("environments/gitlab/testdata/gitlab-ci-main-env.json")
+	t.Cleanup(envCleanup)
+
+	env := gitlab.New()
+	configuration, err := env.GetConfiguration()
+	assert.NoError(t, err)
+	assert.Empty(t, Validate(env, configuration))
+	assert.Empty(t, Validate(&testEnvironment{name: "test"}, &models.Configuration{}))
+
+	configuration.CommitSha = ""
+	assert.Equal(t, []models.ValidationDiagnostic{{
+		Field:     "commitSha",
+		SourceEnv: "CI_COMMIT_SHA",
+		Severity:  models.SeverityCritical,
+		Message:   "commitSha is empty, it is filled from CI_COMMIT_SHA",
+	}}, Validate(env, configuration))
+}
+
+func TestGetConfiguration(t *testing.T) {
+	envCleanup := testutils.SetEnvsFromFile("environments/gitlab/testdata/gitlab-ci-main-env.json")
+	t.Cleanup(envCleanup)
+	t.Setenv("CI_COMMIT_SHA", "")
+	t.Setenv("CI_RUNNER_ID", "")
+
+	configuration, err := GetConfiguration(gitlab.New())
+	assert.NoError(t, err)
+	assert.NotNil(t, configuration)
+
+	_, err = GetConfiguration(gitlab.New(), FailOnCriticalGaps())
+	var validationErr *models.ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+	assert.Len(t, validationErr.Diagnostics, 1)
+	assert.Equal(t, "commitSha", validationErr.Diagnostics[0].Field)
+}